@@ -1,52 +1,30 @@
 package main
 
 import (
-	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 
-	_ "github.com/go-sql-driver/mysql"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"go-films-api/internal/config"
+	"go-films-api/internal/database"
 )
 
 func main() {
-	user := os.Getenv("DB_USER")
-	pass := os.Getenv("DB_PASS")
-	host := os.Getenv("DB_HOST")
-	name := os.Getenv("DB_NAME")
-
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:3306)/%s?multiStatements=true", user, pass, host, name)
-
-	db, err := sql.Open("mysql", dsn)
-
-	if err != nil {
-		log.Fatalf("Could not connect to MySQL: %v", err)
+	cfg, err := config.Load(os.Args[0], os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
 	}
-	defer db.Close()
-
-	driver, err := mysql.WithInstance(db, &mysql.Config{})
-
 	if err != nil {
-		log.Fatalf("Could not create mysql driver: %v", err)
+		log.Fatalf("invalid configuration: %v", err)
 	}
-
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"mysql",
-		driver,
-	)
-
-	if err != nil {
-		log.Fatalf("Could not create migrate instance: %v", err)
+	if err := cfg.ValidateDatabase(); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
 	}
 
-	err = m.Up()
-
-	if err != nil && err != migrate.ErrNoChange {
-		log.Fatalf("Migration failed: %v", err)
+	if err := database.Migrate(cfg.Database, "migrations"); err != nil {
+		log.Fatalf("Could not apply %s migrations: %v", cfg.Database.Driver, err)
 	}
 
 	fmt.Println("Migrations applied successfully!")
@@ -14,72 +14,279 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"flag"
+	"go-films-api/internal/config"
+	"go-films-api/internal/database"
 	"go-films-api/internal/delivery/http"
 	"go-films-api/internal/delivery/http/middleware"
+	"go-films-api/internal/domain"
+	"go-films-api/internal/metrics"
 	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
+	"go-films-api/internal/tracing"
 	"go-films-api/internal/usecase"
 	"log"
+	nethttp "net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	_ "go-films-api/docs"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"gorm.io/driver/mysql"
-	"gorm.io/gorm"
 )
 
 func main() {
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASS")
-	dbHost := os.Getenv("DB_HOST")
-	dbName := os.Getenv("DB_NAME")
-	dbPort := os.Getenv("DB_PORT")
-
-	port := os.Getenv("APP_PORT")
-	if port == "" {
-		port = "8080"
+	cfg, err := config.Load(os.Args[0], os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
 	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		dbUser, dbPass, dbHost, dbName, dbPort)
+	// Uploaded images are kept on disk and served by the API, unless
+	// BLOB_STORE=s3 puts them in an S3-compatible bucket
+	var blobStore storage.BlobStore
+	switch cfg.Media.Store {
+	case config.StoreLocal:
+		blobStore = storage.NewLocalBlobStore(cfg.Media.Dir, cfg.Media.URL)
+	case config.StoreS3:
+		s3Store, err := storage.NewS3BlobStore(cfg.Media.S3)
+		if err != nil {
+			log.Fatalf("invalid S3 configuration: %v", err)
+		}
+		blobStore = s3Store
+	}
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := database.Open(cfg.Database)
 	if err != nil {
 		log.Fatalf("Failed to connect to DB: %v", err)
 	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to connect to DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	appMetrics := metrics.New()
+	if err := appMetrics.InstrumentDB(db, cfg.Database.Name); err != nil {
+		log.Fatalf("could not instrument DB: %v", err)
+	}
+
+	// Spans go to TRACING_EXPORTER; the provider must be set before the
+	// database plugin looks it up
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("could not set up tracing: %v", err)
+	}
+	if err := tracing.InstrumentDB(db, cfg.Database.Name); err != nil {
+		log.Fatalf("could not instrument DB: %v", err)
+	}
+
+	// Cancelled on SIGINT or SIGTERM, which start a graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	userRepo := repository.NewUserRepositoryGorm(db)
-	userService := usecase.NewUserService(userRepo)
+	tokenRepo := repository.NewTokenRepositoryGorm(db)
+	userService := usecase.TraceUserService(usecase.NewUserService(userRepo, tokenRepo, cfg.Auth))
+	go usecase.RunTokenCleanup(ctx, userService, usecase.TokenCleanupInterval)
+
+	authHandler := http.NewAuthHandler(userService, appMetrics)
+	adminHandler := http.NewAdminHandler(userService)
 
-	authHandler := http.NewAuthHandler(userService)
+	genreRepo := repository.NewGenreRepositoryGorm(db)
+	genreService := usecase.NewGenreService(genreRepo)
+	genreHandler := http.NewGenreHandler(genreService)
+
+	personRepo := repository.NewPersonRepositoryGorm(db)
+	personService := usecase.NewPersonService(personRepo)
 
 	filmRepo := repository.NewFilmRepositoryGorm(db)
-	filmService := usecase.NewFilmService(filmRepo)
+	filmService := usecase.TraceFilmService(usecase.NewFilmService(filmRepo, genreRepo, personRepo, blobStore, cfg.Auth))
 	filmHandler := http.NewFilmHandler(filmService)
+	personHandler := http.NewPersonHandler(personService, filmService)
+
+	if cfg.Trash.RetentionDays > 0 {
+		retention := time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour
+		go usecase.RunTrashRetention(ctx, filmService, retention, usecase.TrashRetentionInterval)
+	}
+
+	imageRepo := repository.NewImageRepositoryGorm(db)
+	imageService := usecase.NewImageService(imageRepo, filmRepo, blobStore)
+	imageHandler := http.NewImageHandler(imageService)
+
+	reviewRepo := repository.NewReviewRepositoryGorm(db)
+	reviewService := usecase.NewReviewService(reviewRepo, filmRepo)
+	reviewHandler := http.NewReviewHandler(reviewService)
+
+	favoriteRepo := repository.NewFavoriteRepositoryGorm(db)
+	favoriteService := usecase.NewFavoriteService(favoriteRepo, filmRepo)
+	favoriteHandler := http.NewFavoriteHandler(favoriteService)
+
+	auditRepo := repository.NewAuditRepositoryGorm(db)
+	auditService := usecase.NewAuditService(auditRepo, filmRepo)
+	auditHandler := http.NewAuditHandler(auditService)
+
+	watchlistRepo := repository.NewWatchlistRepositoryGorm(db)
+	watchlistService := usecase.NewWatchlistService(watchlistRepo, filmRepo)
+	watchlistHandler := http.NewWatchlistHandler(watchlistService)
+
+	healthHandler := http.NewHealthHandler(func(ctx context.Context) (*database.MigrationStatus, error) {
+		return database.Ping(ctx, db)
+	})
 
-	authMiddleware := middleware.JWTMiddleware()
+	authMiddleware := middleware.JWTMiddleware(cfg.Auth, tokenRepo)
+	manageCatalog := middleware.RequirePermission(domain.PermManageCatalog)
+	manageUsers := middleware.RequirePermission(domain.PermManageUsers)
+	purgeFilms := middleware.RequirePermission(domain.PermPurgeFilms)
+	viewAudit := middleware.RequirePermission(domain.PermViewAudit)
 
 	r := gin.Default()
+	r.Use(middleware.Tracing())
+	r.Use(middleware.Metrics(appMetrics))
+	r.Use(middleware.ErrorHandler())
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	r.POST("/register", authHandler.Register)
-	r.POST("/login", authHandler.Login)
+	// Probes are public and cheap, so orchestrators can call them often
+	r.GET("/healthz", healthHandler.Healthz)
+	r.GET("/readyz", healthHandler.Readyz)
+	r.GET("/metrics", gin.WrapH(appMetrics.Handler()))
 
-	protected := r.Group("/")
+	// Images are public, like the rest of a film's artwork on the web
+	if _, ok := blobStore.(*storage.LocalBlobStore); ok && strings.HasPrefix(cfg.Media.URL, "/") {
+		r.Static(cfg.Media.URL, cfg.Media.Dir)
+	}
+
+	api := r.Group("/")
+	api.Use(middleware.Timeout(cfg.Server.RequestTimeout))
+
+	api.POST("/register", authHandler.Register)
+	api.POST("/login", authHandler.Login)
+	api.POST("/token/refresh", authHandler.Refresh)
+
+	// Bulk transfers are bounded by their size rather than by REQUEST_TIMEOUT
+	bulk := r.Group("/")
+	bulk.Use(authMiddleware)
+	{
+		bulk.GET("/films/export", filmHandler.ExportFilms)
+		bulk.POST("/films/import", filmHandler.ImportFilms)
+		bulk.POST("/films/:id/images", imageHandler.UploadFilmImage)
+	}
+
+	protected := api.Group("/")
 	protected.Use(authMiddleware)
 	{
+		protected.POST("/logout", authHandler.Logout)
+
 		protected.GET("/films", filmHandler.GetFilms)
 		protected.GET("/films/:id", filmHandler.GetFilmDetails)
 		protected.POST("/films", filmHandler.CreateFilm)
 		protected.PUT("/films/:id", filmHandler.UpdateFilm)
+		protected.PATCH("/films/:id", filmHandler.PatchFilm)
 		protected.DELETE("/films/:id", filmHandler.DeleteFilm)
+		protected.POST("/films/:id/restore", filmHandler.RestoreFilm)
+		protected.GET("/films/:id/history", auditHandler.GetFilmHistory)
+		protected.DELETE("/films/:id/images/:imageID", imageHandler.DeleteFilmImage)
+		protected.GET("/audit", viewAudit, auditHandler.GetAuditEvents)
+
+		protected.GET("/films/:id/reviews", reviewHandler.GetReviews)
+		protected.POST("/films/:id/reviews", reviewHandler.CreateReview)
+		protected.PUT("/films/:id/reviews/:reviewID", reviewHandler.UpdateReview)
+		protected.DELETE("/films/:id/reviews/:reviewID", reviewHandler.DeleteReview)
+
+		protected.GET("/genres", genreHandler.GetGenres)
+		protected.GET("/genres/:slug", genreHandler.GetGenre)
+		protected.POST("/genres", manageCatalog, genreHandler.CreateGenre)
+		protected.PUT("/genres/:slug", manageCatalog, genreHandler.UpdateGenre)
+		protected.DELETE("/genres/:slug", manageCatalog, genreHandler.DeleteGenre)
+
+		protected.GET("/people", personHandler.GetPeople)
+		protected.GET("/people/:id", personHandler.GetPerson)
+		protected.POST("/people", manageCatalog, personHandler.CreatePerson)
+		protected.PUT("/people/:id", manageCatalog, personHandler.UpdatePerson)
+		protected.DELETE("/people/:id", manageCatalog, personHandler.DeletePerson)
+		protected.GET("/people/:id/films", personHandler.GetPersonFilms)
+
+		protected.GET("/me/trash", filmHandler.GetTrash)
+
+		protected.GET("/me/favorites", favoriteHandler.GetFavorites)
+		protected.POST("/me/favorites/:filmID", favoriteHandler.AddFavorite)
+		protected.DELETE("/me/favorites/:filmID", favoriteHandler.RemoveFavorite)
+
+		protected.GET("/me/lists", watchlistHandler.GetWatchlists)
+		protected.POST("/me/lists", watchlistHandler.CreateWatchlist)
+		protected.GET("/me/lists/:id", watchlistHandler.GetWatchlist)
+		protected.PUT("/me/lists/:id", watchlistHandler.UpdateWatchlist)
+		protected.DELETE("/me/lists/:id", watchlistHandler.DeleteWatchlist)
+		protected.GET("/me/lists/:id/items", watchlistHandler.GetWatchlistItems)
+		protected.POST("/me/lists/:id/items", watchlistHandler.AddWatchlistItem)
+		protected.PUT("/me/lists/:id/items/:filmID", watchlistHandler.MoveWatchlistItem)
+		protected.DELETE("/me/lists/:id/items/:filmID", watchlistHandler.RemoveWatchlistItem)
+		protected.GET("/lists/:id", watchlistHandler.GetWatchlist)
+	}
+
+	admin := protected.Group("/admin")
+	{
+		admin.GET("/users", manageUsers, adminHandler.GetUsers)
+		admin.GET("/users/:id", manageUsers, adminHandler.GetUser)
+		admin.PUT("/users/:id/role", manageUsers, adminHandler.UpdateUserRole)
+
+		admin.GET("/trash", purgeFilms, filmHandler.GetAllTrash)
+		admin.DELETE("/trash/:id", purgeFilms, filmHandler.PurgeFilm)
 	}
 
-	if err := r.Run(":" + port); err != nil {
-		log.Fatalf("could not start server: %v", err)
+	srv := &nethttp.Server{
+		Addr:              ":" + cfg.Server.Port,
+		Handler:           r,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	// A server that fails to listen shuts down like one that got a signal,
+	// so buffered spans are still flushed, and then exits with an error
+	failed := false
+	select {
+	case err := <-serveErr:
+		log.Printf("could not start server: %v", err)
+		failed = true
+	case <-ctx.Done():
+	}
+	stop()
+
+	// New connections are refused from here on; in-flight requests get
+	// SHUTDOWN_TIMEOUT to finish before the database is closed under them
+	log.Printf("shutting down, waiting up to %s for in-flight requests", cfg.Server.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("could not finish in-flight requests: %v", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Printf("could not flush spans: %v", err)
+	}
+	if failed {
+		// os.Exit skips the deferred calls
+		sqlDB.Close()
+		os.Exit(1)
 	}
+	log.Println("server stopped")
 }
@@ -0,0 +1,379 @@
+// Package config loads the settings of the server and the migrate command.
+// Every setting has a default and can be changed, from lowest to highest
+// precedence, in a YAML or TOML config file, in an environment variable and
+// with a command-line flag.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"go-films-api/internal/database"
+	"go-films-api/internal/storage"
+)
+
+// Config holds every setting, typed and with defaults filled in.
+type Config struct {
+	Server   Server
+	Database database.Config
+	Auth     Auth
+	Trash    Trash
+	Media    Media
+	Tracing  Tracing
+}
+
+// Server configures the HTTP server. The read, write and idle timeouts are
+// those of http.Server, and 0 disables them; reads and writes are unbounded
+// by default since they include bulk transfers. ShutdownTimeout is how long
+// in-flight requests get to finish on SIGTERM.
+type Server struct {
+	Port string
+	// RequestTimeout bounds every request but bulk transfers; 0 disables it
+	RequestTimeout    time.Duration
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+}
+
+// Auth configures the tokens handed out on login. JWTSecret signs them, and
+// the list cursors of the API.
+type Auth struct {
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+type Trash struct {
+	// RetentionDays is how long films stay in the trash; 0 keeps them forever
+	RetentionDays int
+}
+
+// Media says where uploaded images are kept: on disk below Dir, served
+// under URL, or in the S3 bucket described by S3.
+type Media struct {
+	Store string
+	Dir   string
+	URL   string
+	S3    storage.S3Config
+}
+
+// Tracing says where the OpenTelemetry spans of the API go: nowhere, to
+// standard output, or over OTLP/HTTP to OTLPEndpoint, a collector URL such as
+// http://localhost:4318.
+type Tracing struct {
+	Exporter     string
+	OTLPEndpoint string
+	ServiceName  string
+}
+
+// Trace exporters
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Blob stores
+const (
+	StoreLocal = "local"
+	StoreS3    = "s3"
+)
+
+// MinJWTSecretLength is the shortest secret accepted: HMAC-SHA256 keys
+// should be at least as long as the hash.
+const MinJWTSecretLength = 32
+
+// Default returns the configuration used when nothing is set.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Port:              "8080",
+			RequestTimeout:    30 * time.Second,
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: database.Config{Driver: database.MySQL},
+		Auth: Auth{
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: 30 * 24 * time.Hour,
+		},
+		Trash: Trash{RetentionDays: 30},
+		Media: Media{
+			Store: StoreLocal,
+			Dir:   "media",
+			URL:   "/media",
+			S3:    storage.S3Config{Region: "us-east-1"},
+		},
+		Tracing: Tracing{
+			Exporter:     ExporterNone,
+			OTLPEndpoint: "http://localhost:4318",
+			ServiceName:  "go-films-api",
+		},
+	}
+}
+
+// setting ties a field of Config to its key in the config file, its
+// environment variable and its flag. Secrets have no flag, since flags show
+// up in process listings.
+type setting struct {
+	key   string
+	env   string
+	flag  string
+	usage string
+	value interface{}
+}
+
+func settings(cfg *Config) []setting {
+	return []setting{
+		{"server.port", "APP_PORT", "port", "port the API listens on", &cfg.Server.Port},
+		{"server.request_timeout", "REQUEST_TIMEOUT", "request-timeout", "time a request may take, 0 for no limit", &cfg.Server.RequestTimeout},
+		{"server.read_header_timeout", "READ_HEADER_TIMEOUT", "read-header-timeout", "time to read request headers, 0 for no limit", &cfg.Server.ReadHeaderTimeout},
+		{"server.read_timeout", "READ_TIMEOUT", "read-timeout", "time to read a whole request, 0 for no limit", &cfg.Server.ReadTimeout},
+		{"server.write_timeout", "WRITE_TIMEOUT", "write-timeout", "time to write a response, 0 for no limit", &cfg.Server.WriteTimeout},
+		{"server.idle_timeout", "IDLE_TIMEOUT", "idle-timeout", "time a keep-alive connection may sit idle, 0 for no limit", &cfg.Server.IdleTimeout},
+		{"server.shutdown_timeout", "SHUTDOWN_TIMEOUT", "shutdown-timeout", "time in-flight requests get to finish on shutdown", &cfg.Server.ShutdownTimeout},
+
+		{"database.driver", "DB_DRIVER", "db-driver", "database driver: mysql, postgres or sqlite", &cfg.Database.Driver},
+		{"database.host", "DB_HOST", "db-host", "database host", &cfg.Database.Host},
+		{"database.port", "DB_PORT", "db-port", "database port, defaults to the driver's", &cfg.Database.Port},
+		{"database.user", "DB_USER", "db-user", "database user", &cfg.Database.User},
+		{"database.password", "DB_PASS", "", "", &cfg.Database.Password},
+		{"database.name", "DB_NAME", "db-name", "database name, or file for sqlite", &cfg.Database.Name},
+
+		{"auth.jwt_secret", "JWT_SECRET", "", "", &cfg.Auth.JWTSecret},
+		{"auth.access_token_ttl", "ACCESS_TOKEN_TTL", "access-token-ttl", "lifetime of access tokens", &cfg.Auth.AccessTokenTTL},
+		{"auth.refresh_token_ttl", "REFRESH_TOKEN_TTL", "refresh-token-ttl", "lifetime of refresh tokens", &cfg.Auth.RefreshTokenTTL},
+
+		{"trash.retention_days", "TRASH_RETENTION_DAYS", "trash-retention-days", "days before trashed films are purged, 0 to keep them", &cfg.Trash.RetentionDays},
+
+		{"media.store", "BLOB_STORE", "blob-store", "where images are kept: local or s3", &cfg.Media.Store},
+		{"media.dir", "MEDIA_DIR", "media-dir", "directory of local images", &cfg.Media.Dir},
+		{"media.url", "MEDIA_URL", "media-url", "path or URL local images are served under", &cfg.Media.URL},
+		{"media.s3.endpoint", "S3_ENDPOINT", "s3-endpoint", "base URL of the S3 server", &cfg.Media.S3.Endpoint},
+		{"media.s3.region", "S3_REGION", "s3-region", "S3 bucket region", &cfg.Media.S3.Region},
+		{"media.s3.bucket", "S3_BUCKET", "s3-bucket", "S3 bucket name", &cfg.Media.S3.Bucket},
+		{"media.s3.access_key", "S3_ACCESS_KEY", "s3-access-key", "S3 access key", &cfg.Media.S3.AccessKey},
+		{"media.s3.secret_key", "S3_SECRET_KEY", "", "", &cfg.Media.S3.SecretKey},
+		{"media.s3.public_url", "S3_PUBLIC_URL", "s3-public-url", "URL clients fetch S3 images from", &cfg.Media.S3.PublicURL},
+
+		{"tracing.exporter", "TRACING_EXPORTER", "tracing-exporter", "where spans go: none, stdout or otlp", &cfg.Tracing.Exporter},
+		{"tracing.otlp_endpoint", "OTEL_EXPORTER_OTLP_ENDPOINT", "otlp-endpoint", "URL of the OTLP/HTTP collector", &cfg.Tracing.OTLPEndpoint},
+		{"tracing.service_name", "OTEL_SERVICE_NAME", "service-name", "service name spans are reported under", &cfg.Tracing.ServiceName},
+	}
+}
+
+// Load reads the configuration from the config file named by the -config
+// flag or CONFIG_FILE, the environment and args, the command-line arguments
+// without the program name. Values are parsed into their types, but not
+// validated; see Validate.
+func Load(name string, args []string) (*Config, error) {
+	cfg := Default()
+	all := settings(&cfg)
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML or TOML config file")
+	// Secrets are parsed like the rest, only never from args
+	secrets := flag.NewFlagSet(name, flag.ContinueOnError)
+	values := make(map[string]flag.Value, len(all))
+	for _, s := range all {
+		if s.flag == "" {
+			values[s.key] = bind(secrets, s)
+		} else {
+			values[s.key] = bind(fs, s)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	// Flags win over everything else, so they are set again at the end
+	flags := map[string]string{}
+	fs.Visit(func(f *flag.Flag) { flags[f.Name] = f.Value.String() })
+	cfg = Default()
+
+	if *configFile != "" {
+		file, err := readFile(*configFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range sortedKeys(file) {
+			value, ok := values[key]
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown setting %q", *configFile, key)
+			}
+			if err := value.Set(file[key]); err != nil {
+				return nil, fmt.Errorf("%s: invalid %s %q", *configFile, key, file[key])
+			}
+		}
+	}
+
+	for _, s := range all {
+		if v := os.Getenv(s.env); v != "" {
+			if err := values[s.key].Set(v); err != nil {
+				return nil, fmt.Errorf("invalid %s %q", s.env, v)
+			}
+		}
+	}
+
+	for name, v := range flags {
+		if err := fs.Set(name, v); err != nil {
+			return nil, fmt.Errorf("invalid -%s %q", name, v)
+		}
+	}
+	return &cfg, nil
+}
+
+// bind registers s with fs under its flag name, or under its key when it
+// has no flag, and returns the value that sets it.
+func bind(fs *flag.FlagSet, s setting) flag.Value {
+	name := s.flag
+	if name == "" {
+		name = s.key
+	}
+	switch p := s.value.(type) {
+	case *string:
+		fs.StringVar(p, name, *p, s.usage)
+	case *int:
+		fs.IntVar(p, name, *p, s.usage)
+	case *time.Duration:
+		fs.DurationVar(p, name, *p, s.usage)
+	default:
+		panic(fmt.Sprintf("config: unsupported type %T of %s", p, s.key))
+	}
+	return fs.Lookup(name).Value
+}
+
+// readFile reads a YAML or TOML config file, picked by its extension, into
+// a map from dotted keys such as "database.host" to values.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file: %w", err)
+	}
+
+	var tree map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("%s: config file must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	if err := flatten("", tree, values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+func flatten(prefix string, tree map[string]interface{}, values map[string]string) error {
+	for key, value := range tree {
+		key = prefix + key
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if err := flatten(key+".", v, values); err != nil {
+				return err
+			}
+		case string, bool, int, int64, uint64, float64:
+			values[key] = fmt.Sprint(v)
+		default:
+			return fmt.Errorf("setting %q must be a string or a number", key)
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate reports the first setting that would keep the server from
+// working, so that it fails at startup rather than on the first request.
+func (c *Config) Validate() error {
+	if c.Server.Port == "" {
+		return errors.New("APP_PORT must not be empty")
+	}
+	if c.Server.RequestTimeout < 0 {
+		return errors.New("REQUEST_TIMEOUT must not be negative")
+	}
+	if c.Server.ReadHeaderTimeout < 0 || c.Server.ReadTimeout < 0 || c.Server.WriteTimeout < 0 || c.Server.IdleTimeout < 0 {
+		return errors.New("READ_HEADER_TIMEOUT, READ_TIMEOUT, WRITE_TIMEOUT and IDLE_TIMEOUT must not be negative")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		return errors.New("SHUTDOWN_TIMEOUT must be positive")
+	}
+	if err := c.ValidateDatabase(); err != nil {
+		return err
+	}
+
+	switch {
+	case c.Auth.JWTSecret == "":
+		return errors.New("JWT_SECRET is required")
+	case len(c.Auth.JWTSecret) < MinJWTSecretLength:
+		return fmt.Errorf("JWT_SECRET must be at least %d bytes long", MinJWTSecretLength)
+	case strings.Count(c.Auth.JWTSecret, c.Auth.JWTSecret[:1]) == len(c.Auth.JWTSecret):
+		return errors.New("JWT_SECRET must not repeat a single character")
+	}
+	if c.Auth.AccessTokenTTL <= 0 || c.Auth.RefreshTokenTTL <= 0 {
+		return errors.New("ACCESS_TOKEN_TTL and REFRESH_TOKEN_TTL must be positive")
+	}
+
+	if c.Trash.RetentionDays < 0 {
+		return errors.New("TRASH_RETENTION_DAYS must not be negative")
+	}
+	if c.Media.Store != StoreLocal && c.Media.Store != StoreS3 {
+		return fmt.Errorf("invalid BLOB_STORE %q, expected local or s3", c.Media.Store)
+	}
+
+	switch c.Tracing.Exporter {
+	case ExporterNone, ExporterStdout:
+	case ExporterOTLP:
+		if u, err := url.Parse(c.Tracing.OTLPEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid OTEL_EXPORTER_OTLP_ENDPOINT %q, expected an http or https URL", c.Tracing.OTLPEndpoint)
+		}
+	default:
+		return fmt.Errorf("invalid TRACING_EXPORTER %q, expected none, stdout or otlp", c.Tracing.Exporter)
+	}
+	if c.Tracing.ServiceName == "" {
+		return errors.New("OTEL_SERVICE_NAME must not be empty")
+	}
+	return nil
+}
+
+// ValidateDatabase checks only the database settings, which are all the
+// migrate command needs.
+func (c *Config) ValidateDatabase() error {
+	if !database.IsValidDriver(c.Database.Driver) {
+		return fmt.Errorf("invalid DB_DRIVER %q, expected mysql, postgres or sqlite", c.Database.Driver)
+	}
+	if _, err := database.DSN(c.Database); err != nil {
+		return fmt.Errorf("invalid database settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-films-api/internal/config"
+	"go-films-api/internal/database"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("APP_PORT", "")
+	t.Setenv("REQUEST_TIMEOUT", "")
+
+	cfg, err := config.Load("server", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "8080", cfg.Server.Port)
+	assert.Equal(t, 30*time.Second, cfg.Server.RequestTimeout)
+	assert.Equal(t, time.Hour, cfg.Auth.AccessTokenTTL)
+	assert.Equal(t, config.StoreLocal, cfg.Media.Store)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  port: 9000
+  request_timeout: 5s
+database:
+  driver: postgres
+  host: db
+  name: films
+trash:
+  retention_days: 7
+`)
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("APP_PORT", "")
+	t.Setenv("REQUEST_TIMEOUT", "10s")
+	t.Setenv("DB_HOST", "")
+	t.Setenv("TRASH_RETENTION_DAYS", "")
+
+	cfg, err := config.Load("server", []string{"-config", path, "-port", "9100"})
+	assert.NoError(t, err)
+	assert.Equal(t, "9100", cfg.Server.Port, "flags win over the file")
+	assert.Equal(t, 10*time.Second, cfg.Server.RequestTimeout, "the environment wins over the file")
+	assert.Equal(t, database.Postgres, cfg.Database.Driver)
+	assert.Equal(t, "db", cfg.Database.Host)
+	assert.Equal(t, 7, cfg.Trash.RetentionDays)
+}
+
+func TestLoad_TOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[auth]
+jwt_secret = "`+testSecret+`"
+access_token_ttl = "15m"
+`)
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("ACCESS_TOKEN_TTL", "")
+
+	cfg, err := config.Load("server", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, testSecret, cfg.Auth.JWTSecret)
+	assert.Equal(t, 15*time.Minute, cfg.Auth.AccessTokenTTL)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("REQUEST_TIMEOUT", "")
+
+	_, err := config.Load("server", []string{"-request-timeout", "soon"})
+	assert.Error(t, err)
+
+	// Secrets have no flag
+	_, err = config.Load("server", []string{"-auth.jwt_secret", testSecret})
+	assert.Error(t, err)
+
+	t.Setenv("TRASH_RETENTION_DAYS", "a week")
+	_, err = config.Load("server", nil)
+	assert.EqualError(t, err, `invalid TRASH_RETENTION_DAYS "a week"`)
+	t.Setenv("TRASH_RETENTION_DAYS", "")
+
+	path := writeFile(t, "config.yaml", "server:\n  prot: 9000\n")
+	_, err = config.Load("server", []string{"-config", path})
+	assert.EqualError(t, err, path+`: unknown setting "server.prot"`)
+
+	_, err = config.Load("server", []string{"-config", writeFile(t, "config.json", "{}")})
+	assert.Error(t, err)
+}
+
+func validConfig() config.Config {
+	cfg := config.Default()
+	cfg.Database = database.Config{Driver: database.MySQL, User: "root", Host: "db", Name: "films"}
+	cfg.Auth.JWTSecret = testSecret
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	cfg := validConfig()
+	assert.NoError(t, cfg.Validate())
+
+	cfg = validConfig()
+	cfg.Auth.JWTSecret = ""
+	assert.EqualError(t, cfg.Validate(), "JWT_SECRET is required")
+
+	cfg = validConfig()
+	cfg.Auth.JWTSecret = "some-secret"
+	assert.EqualError(t, cfg.Validate(), "JWT_SECRET must be at least 32 bytes long")
+
+	cfg = validConfig()
+	cfg.Auth.JWTSecret = "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"
+	assert.EqualError(t, cfg.Validate(), "JWT_SECRET must not repeat a single character")
+
+	cfg = validConfig()
+	cfg.Database.Driver = "oracle"
+	assert.EqualError(t, cfg.Validate(), `invalid DB_DRIVER "oracle", expected mysql, postgres or sqlite`)
+
+	cfg = validConfig()
+	cfg.Database.Port = "33o6"
+	assert.EqualError(t, cfg.Validate(), `invalid database settings: invalid database port "33o6"`)
+
+	cfg = validConfig()
+	cfg.Media.Store = "ftp"
+	assert.EqualError(t, cfg.Validate(), `invalid BLOB_STORE "ftp", expected local or s3`)
+
+	cfg = validConfig()
+	cfg.Auth.AccessTokenTTL = 0
+	assert.Error(t, cfg.Validate())
+}
+
+func TestValidateDatabase_IgnoresTheRest(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auth.JWTSecret = ""
+	assert.NoError(t, cfg.ValidateDatabase())
+}
+
+func TestValidate_Tracing(t *testing.T) {
+	cfg := validConfig()
+	cfg.Tracing.Exporter = config.ExporterOTLP
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Tracing.OTLPEndpoint = "localhost:4318"
+	assert.EqualError(t, cfg.Validate(), `invalid OTEL_EXPORTER_OTLP_ENDPOINT "localhost:4318", expected an http or https URL`)
+
+	cfg = validConfig()
+	cfg.Tracing.Exporter = "jaeger"
+	assert.EqualError(t, cfg.Validate(), `invalid TRACING_EXPORTER "jaeger", expected none, stdout or otlp`)
+}
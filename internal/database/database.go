@@ -0,0 +1,212 @@
+// Package database opens the database the API runs on and applies its
+// migrations. MySQL, PostgreSQL and SQLite are supported; each has its own
+// set of migrations below migrations/<driver>.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+
+	mysqldriver "github.com/go-sql-driver/mysql" // also registers the "mysql" driver
+	"github.com/golang-migrate/migrate/v4"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file" // reads migrations from disk
+	_ "github.com/jackc/pgx/v5/stdlib"                   // registers the "pgx" driver
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Supported drivers
+const (
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// Config says which database to connect to. For SQLite, Name is the path of
+// the database file and the other fields are ignored.
+type Config struct {
+	Driver   string
+	User     string
+	Password string
+	Host     string
+	Port     string
+	Name     string
+}
+
+// IsValidDriver reports whether driver is one of the supported drivers.
+func IsValidDriver(driver string) bool {
+	return driver == MySQL || driver == Postgres || driver == SQLite
+}
+
+// DSN returns the data source name of cfg for the database/sql driver
+// behind cfg.Driver. It fails when cfg cannot make a well-formed DSN, so
+// that a bad configuration is reported before connecting.
+func DSN(cfg Config) (string, error) {
+	switch cfg.Driver {
+	case MySQL, Postgres:
+		if cfg.Name == "" {
+			return "", errors.New("missing database name")
+		}
+		if cfg.Port != "" {
+			if port, err := strconv.Atoi(cfg.Port); err != nil || port < 1 || port > 65535 {
+				return "", fmt.Errorf("invalid database port %q", cfg.Port)
+			}
+		}
+	}
+
+	switch cfg.Driver {
+	case MySQL:
+		port := cfg.Port
+		if port == "" {
+			port = "3306"
+		}
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			cfg.User, cfg.Password, cfg.Host, port, cfg.Name)
+		// The format has no escaping, so a "/" or "?" in the wrong field
+		// would silently connect somewhere else
+		parsed, err := mysqldriver.ParseDSN(dsn)
+		if err != nil || parsed.User != cfg.User || parsed.Passwd != cfg.Password ||
+			parsed.Addr != cfg.Host+":"+port || parsed.DBName != cfg.Name {
+			return "", errors.New("malformed MySQL DSN, check the database host, user, password and name")
+		}
+		return dsn, nil
+	case Postgres:
+		port := cfg.Port
+		if port == "" {
+			port = "5432"
+		}
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(cfg.User, cfg.Password),
+			Host:     cfg.Host + ":" + port,
+			Path:     "/" + cfg.Name,
+			RawQuery: "sslmode=disable",
+		}
+		if _, err := url.Parse(dsn.String()); err != nil {
+			return "", errors.New("malformed PostgreSQL DSN, check the database host")
+		}
+		return dsn.String(), nil
+	case SQLite:
+		if cfg.Name == "" {
+			return "", errors.New("missing SQLite database file")
+		}
+		// Foreign keys are off by default in SQLite, and cascading deletes
+		// depend on them. Transactions take the write lock up front so that
+		// concurrent writers wait for each other instead of failing, and
+		// times are stored in a format that sorts and compares as text.
+		return "file:" + filepath.ToSlash(cfg.Name) +
+			"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)" +
+			"&_txlock=immediate&_time_format=sqlite", nil
+	}
+	return "", fmt.Errorf("unsupported database driver %q, expected mysql, postgres or sqlite", cfg.Driver)
+}
+
+// Open connects GORM to the database described by cfg.
+func Open(cfg Config) (*gorm.DB, error) {
+	dsn, err := DSN(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialector gorm.Dialector
+	switch cfg.Driver {
+	case MySQL:
+		dialector = mysql.Open(dsn)
+	case Postgres:
+		dialector = postgres.Open(dsn)
+	case SQLite:
+		dialector = sqlite.Dialector{DriverName: sqliteDriverName, DSN: dsn}
+	}
+	return gorm.Open(dialector, &gorm.Config{})
+}
+
+// Migrate applies the migrations in dir/<driver> that the database described
+// by cfg has not run yet.
+func Migrate(cfg Config, dir string) error {
+	dsn, err := DSN(cfg)
+	if err != nil {
+		return err
+	}
+	driverName := cfg.Driver
+	switch cfg.Driver {
+	case MySQL:
+		// Migrations may hold several statements
+		dsn += "&multiStatements=true"
+	case Postgres:
+		driverName = "pgx"
+	case SQLite:
+		driverName = sqliteDriverName
+	}
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return fmt.Errorf("could not connect to database: %w", err)
+	}
+	defer db.Close()
+
+	var target migratedb.Driver
+	switch cfg.Driver {
+	case MySQL:
+		target, err = migratemysql.WithInstance(db, &migratemysql.Config{})
+	case Postgres:
+		target, err = migratepgx.WithInstance(db, &migratepgx.Config{})
+	case SQLite:
+		target, err = migratesqlite.WithInstance(db, &migratesqlite.Config{})
+	default:
+		return fmt.Errorf("unsupported database driver %q, expected mysql, postgres or sqlite", cfg.Driver)
+	}
+	if err != nil {
+		return fmt.Errorf("could not create %s migration driver: %w", cfg.Driver, err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance("file://"+filepath.ToSlash(filepath.Join(dir, cfg.Driver)), cfg.Driver, target)
+	if err != nil {
+		return fmt.Errorf("could not create migrate instance: %w", err)
+	}
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	return nil
+}
+
+// MigrationStatus is the migration a database was last brought to.
+// A dirty migration failed halfway and needs fixing by hand.
+type MigrationStatus struct {
+	Version uint
+	Dirty   bool
+}
+
+// Ping checks that db can still reach the database, and returns the
+// migration it is at: version 0 if the migrate command has not applied any
+// yet, and an error if it never ran.
+func Ping(ctx context.Context, db *gorm.DB) (*MigrationStatus, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("could not reach database: %w", err)
+	}
+
+	// golang-migrate keeps a single row in this table, on every driver
+	var status MigrationStatus
+	err = sqlDB.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations").Scan(&status.Version, &status.Dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &status, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read migration version: %w", err)
+	}
+	return &status, nil
+}
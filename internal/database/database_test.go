@@ -0,0 +1,129 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-films-api/internal/database"
+	"go-films-api/internal/usecase"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratemysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDSN(t *testing.T) {
+	dsn, err := database.DSN(database.Config{Driver: database.MySQL, User: "root", Password: "pw", Host: "db", Name: "films"})
+	assert.NoError(t, err)
+	assert.Equal(t, "root:pw@tcp(db:3306)/films?charset=utf8mb4&parseTime=True&loc=Local", dsn)
+
+	dsn, err = database.DSN(database.Config{Driver: database.Postgres, User: "root", Password: "p@ss", Host: "db", Port: "6543", Name: "films"})
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres://root:p%40ss@db:6543/films?sslmode=disable", dsn)
+
+	dsn, err = database.DSN(database.Config{Driver: database.SQLite, Name: "data/films.db"})
+	assert.NoError(t, err)
+	assert.Contains(t, dsn, "file:data/films.db?")
+	assert.Contains(t, dsn, "_pragma=foreign_keys(1)")
+
+	_, err = database.DSN(database.Config{Driver: database.SQLite})
+	assert.Error(t, err)
+
+	_, err = database.DSN(database.Config{Driver: database.MySQL, Host: "db", Port: "33o6", Name: "films"})
+	assert.EqualError(t, err, `invalid database port "33o6"`)
+
+	_, err = database.DSN(database.Config{Driver: database.Postgres, Host: "db"})
+	assert.EqualError(t, err, "missing database name")
+
+	// The database name follows the last slash of a MySQL DSN
+	_, err = database.DSN(database.Config{Driver: database.MySQL, User: "root", Host: "db", Name: "films/test"})
+	assert.Error(t, err)
+
+	_, err = database.DSN(database.Config{Driver: database.Postgres, Host: "db/x y%", Name: "films"})
+	assert.Error(t, err)
+
+	_, err = database.DSN(database.Config{Driver: "oracle"})
+	assert.EqualError(t, err, `unsupported database driver "oracle", expected mysql, postgres or sqlite`)
+}
+
+func TestPing(t *testing.T) {
+	ctx := context.Background()
+	cfg := database.Config{Driver: database.SQLite, Name: filepath.Join(t.TempDir(), "films.db")}
+	db, err := database.Open(cfg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	// Before the first migration there is nothing to read the version from
+	_, err = database.Ping(ctx, db)
+	assert.ErrorContains(t, err, "could not read migration version")
+
+	assert.NoError(t, database.Migrate(cfg, "../../migrations"))
+	status, err := database.Ping(ctx, db)
+	assert.NoError(t, err)
+	assert.Equal(t, &database.MigrationStatus{Version: 15}, status)
+
+	sqlDB.Close()
+	_, err = database.Ping(ctx, db)
+	assert.ErrorContains(t, err, "could not reach database")
+}
+
+// TestMigrate_MySQLGenreSlugs converts legacy free-text genres on a real MySQL
+// server when MYSQL_TEST_DSN is set, e.g. to "root:root@tcp(localhost:3306)/films_test".
+// The database must be empty; the test drops everything in it afterwards.
+func TestMigrate_MySQLGenreSlugs(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN is not set")
+	}
+	db, err := sql.Open("mysql", dsn+"?multiStatements=true")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.Close()
+	target, err := migratemysql.WithInstance(db, &migratemysql.Config{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://../../migrations/mysql", database.MySQL, target)
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Cleanup(func() { m.Drop() })
+
+	// Right before genres got their own table
+	if !assert.NoError(t, m.Migrate(4)) {
+		return
+	}
+	_, err = db.Exec(`INSERT INTO films (user_id, title, genre) VALUES
+		(1, 'Matilda', 'Children''s'), (1, 'Rocketman', 'Rock & Roll'),
+		(1, 'Alien', 'Science  Fiction'), (1, 'Laura', ' -Film_Noir- '), (1, 'Untitled', '--')`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.NoError(t, m.Migrate(5)) {
+		return
+	}
+
+	rows, err := db.Query("SELECT name, slug FROM genres")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer rows.Close()
+	slugs := map[string]bool{}
+	for rows.Next() {
+		var name, slug string
+		assert.NoError(t, rows.Scan(&name, &slug))
+		assert.Equal(t, usecase.Slugify(name), slug, "genre %q", name)
+		slugs[slug] = true
+	}
+	for _, slug := range []string{"children-s", "rock-roll", "science-fiction", "film-noir"} {
+		assert.True(t, slugs[slug], "missing genre %q", slug)
+	}
+	assert.Len(t, slugs, 7, "the three seeded genres and four converted ones")
+}
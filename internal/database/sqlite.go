@@ -0,0 +1,54 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+// sqliteDriverName is the SQLite driver the API connects through. It writes
+// every time in UTC: SQLite stores times as text and compares them as such,
+// which only works if they all have the same offset.
+const sqliteDriverName = "sqlite-utc"
+
+func init() {
+	sql.Register(sqliteDriverName, utcDriver{&sqlite.Driver{}})
+}
+
+type utcDriver struct {
+	driver.Driver
+}
+
+func (d utcDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return utcConn{conn.(sqliteConn)}, nil
+}
+
+// sqliteConn is what a SQLite connection implements beyond driver.Conn.
+type sqliteConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+}
+
+type utcConn struct {
+	sqliteConn
+}
+
+// CheckNamedValue converts time arguments to UTC and leaves the others to
+// the default conversion.
+func (utcConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if t, ok := nv.Value.(time.Time); ok {
+		nv.Value = t.UTC()
+		return nil
+	}
+	return driver.ErrSkip
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler serves the /admin endpoints. Access is restricted by the
+// router with middleware.RequirePermission.
+type AdminHandler struct {
+	userService usecase.UserService
+}
+
+type UserRoleRequest struct {
+	Role string `json:"role" binding:"required" enums:"user,editor,admin"`
+}
+
+type UserListResponse struct {
+	Items    []domain.User `json:"items"`
+	Total    int64         `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+	Next     string        `json:"next,omitempty"`
+	Prev     string        `json:"prev,omitempty"`
+}
+
+func NewAdminHandler(us usecase.UserService) *AdminHandler {
+	return &AdminHandler{userService: us}
+}
+
+// GetUsers godoc
+// @Summary List users
+// @Description Retrieves a paginated list of users ordered by username. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param role query string false "Only users with this role" Enums(user, editor, admin)
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} UserListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /admin/users [get]
+func (h *AdminHandler) GetUsers(c *gin.Context) {
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.userService.ListUsers(c.Request.Context(), domain.Role(c.Query("role")), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp := UserListResponse{
+		Items:    result.Users,
+		Total:    result.Total,
+		Page:     result.Page,
+		PageSize: result.PageSize,
+	}
+	if resp.Items == nil {
+		resp.Items = []domain.User{}
+	}
+	if int64(result.Page*result.PageSize) < result.Total {
+		resp.Next = listLink(c, "page", strconv.Itoa(result.Page+1))
+	}
+	if result.Page > 1 {
+		resp.Prev = listLink(c, "page", strconv.Itoa(result.Page-1))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Description Retrieves a user by ID. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} middleware.Problem "Invalid user ID"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 404 {object} middleware.Problem "User not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateUserRole godoc
+// @Summary Change a user's role
+// @Description Sets the role of a user and signs them out of every session, so the new role applies from their next login. Admins cannot change their own role.
+// @Tags admin
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body UserRoleRequest true "New role"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} middleware.Problem "Invalid role"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 404 {object} middleware.Problem "User not found"
+// @Failure 409 {object} middleware.Problem "Cannot change your own role"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /admin/users/{id}/role [put]
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	user, err := h.userService.SetUserRole(c.Request.Context(), actorID, id, domain.Role(req.Role))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func parseUserID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(domain.Invalid("id", "invalid user ID"))
+		return 0, false
+	}
+	return uint(id64), true
+}
@@ -0,0 +1,176 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	adminHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) Register(ctx context.Context, username, password string) error {
+	args := m.Called(ctx, username, password)
+	return args.Error(0)
+}
+
+func (m *MockUserService) Login(ctx context.Context, username, password string) (*usecase.AuthTokens, error) {
+	args := m.Called(ctx, username, password)
+	if tokens, ok := args.Get(0).(*usecase.AuthTokens); ok {
+		return tokens, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserService) Refresh(ctx context.Context, refreshToken string) (*usecase.AuthTokens, error) {
+	args := m.Called(ctx, refreshToken)
+	if tokens, ok := args.Get(0).(*usecase.AuthTokens); ok {
+		return tokens, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserService) Logout(ctx context.Context, sessionID string) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ListUsers(ctx context.Context, role domain.Role, page, pageSize int) (*usecase.UserPage, error) {
+	args := m.Called(ctx, role, page, pageSize)
+	if result, ok := args.Get(0).(*usecase.UserPage); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserService) GetUser(ctx context.Context, id uint) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if user, ok := args.Get(0).(*domain.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserService) SetUserRole(ctx context.Context, actorID, userID uint, role domain.Role) (*domain.User, error) {
+	args := m.Called(ctx, actorID, userID, role)
+	if user, ok := args.Get(0).(*domain.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserService) DeleteExpiredTokens(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func setupAdminRouter(service *MockUserService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	handler := adminHttp.NewAdminHandler(service)
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Set("role", domain.RoleAdmin)
+		c.Next()
+	})
+	r.GET("/admin/users", handler.GetUsers)
+	r.GET("/admin/users/:id", handler.GetUser)
+	r.PUT("/admin/users/:id/role", handler.UpdateUserRole)
+	return r
+}
+
+func TestGetUsers(t *testing.T) {
+	mockService := new(MockUserService)
+	r := setupAdminRouter(mockService)
+
+	mockService.On("ListUsers", mock.Anything, domain.RoleEditor, 1, 1).Return(&usecase.UserPage{
+		Users:    []domain.User{{ID: 3, Username: "carol", Role: domain.RoleEditor}},
+		Total:    2,
+		Page:     1,
+		PageSize: 1,
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/admin/users?role=editor&page_size=1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp adminHttp.UserListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, domain.RoleEditor, resp.Items[0].Role)
+	assert.Equal(t, "/admin/users?page=2&page_size=1&role=editor", resp.Next)
+	assert.NotContains(t, w.Body.String(), "Password")
+}
+
+func TestGetUsers_InvalidRole(t *testing.T) {
+	mockService := new(MockUserService)
+	r := setupAdminRouter(mockService)
+
+	mockService.On("ListUsers", mock.Anything, domain.Role("root"), 1, 20).Return(nil, domain.Invalid("role", "invalid role"))
+
+	req, _ := http.NewRequest("GET", "/admin/users?role=root", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetUser_NotFound(t *testing.T) {
+	mockService := new(MockUserService)
+	r := setupAdminRouter(mockService)
+
+	mockService.On("GetUser", mock.Anything, uint(9)).Return(nil, domain.NotFound("user_not_found", "user not found"))
+
+	req, _ := http.NewRequest("GET", "/admin/users/9", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	mockService := new(MockUserService)
+	r := setupAdminRouter(mockService)
+
+	mockService.On("SetUserRole", mock.Anything, uint(5), uint(7), domain.RoleEditor).
+		Return(&domain.User{ID: 7, Username: "bob", Role: domain.RoleEditor}, nil)
+
+	req, _ := http.NewRequest("PUT", "/admin/users/7/role", bytes.NewBufferString(`{"role":"editor"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"Role":"editor"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateUserRole_OwnRole(t *testing.T) {
+	mockService := new(MockUserService)
+	r := setupAdminRouter(mockService)
+
+	mockService.On("SetUserRole", mock.Anything, uint(5), uint(5), domain.RoleUser).
+		Return(nil, domain.Conflict("own_role_change", "you cannot change your own role"))
+
+	req, _ := http.NewRequest("PUT", "/admin/users/5/role", bytes.NewBufferString(`{"role":"user"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
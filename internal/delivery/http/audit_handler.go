@@ -0,0 +1,161 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService usecase.AuditService
+}
+
+type AuditListResponse struct {
+	Items    []domain.AuditEvent `json:"items"`
+	Total    int64               `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"page_size"`
+	Next     string              `json:"next,omitempty"`
+	Prev     string              `json:"prev,omitempty"`
+}
+
+func NewAuditHandler(as usecase.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: as}
+}
+
+// GetFilmHistory godoc
+// @Summary Get the change history of a film
+// @Description Retrieves the audit events of a film, newest first. Each event names the user who made the change and, for creates and updates, the before and after value of every changed field.
+// @Tags films
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} AuditListResponse
+// @Failure 400 {object} middleware.Problem "Invalid Film ID or query parameter"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/{id}/history [get]
+func (h *AuditHandler) GetFilmHistory(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.auditService.FilmHistory(c.Request.Context(), filmID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeAuditPage(c, result)
+}
+
+// GetAuditEvents godoc
+// @Summary List audit events
+// @Description Retrieves the audit log of every film, newest first, including trashed and purged films. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param film_id query int false "Only events of this film"
+// @Param actor_id query int false "Only changes made by this user"
+// @Param action query string false "Only events of this kind" Enums(create, update, delete, restore, purge)
+// @Param from query string false "Only events at or after this time (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Only events at or before this time (YYYY-MM-DD, inclusive, or RFC 3339)"
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} AuditListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /audit [get]
+func (h *AuditHandler) GetAuditEvents(c *gin.Context) {
+	query := usecase.AuditQuery{Action: domain.AuditAction(c.Query("action"))}
+
+	for _, param := range []struct {
+		name string
+		dest *uint
+	}{
+		{"film_id", &query.FilmID},
+		{"actor_id", &query.ActorID},
+	} {
+		if value := c.Query(param.name); value != "" {
+			id64, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || id64 == 0 {
+				c.Error(domain.Invalid(param.name, "invalid "+param.name))
+				return
+			}
+			*param.dest = uint(id64)
+		}
+	}
+
+	var ok bool
+	if query.From, ok = parseAuditTime(c, "from", false); !ok {
+		return
+	}
+	if query.To, ok = parseAuditTime(c, "to", true); !ok {
+		return
+	}
+	if query.Page, query.PageSize, ok = parsePagination(c); !ok {
+		return
+	}
+
+	result, err := h.auditService.ListAuditEvents(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeAuditPage(c, result)
+}
+
+// parseAuditTime reads an RFC 3339 time or a date from the named query
+// parameter. A date used as an upper bound covers the whole day.
+func parseAuditTime(c *gin.Context, name string, endOfDay bool) (time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, true
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		c.Error(domain.Invalid(name, "invalid "+name+" format, expected YYYY-MM-DD or RFC 3339"))
+		return time.Time{}, false
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, true
+}
+
+func writeAuditPage(c *gin.Context, result *usecase.AuditPage) {
+	resp := AuditListResponse{
+		Items:    result.Events,
+		Total:    result.Total,
+		Page:     result.Page,
+		PageSize: result.PageSize,
+	}
+	if resp.Items == nil {
+		resp.Items = []domain.AuditEvent{}
+	}
+	if int64(result.Page*result.PageSize) < result.Total {
+		resp.Next = listLink(c, "page", strconv.Itoa(result.Page+1))
+	}
+	if result.Page > 1 {
+		resp.Prev = listLink(c, "page", strconv.Itoa(result.Page-1))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
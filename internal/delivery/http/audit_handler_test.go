@@ -0,0 +1,124 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	filmHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	usecase "go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) ListAuditEvents(ctx context.Context, query usecase.AuditQuery) (*usecase.AuditPage, error) {
+	args := m.Called(ctx, query)
+	if page, ok := args.Get(0).(*usecase.AuditPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuditService) FilmHistory(ctx context.Context, filmID uint, page, pageSize int) (*usecase.AuditPage, error) {
+	args := m.Called(ctx, filmID, page, pageSize)
+	if page, ok := args.Get(0).(*usecase.AuditPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupAuditRouter(mockService *MockAuditService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	auditHandler := filmHttp.NewAuditHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films/:id/history", auditHandler.GetFilmHistory)
+	r.GET("/audit", auditHandler.GetAuditEvents)
+	return r
+}
+
+func TestGetFilmHistory(t *testing.T) {
+	mockService := new(MockAuditService)
+	r := setupAuditRouter(mockService)
+
+	actorID := uint(5)
+	mockService.On("FilmHistory", mock.Anything, uint(10), 1, 20).Return(&usecase.AuditPage{
+		Events: []domain.AuditEvent{{
+			ID:      2,
+			FilmID:  10,
+			Action:  domain.AuditActionUpdate,
+			ActorID: &actorID,
+			Changes: []domain.FieldChange{{Field: "title", Before: "Heat", After: "Heat (1995)"}},
+		}},
+		Total:    1,
+		Page:     1,
+		PageSize: 20,
+	}, nil)
+	mockService.On("FilmHistory", mock.Anything, uint(11), 1, 20).Return(nil, domain.NotFound("film_not_found", "film not found"))
+
+	req, _ := http.NewRequest("GET", "/films/10/history", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp filmHttp.AuditListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, domain.AuditActionUpdate, resp.Items[0].Action)
+	assert.Equal(t, "title", resp.Items[0].Changes[0].Field)
+	assert.Equal(t, "Heat", resp.Items[0].Changes[0].Before)
+
+	req, _ = http.NewRequest("GET", "/films/11/history", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAuditEvents_Filters(t *testing.T) {
+	mockService := new(MockAuditService)
+	r := setupAuditRouter(mockService)
+
+	mockService.On("ListAuditEvents", mock.Anything, usecase.AuditQuery{
+		FilmID:   10,
+		ActorID:  5,
+		Action:   domain.AuditActionDelete,
+		From:     time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		Page:     1,
+		PageSize: 20,
+	}).Return(&usecase.AuditPage{Total: 0, Page: 1, PageSize: 20}, nil)
+
+	req, _ := http.NewRequest("GET", "/audit?film_id=10&actor_id=5&action=delete&from=2024-03-01&to=2024-03-01", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"items":[]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAuditEvents_InvalidQuery(t *testing.T) {
+	mockService := new(MockAuditService)
+	r := setupAuditRouter(mockService)
+
+	for _, query := range []string{"film_id=abc", "actor_id=0", "from=yesterday", "page=0"} {
+		req, _ := http.NewRequest("GET", "/audit?"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	mockService.AssertNotCalled(t, "ListAuditEvents", mock.Anything, mock.Anything)
+}
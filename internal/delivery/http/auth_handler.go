@@ -1,21 +1,33 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
+	"go-films-api/internal/domain"
+	"go-films-api/internal/metrics"
 	"go-films-api/internal/usecase"
 
 	"github.com/gin-gonic/gin"
 )
 
+// AuthMetrics counts logins and registrations by outcome, like
+// metrics.Metrics.
+type AuthMetrics interface {
+	LoginAttempt(result string)
+	Registration(result string)
+}
+
 type AuthHandler struct {
 	userService usecase.UserService
+	metrics     AuthMetrics
 }
 
-func NewAuthHandler(us usecase.UserService) *AuthHandler {
+func NewAuthHandler(us usecase.UserService, am AuthMetrics) *AuthHandler {
 	return &AuthHandler{
 		userService: us,
+		metrics:     am,
 	}
 }
 
@@ -29,6 +41,17 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type TokenResponse struct {
+	Token            string `json:"token"`
+	ExpiresAt        string `json:"expires_at"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt string `json:"refresh_expires_at"`
+}
+
 // Register godoc
 // @Summary Register a new user
 // @Description Registers a new user with the provided username and password.
@@ -37,20 +60,23 @@ type LoginRequest struct {
 // @Produce json
 // @Param request body RegisterRequest true "User credentials"
 // @Success 201 {object} map[string]string "User registered successfully"
-// @Failure 400 {object} map[string]string "Invalid request body"
-// @Failure 409 {object} map[string]string "Username already exists"
+// @Failure 400 {object} middleware.Problem "Invalid request body"
+// @Failure 409 {object} middleware.Problem "Username already exists"
 // @Router /register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		h.metrics.Registration(metrics.ResultInvalidRequest)
+		c.Error(errInvalidBody)
 		return
 	}
 
-	if err := h.userService.Register(req.Username, req.Password); err != nil {
-		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	if err := h.userService.Register(c.Request.Context(), req.Username, req.Password); err != nil {
+		h.metrics.Registration(authResult(err))
+		c.Error(err)
 		return
 	}
+	h.metrics.Registration(metrics.ResultSuccess)
 
 	c.JSON(http.StatusCreated, gin.H{"message": "user registered successfully"})
 }
@@ -62,25 +88,100 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body LoginRequest true "User credentials"
-// @Success 200 {object} map[string]string "Login successful"
-// @Failure 400 {object} map[string]string "Invalid request body"
-// @Failure 401 {object} map[string]string "Invalid credentials"
+// @Success 200 {object} TokenResponse "Access and refresh tokens"
+// @Failure 400 {object} middleware.Problem "Invalid request body"
+// @Failure 401 {object} middleware.Problem "Invalid credentials"
 // @Router /login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		h.metrics.LoginAttempt(metrics.ResultInvalidRequest)
+		c.Error(errInvalidBody)
+		return
+	}
+
+	tokens, err := h.userService.Login(c.Request.Context(), req.Username, req.Password)
+	if err != nil {
+		h.metrics.LoginAttempt(authResult(err))
+		c.Error(err)
+		return
+	}
+	h.metrics.LoginAttempt(metrics.ResultSuccess)
+
+	c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchanges a refresh token for a new access and refresh token pair. Refresh tokens are single-use; presenting one twice revokes the whole session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse "Access and refresh tokens"
+// @Failure 400 {object} middleware.Problem "Invalid request body"
+// @Failure 401 {object} middleware.Problem "Invalid, expired or reused refresh token"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /token/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
 		return
 	}
 
-	token, exp, err := h.userService.Login(req.Username, req.Password)
+	tokens, err := h.userService.Refresh(c.Request.Context(), req.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token":      token,
-		"expires_at": exp.Format(time.RFC3339),
-	})
+	c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revokes the current session: its refresh tokens stop working and its access tokens are rejected.
+// @Tags auth
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} middleware.Problem "Unauthorized"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	sessionID := c.GetString("sessionID")
+	if sessionID == "" {
+		c.Error(errUnauthorized)
+		return
+	}
+
+	if err := h.userService.Logout(c.Request.Context(), sessionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func newTokenResponse(tokens *usecase.AuthTokens) TokenResponse {
+	return TokenResponse{
+		Token:            tokens.AccessToken,
+		ExpiresAt:        tokens.AccessExpiresAt.Format(time.RFC3339),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: tokens.RefreshExpiresAt.Format(time.RFC3339),
+	}
+}
+
+// authResult maps the error of a failed login or registration to the
+// outcome it is counted under.
+func authResult(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return metrics.ResultInvalidCredentials
+	case errors.Is(err, domain.ErrValidation):
+		return metrics.ResultInvalidRequest
+	case errors.Is(err, domain.ErrConflict):
+		return metrics.ResultConflict
+	}
+	return metrics.ResultError
 }
@@ -6,31 +6,40 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"go-films-api/internal/config"
 	authHttp "go-films-api/internal/delivery/http"
 	"go-films-api/internal/domain"
+	"go-films-api/internal/metrics"
 	"go-films-api/internal/repository"
 	"go-films-api/internal/usecase"
 )
 
+var testAuth = config.Auth{
+	JWTSecret:       "test-secret-that-is-long-enough-for-hs256",
+	AccessTokenTTL:  time.Hour,
+	RefreshTokenTTL: 30 * 24 * time.Hour,
+}
+
 func TestRegisterHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := new(repository.MockUserRepository)
-	userService := usecase.NewUserService(mockRepo)
-	authHandler := authHttp.NewAuthHandler(userService)
+	userService := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
+	authHandler := authHttp.NewAuthHandler(userService, metrics.New())
 
-	r := gin.Default()
+	r := newTestRouter()
 	r.POST("/register", authHandler.Register)
 
-	mockRepo.On("GetUserByUsername", "newuser").Return(nil, nil)
-	mockRepo.On("CreateUser", mock.Anything).Return(nil)
+	mockRepo.On("GetUserByUsername", mock.Anything, "newuser").Return(nil, nil)
+	mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
 
-	body := `{"username":"newuser","password":"secret"}`
+	body := `{"username":"newuser","password":"Secret@123"}`
 	req, _ := http.NewRequest("POST", "/register", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -51,17 +60,19 @@ func TestLoginHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockRepo := new(repository.MockUserRepository)
-	userService := usecase.NewUserService(mockRepo)
-	authHandler := authHttp.NewAuthHandler(userService)
+	tokenRepo := new(repository.MockTokenRepository)
+	userService := usecase.NewUserService(mockRepo, tokenRepo, testAuth)
+	authHandler := authHttp.NewAuthHandler(userService, metrics.New())
 
-	r := gin.Default()
+	r := newTestRouter()
 	r.POST("/login", authHandler.Login)
 
 	// Provide a known hashed password matching "secret"
 	hashed := "$2a$10$1fybhpdIC527ODopk5/FLu5L5o60g.2p1NGd7Zso75iv.R4siZm3e"
 	user := &domain.User{ID: 1, Username: "alex", Password: hashed}
 
-	mockRepo.On("GetUserByUsername", "alex").Return(user, nil)
+	mockRepo.On("GetUserByUsername", mock.Anything, "alex").Return(user, nil)
+	tokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil)
 
 	body := `{"username":"alex","password":"secret"}`
 	req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(body))
@@ -76,5 +87,128 @@ func TestLoginHandler(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp["token"], "Expected a token in response")
+	assert.NotEmpty(t, resp["refresh_token"], "Expected a refresh token in response")
 	mockRepo.AssertExpectations(t)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestRefreshHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userRepo := new(repository.MockUserRepository)
+	tokenRepo := new(repository.MockTokenRepository)
+	userService := usecase.NewUserService(userRepo, tokenRepo, testAuth)
+	authHandler := authHttp.NewAuthHandler(userService, metrics.New())
+
+	r := newTestRouter()
+	r.POST("/token/refresh", authHandler.Refresh)
+
+	userRepo.On("GetUserByID", mock.Anything, uint(1)).Return(&domain.User{ID: 1, Username: "alex"}, nil)
+	current := &domain.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+	tokenRepo.On("GetRefreshTokenByHash", mock.Anything, mock.Anything).Return(current, nil)
+	tokenRepo.On("RotateRefreshToken", mock.Anything, current, mock.Anything).Return(true, nil)
+
+	body := `{"refresh_token":"some-token"}`
+	req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp["token"])
+	assert.NotEmpty(t, resp["refresh_token"])
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestRefreshHandler_ReusedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokenRepo := new(repository.MockTokenRepository)
+	userService := usecase.NewUserService(new(repository.MockUserRepository), tokenRepo, testAuth)
+	authHandler := authHttp.NewAuthHandler(userService, metrics.New())
+
+	r := newTestRouter()
+	r.POST("/token/refresh", authHandler.Refresh)
+
+	usedAt := time.Now()
+	used := &domain.RefreshToken{ID: 3, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+	tokenRepo.On("GetRefreshTokenByHash", mock.Anything, mock.Anything).Return(used, nil)
+	tokenRepo.On("RevokeTokenFamily", mock.Anything, "family").Return(nil)
+
+	body := `{"refresh_token":"some-token"}`
+	req, _ := http.NewRequest("POST", "/token/refresh", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "refresh token reuse detected")
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestLogoutHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokenRepo := new(repository.MockTokenRepository)
+	userService := usecase.NewUserService(new(repository.MockUserRepository), tokenRepo, testAuth)
+	authHandler := authHttp.NewAuthHandler(userService, metrics.New())
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(1))
+		c.Set("sessionID", "family")
+		c.Next()
+	})
+	r.POST("/logout", authHandler.Logout)
+
+	tokenRepo.On("RevokeTokenFamily", mock.Anything, "family").Return(nil)
+
+	req, _ := http.NewRequest("POST", "/logout", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestAuthHandler_Metrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockRepo := new(repository.MockUserRepository)
+	tokenRepo := new(repository.MockTokenRepository)
+	m := metrics.New()
+	authHandler := authHttp.NewAuthHandler(usecase.NewUserService(mockRepo, tokenRepo, testAuth), m)
+
+	r := newTestRouter()
+	r.POST("/register", authHandler.Register)
+	r.POST("/login", authHandler.Login)
+
+	hashed := "$2a$10$1fybhpdIC527ODopk5/FLu5L5o60g.2p1NGd7Zso75iv.R4siZm3e"
+	mockRepo.On("GetUserByUsername", mock.Anything, "alex").Return(&domain.User{ID: 1, Username: "alex", Password: hashed}, nil)
+	tokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(nil)
+
+	for _, call := range []struct{ path, body string }{
+		{"/login", `{"username":"alex","password":"secret"}`},
+		{"/login", `{"username":"alex","password":"wrong"}`},
+		{"/login", `{"username":"alex"}`},
+		{"/register", `{"username":"alex","password":"Secret@123"}`},
+	} {
+		req, _ := http.NewRequest("POST", call.path, bytes.NewBufferString(call.body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `films_auth_logins_total{result="success"} 1`)
+	assert.Contains(t, w.Body.String(), `films_auth_logins_total{result="invalid_credentials"} 1`)
+	assert.Contains(t, w.Body.String(), `films_auth_logins_total{result="invalid_request"} 1`)
+	assert.Contains(t, w.Body.String(), `films_auth_registrations_total{result="conflict"} 1`)
 }
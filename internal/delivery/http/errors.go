@@ -0,0 +1,12 @@
+package http
+
+import "go-films-api/internal/domain"
+
+// Errors raised by the handlers themselves. Like the service errors they are
+// passed to c.Error and rendered by middleware.ErrorHandler.
+var (
+	errInvalidBody  = &domain.Error{Kind: domain.ErrValidation, Code: "invalid_body", Message: "invalid request body"}
+	errUnauthorized = domain.Unauthorized("unauthorized", "unauthorized")
+
+	errInvalidFilmSort = domain.Invalid("sort", "invalid sort field, expected title, release_date, created_at, director, average_rating or review_count")
+)
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-films-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errIfMatchRequired = domain.PreconditionRequired("if_match_required", "If-Match header with the film's ETag is required")
+	errInvalidIfMatch  = domain.Invalid("If-Match", "If-Match must be a single ETag returned by GET /films/{id}")
+)
+
+// filmETag builds the entity tag of a film representation. It starts with
+// the film version, which If-Match is checked against, and ends with a digest
+// of the body so that changes that do not bump the version, such as new
+// reviews, still invalidate cached copies.
+func filmETag(version uint, body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf(`"%d-%x"`, version, sum[:8])
+}
+
+// writeFilm renders film with its ETag, answering 304 Not Modified when the
+// request's If-None-Match already names it.
+func writeFilm(c *gin.Context, status int, film *domain.Film) {
+	body, err := json.Marshal(film)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	etag := filmETag(film.Version, body)
+	c.Header("ETag", etag)
+
+	if status == http.StatusOK && etagListMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", body)
+}
+
+// etagListMatches reports whether the If-None-Match header value names etag,
+// using the weak comparison RFC 9110 prescribes for If-None-Match.
+func etagListMatches(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIfMatch returns the film version named by the If-Match header. The
+// version prefix of an ETag from filmETag is enough, so clients may also send
+// the Version field of the film as "3". "*" matches any version and yields 0.
+func parseIfMatch(c *gin.Context) (uint, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.Error(errIfMatchRequired)
+		return 0, false
+	}
+	if header == "*" {
+		return 0, true
+	}
+
+	// Weak tags never match under the strong comparison If-Match requires
+	if strings.HasPrefix(header, "W/") {
+		c.Error(domain.ErrFilmModified)
+		return 0, false
+	}
+	tag, ok := strings.CutPrefix(header, `"`)
+	if !ok || strings.Contains(tag, ",") {
+		c.Error(errInvalidIfMatch)
+		return 0, false
+	}
+	tag, ok = strings.CutSuffix(tag, `"`)
+	if !ok {
+		c.Error(errInvalidIfMatch)
+		return 0, false
+	}
+	versionPart, _, _ := strings.Cut(tag, "-")
+	version, err := strconv.ParseUint(versionPart, 10, 32)
+	if err != nil || version == 0 {
+		c.Error(errInvalidIfMatch)
+		return 0, false
+	}
+	return uint(version), true
+}
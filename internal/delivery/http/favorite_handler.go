@@ -0,0 +1,117 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FavoriteHandler struct {
+	favoriteService usecase.FavoriteService
+}
+
+func NewFavoriteHandler(fs usecase.FavoriteService) *FavoriteHandler {
+	return &FavoriteHandler{favoriteService: fs}
+}
+
+// GetFavorites godoc
+// @Summary List my favorite films
+// @Description Retrieves a paginated list of the authenticated user's favorite films, most recently added first.
+// @Tags favorites
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} FilmListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/favorites [get]
+func (h *FavoriteHandler) GetFavorites(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.favoriteService.ListFavorites(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeFilmPage(c, result, false)
+}
+
+// AddFavorite godoc
+// @Summary Add a favorite film
+// @Description Marks a film as a favorite of the authenticated user. Adding a film twice has no effect.
+// @Tags favorites
+// @Security BearerAuth
+// @Param filmID path int true "Film ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} middleware.Problem "Invalid film ID"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/favorites/{filmID} [post]
+func (h *FavoriteHandler) AddFavorite(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	filmID, ok := parseFilmIDParam(c, "filmID")
+	if !ok {
+		return
+	}
+
+	if err := h.favoriteService.AddFavorite(c.Request.Context(), userID, filmID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveFavorite godoc
+// @Summary Remove a favorite film
+// @Description Removes a film from the authenticated user's favorites.
+// @Tags favorites
+// @Security BearerAuth
+// @Param filmID path int true "Film ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} middleware.Problem "Invalid film ID"
+// @Failure 404 {object} middleware.Problem "Film is not in your favorites"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/favorites/{filmID} [delete]
+func (h *FavoriteHandler) RemoveFavorite(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	filmID, ok := parseFilmIDParam(c, "filmID")
+	if !ok {
+		return
+	}
+
+	if err := h.favoriteService.RemoveFavorite(c.Request.Context(), userID, filmID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseFilmIDParam(c *gin.Context, name string) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		c.Error(domain.Invalid("id", "invalid film ID"))
+		return 0, false
+	}
+	return uint(id64), true
+}
@@ -0,0 +1,128 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	favoriteHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFavoriteService struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteService) ListFavorites(ctx context.Context, userID uint, page, pageSize int) (*usecase.FilmPage, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	if result, ok := args.Get(0).(*usecase.FilmPage); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFavoriteService) AddFavorite(ctx context.Context, userID, filmID uint) error {
+	args := m.Called(ctx, userID, filmID)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteService) RemoveFavorite(ctx context.Context, userID, filmID uint) error {
+	args := m.Called(ctx, userID, filmID)
+	return args.Error(0)
+}
+
+func setupFavoriteRouter(service *MockFavoriteService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	handler := favoriteHttp.NewFavoriteHandler(service)
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.GET("/me/favorites", handler.GetFavorites)
+	r.POST("/me/favorites/:filmID", handler.AddFavorite)
+	r.DELETE("/me/favorites/:filmID", handler.RemoveFavorite)
+	return r
+}
+
+func TestGetFavorites(t *testing.T) {
+	mockService := new(MockFavoriteService)
+	r := setupFavoriteRouter(mockService)
+
+	mockService.On("ListFavorites", mock.Anything, uint(5), 1, 20).Return(&usecase.FilmPage{
+		Films:    []domain.Film{{ID: 3, Title: "Heat"}},
+		Total:    1,
+		Page:     1,
+		PageSize: 20,
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/me/favorites", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp favoriteHttp.FilmListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Empty(t, resp.Next)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddFavorite(t *testing.T) {
+	mockService := new(MockFavoriteService)
+	r := setupFavoriteRouter(mockService)
+
+	mockService.On("AddFavorite", mock.Anything, uint(5), uint(3)).Return(nil)
+
+	req, _ := http.NewRequest("POST", "/me/favorites/3", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddFavorite_FilmNotFound(t *testing.T) {
+	mockService := new(MockFavoriteService)
+	r := setupFavoriteRouter(mockService)
+
+	mockService.On("AddFavorite", mock.Anything, uint(5), uint(3)).Return(domain.NotFound("film_not_found", "film not found"))
+
+	req, _ := http.NewRequest("POST", "/me/favorites/3", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRemoveFavorite_NotFavorite(t *testing.T) {
+	mockService := new(MockFavoriteService)
+	r := setupFavoriteRouter(mockService)
+
+	mockService.On("RemoveFavorite", mock.Anything, uint(5), uint(3)).Return(domain.NotFound("favorite_not_found", "film is not in your favorites"))
+
+	req, _ := http.NewRequest("DELETE", "/me/favorites/3", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "film is not in your favorites")
+}
+
+func TestAddFavorite_InvalidID(t *testing.T) {
+	r := setupFavoriteRouter(new(MockFavoriteService))
+
+	req, _ := http.NewRequest("POST", "/me/favorites/abc", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-films-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// csvExportColumns are the columns of a CSV export. The first five are the
+// ones POST /films/import reads.
+var csvExportColumns = []string{
+	"title", "release_date", "synopsis", "genres", "credits",
+	"id", "average_rating", "review_count", "created_at", "updated_at",
+}
+
+// filmExporter writes films in one export format. Begin is called before the
+// first batch and End after the last one, even when there were no films.
+type filmExporter interface {
+	Begin() error
+	Write(films []domain.Film) error
+	End() error
+}
+
+var exportFormats = map[string]struct {
+	contentType string
+	newExporter func(w io.Writer) filmExporter
+}{
+	"csv":    {"text/csv; charset=utf-8", newCSVExporter},
+	"ndjson": {"application/x-ndjson", newNDJSONExporter},
+	"json":   {"application/json; charset=utf-8", newJSONExporter},
+}
+
+// ExportFilms godoc
+// @Summary Export films
+// @Description Downloads every film matching the same filters and sort order as GET /films, as CSV, JSON Lines or a JSON array. Films are streamed from the database, so exports are not paginated. The first five CSV columns (title, release_date, synopsis, genres and credits) use the format POST /films/import reads.
+// @Tags films
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce json
+// @Param format query string false "Export format (default csv)" Enums(csv, ndjson, json)
+// @Param q query string false "Full-text search over title, synopsis, cast and director"
+// @Param title query string false "Film title"
+// @Param director query string false "Director name (partial match)"
+// @Param genre query string false "Comma-separated list of genre slugs, e.g. action,drama"
+// @Param release_date query string false "Film release date (YYYY-MM-DD)"
+// @Param release_date_from query string false "Earliest release date, inclusive (YYYY-MM-DD)"
+// @Param release_date_to query string false "Latest release date, inclusive (YYYY-MM-DD)"
+// @Param year query int false "Release year"
+// @Param created_by query int false "ID of the user who created the film"
+// @Param created_after query string false "Only films created after this time (YYYY-MM-DD or RFC 3339)"
+// @Param sort query string false "Sort field: title, release_date, created_at, director, average_rating or review_count. Prefix with - for descending order"
+// @Success 200 {array} domain.Film
+// @Failure 400 {object} middleware.Problem "Invalid format or query parameter"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/export [get]
+func (h *FilmHandler) ExportFilms(c *gin.Context) {
+	name := c.DefaultQuery("format", "csv")
+	format, ok := exportFormats[name]
+	if !ok {
+		c.Error(domain.Invalid("format", "format must be csv, ndjson or json"))
+		return
+	}
+	query, ok := parseFilmFilters(c)
+	if !ok {
+		return
+	}
+
+	// Nothing is written before the first batch arrives, so a query that
+	// fails straight away still gets a problem response
+	exporter := format.newExporter(c.Writer)
+	started := false
+	begin := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		c.Header("Content-Type", format.contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="films-%s.%s"`,
+			time.Now().UTC().Format("20060102-150405"), name))
+		c.Status(http.StatusOK)
+		return exporter.Begin()
+	}
+
+	err := h.filmService.ExportFilms(c.Request.Context(), query, func(films []domain.Film) error {
+		if err := begin(); err != nil {
+			return err
+		}
+		if err := exporter.Write(films); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err == nil {
+		if err = begin(); err == nil {
+			err = exporter.End()
+		}
+	}
+	if err != nil {
+		// Once streaming has started the error can only be logged, and the
+		// truncated download is the client's hint that something went wrong
+		c.Error(err)
+	}
+}
+
+// csvFormulaPrefixes are the first characters that make spreadsheet
+// applications read a cell as a formula.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// csvCell escapes value so that spreadsheets show it as text: a value that
+// could be read as a formula is prefixed with a single quote.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+type csvExporter struct {
+	w *csv.Writer
+}
+
+func newCSVExporter(w io.Writer) filmExporter {
+	return &csvExporter{w: csv.NewWriter(w)}
+}
+
+func (e *csvExporter) Begin() error {
+	return e.w.Write(csvExportColumns)
+}
+
+func (e *csvExporter) Write(films []domain.Film) error {
+	for _, film := range films {
+		var releaseDate string
+		if film.ReleaseDate != nil {
+			releaseDate = film.ReleaseDate.Format("2006-01-02")
+		}
+		genres := make([]string, len(film.Genres))
+		for i, genre := range film.Genres {
+			genres[i] = genre.Slug
+		}
+		credits := make([]string, len(film.Credits))
+		for i, credit := range film.Credits {
+			credits[i] = fmt.Sprintf("%s:%d", credit.Role, credit.PersonID)
+			if credit.Character != "" {
+				credits[i] += ":" + credit.Character
+			}
+		}
+
+		if err := e.w.Write([]string{
+			csvCell(film.Title),
+			releaseDate,
+			csvCell(film.Synopsis),
+			csvCell(strings.Join(genres, ";")),
+			csvCell(strings.Join(credits, ";")),
+			strconv.FormatUint(uint64(film.ID), 10),
+			strconv.FormatFloat(film.AverageRating, 'f', 2, 64),
+			strconv.Itoa(film.ReviewCount),
+			film.CreatedAt.UTC().Format(time.RFC3339),
+			film.UpdatedAt.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExporter) End() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonExporter writes one film object per line.
+type ndjsonExporter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONExporter(w io.Writer) filmExporter {
+	return &ndjsonExporter{enc: json.NewEncoder(w)}
+}
+
+func (e *ndjsonExporter) Begin() error { return nil }
+
+func (e *ndjsonExporter) Write(films []domain.Film) error {
+	for _, film := range films {
+		if err := e.enc.Encode(film); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *ndjsonExporter) End() error { return nil }
+
+// jsonExporter writes a single JSON array, one element at a time.
+type jsonExporter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONExporter(w io.Writer) filmExporter {
+	return &jsonExporter{w: w}
+}
+
+func (e *jsonExporter) Begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonExporter) Write(films []domain.Film) error {
+	for _, film := range films {
+		data, err := json.Marshal(film)
+		if err != nil {
+			return err
+		}
+		if e.count > 0 {
+			data = append([]byte(","), data...)
+		}
+		if _, err := e.w.Write(data); err != nil {
+			return err
+		}
+		e.count++
+	}
+	return nil
+}
+
+func (e *jsonExporter) End() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
@@ -0,0 +1,172 @@
+package http_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	filmHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	usecase "go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupExportRouter(mockService *MockFilmService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films/export", filmHandler.ExportFilms)
+	return r
+}
+
+func exportedFilms() []domain.Film {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	return []domain.Film{
+		{
+			ID:          10,
+			Title:       "Heat",
+			ReleaseDate: datePtr(1995, 12, 15),
+			Synopsis:    "A heist, then a chase",
+			Genres:      []domain.Genre{{Slug: "crime"}, {Slug: "drama"}},
+			Credits: []domain.FilmCredit{
+				{PersonID: 1, Role: domain.CreditRoleDirector},
+				{PersonID: 2, Role: domain.CreditRoleActor, Character: "Neil McCauley"},
+			},
+			AverageRating: 4.5,
+			ReviewCount:   2,
+			CreatedAt:     created,
+			UpdatedAt:     created,
+		},
+		{ID: 11, Title: "Ronin", CreatedAt: created, UpdatedAt: created},
+	}
+}
+
+func TestExportFilms_CSV(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupExportRouter(mockService)
+
+	mockService.On("ExportFilms", mock.Anything, usecase.ListFilmsQuery{Director: "Mann", Sort: "-release_date"}).
+		Return(exportedFilms(), nil)
+
+	req, _ := http.NewRequest("GET", "/films/export?director=Mann&sort=-release_date", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="films-\d{8}-\d{6}\.csv"$`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, strings.Join([]string{
+		"title,release_date,synopsis,genres,credits,id,average_rating,review_count,created_at,updated_at",
+		`Heat,1995-12-15,"A heist, then a chase",crime;drama,director:1;actor:2:Neil McCauley,10,4.50,2,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z`,
+		"Ronin,,,,,11,0.00,0,2024-05-01T12:00:00Z,2024-05-01T12:00:00Z",
+		"",
+	}, "\n"), w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestExportFilms_CSVFormulas(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupExportRouter(mockService)
+
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockService.On("ExportFilms", mock.Anything, usecase.ListFilmsQuery{}).Return([]domain.Film{
+		{ID: 1, Title: "=HYPERLINK(\"http://evil.example\")", Synopsis: "+1 for this", CreatedAt: created, UpdatedAt: created},
+		{ID: 2, Title: "-ish", Synopsis: "@SUM(A1)", CreatedAt: created, UpdatedAt: created},
+		{ID: 3, Title: "\tTabbed", Synopsis: "\rReturned", CreatedAt: created, UpdatedAt: created},
+		{ID: 4, Title: "Safe = sound", Synopsis: "", CreatedAt: created, UpdatedAt: created},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/films/export", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if assert.NoError(t, err) && assert.Len(t, records, 5) {
+		assert.Equal(t, []string{"'=HYPERLINK(\"http://evil.example\")", "'+1 for this"}, []string{records[1][0], records[1][2]})
+		assert.Equal(t, []string{"'-ish", "'@SUM(A1)"}, []string{records[2][0], records[2][2]})
+		assert.Equal(t, []string{"'\tTabbed", "'\rReturned"}, []string{records[3][0], records[3][2]})
+		assert.Equal(t, []string{"Safe = sound", ""}, []string{records[4][0], records[4][2]})
+	}
+}
+
+func TestExportFilms_NDJSON(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupExportRouter(mockService)
+
+	mockService.On("ExportFilms", mock.Anything, usecase.ListFilmsQuery{}).Return(exportedFilms(), nil)
+
+	req, _ := http.NewRequest("GET", "/films/export?format=ndjson", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `.ndjson"`)
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	var film domain.Film
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &film))
+	assert.Equal(t, "Ronin", film.Title)
+}
+
+func TestExportFilms_JSON(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupExportRouter(mockService)
+
+	mockService.On("ExportFilms", mock.Anything, usecase.ListFilmsQuery{Year: 1995}).Return(exportedFilms(), nil)
+	mockService.On("ExportFilms", mock.Anything, usecase.ListFilmsQuery{Year: 2001}).Return(nil, nil)
+
+	req, _ := http.NewRequest("GET", "/films/export?format=json&year=1995", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var films []domain.Film
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &films))
+	assert.Len(t, films, 2)
+	assert.Equal(t, "Heat", films[0].Title)
+
+	// An empty export is still a valid document
+	req, _ = http.NewRequest("GET", "/films/export?format=json&year=2001", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `.json"`)
+}
+
+func TestExportFilms_Errors(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupExportRouter(mockService)
+
+	mockService.On("ExportFilms", mock.Anything, usecase.ListFilmsQuery{Title: "Heat"}).Return(nil, errors.New("db down"))
+
+	for _, tc := range []struct {
+		query  string
+		status int
+	}{
+		{"format=xml", http.StatusBadRequest},
+		{"year=abc", http.StatusBadRequest},
+		{"sort=budget", http.StatusBadRequest},
+		{"title=Heat", http.StatusInternalServerError},
+	} {
+		req, _ := http.NewRequest("GET", "/films/export?"+tc.query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc.query)
+		assert.Empty(t, w.Header().Get("Content-Disposition"), tc.query)
+	}
+	mockService.AssertExpectations(t)
+}
@@ -1,10 +1,13 @@
 package http
 
 import (
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"go-films-api/internal/domain"
 	"go-films-api/internal/usecase"
 
 	"github.com/gin-gonic/gin"
@@ -14,22 +17,35 @@ type FilmHandler struct {
 	filmService usecase.FilmService
 }
 
-type CreateFilmRequest struct {
-	Title       string `json:"title" binding:"required"`
-	Director    string `json:"director"`
-	ReleaseDate string `json:"release_date"`
-	Cast        string `json:"cast"`
-	Genre       string `json:"genre"`
-	Synopsis    string `json:"synopsis"`
+// FilmRequest is the full representation of a film's editable fields, used
+// to create a film and to replace one with PUT. It is checked by the film
+// service, which reports every invalid field at once, so the title is not
+// enforced by binding.
+type FilmRequest struct {
+	Title       string          `json:"title" validate:"required" maxLength:"255"`
+	ReleaseDate string          `json:"release_date" example:"1995-12-15"`
+	Genres      []string        `json:"genres" maxItems:"10"`
+	Credits     []CreditRequest `json:"credits" maxItems:"200"`
+	Synopsis    string          `json:"synopsis" maxLength:"10000"`
 }
 
-type UpdateFilmRequest struct {
-	Title       *string `json:"title"`
-	Director    *string `json:"director"`
-	ReleaseDate *string `json:"release_date"`
-	Cast        *string `json:"cast"`
-	Genre       *string `json:"genre"`
-	Synopsis    *string `json:"synopsis"`
+type CreditRequest struct {
+	PersonID     uint   `json:"person_id" binding:"required"`
+	Role         string `json:"role" binding:"required" enums:"director,actor,writer,composer"`
+	Character    string `json:"character" maxLength:"255"`
+	BillingOrder *int   `json:"billing_order"`
+}
+
+type FilmListResponse struct {
+	Items    []domain.Film `json:"items"`
+	Total    int64         `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+	Next     string        `json:"next,omitempty"`
+	Prev     string        `json:"prev,omitempty"`
+
+	// NextCursor can be passed back as ?cursor= to fetch the next page with keyset pagination
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 func NewFilmHandler(fs usecase.FilmService) *FilmHandler {
@@ -38,249 +54,496 @@ func NewFilmHandler(fs usecase.FilmService) *FilmHandler {
 
 // GetFilms godoc
 // @Summary Get a list of films
-// @Description Retrieves a list of films, optionally filtered by title, genre, and release date.
+// @Description Retrieves a paginated list of films, optionally filtered by title, director, genres, release date range, year and creator.
+// @Description With q, films are searched by title, synopsis, cast and director, ranked by relevance and returned with a Score and highlighted snippets.
 // @Tags films
 // @Security BearerAuth
 // @Accept json
 // @Produce json
+// @Param q query string false "Full-text search over title, synopsis, cast and director"
 // @Param title query string false "Film title"
-// @Param genre query string false "Film genre"
+// @Param director query string false "Director name (partial match)"
+// @Param genre query string false "Comma-separated list of genre slugs, e.g. action,drama"
 // @Param release_date query string false "Film release date (YYYY-MM-DD)"
-// @Success 200 {array} domain.Film
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Param release_date_from query string false "Earliest release date, inclusive (YYYY-MM-DD)"
+// @Param release_date_to query string false "Latest release date, inclusive (YYYY-MM-DD)"
+// @Param year query int false "Release year"
+// @Param created_by query int false "ID of the user who created the film"
+// @Param created_after query string false "Only films created after this time (YYYY-MM-DD or RFC 3339)"
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Param sort query string false "Sort field: title, release_date, created_at, director, average_rating or review_count. Prefix with - for descending order"
+// @Param cursor query string false "Opaque cursor from a previous response's next_cursor; replaces page"
+// @Success 200 {object} FilmListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
 // @Router /films [get]
 func (h *FilmHandler) GetFilms(c *gin.Context) {
-	title := c.Query("title")
-	genre := c.Query("genre")
+	query, ok := parseFilmFilters(c)
+	if !ok {
+		return
+	}
+	if query.Page, query.PageSize, ok = parsePagination(c); !ok {
+		return
+	}
+	query.Cursor = c.Query("cursor")
+
+	result, err := h.filmService.ListFilms(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeFilmPage(c, result, query.Cursor != "")
+}
+
+// parseFilmFilters reads the filters and sort order of GET /films from the
+// query string.
+func parseFilmFilters(c *gin.Context) (usecase.ListFilmsQuery, bool) {
+	query := usecase.ListFilmsQuery{
+		Query:    c.Query("q"),
+		Title:    c.Query("title"),
+		Director: c.Query("director"),
+	}
+
+	for _, g := range strings.Split(c.Query("genre"), ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			query.Genres = append(query.Genres, g)
+		}
+	}
 
-	releaseDateStr := c.Query("release_date")
-	var releaseDate time.Time
 	var err error
-	if releaseDateStr != "" {
-		releaseDate, err = time.Parse("2006-01-02", releaseDateStr)
+	for _, param := range []struct {
+		name string
+		dest *time.Time
+	}{
+		{"release_date", &query.ReleaseDate},
+		{"release_date_from", &query.ReleaseDateFrom},
+		{"release_date_to", &query.ReleaseDateTo},
+	} {
+		if value := c.Query(param.name); value != "" {
+			*param.dest, err = time.Parse("2006-01-02", value)
+			if err != nil {
+				c.Error(domain.Invalid(param.name, "invalid "+param.name+" format, expected YYYY-MM-DD"))
+				return query, false
+			}
+		}
+	}
+	if !query.ReleaseDateFrom.IsZero() && !query.ReleaseDateTo.IsZero() && query.ReleaseDateFrom.After(query.ReleaseDateTo) {
+		c.Error(domain.Invalid("release_date_from", "release_date_from must not be after release_date_to"))
+		return query, false
+	}
+
+	if value := c.Query("created_after"); value != "" {
+		query.CreatedAfter, err = time.Parse(time.RFC3339, value)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid release_date format, expected YYYY-MM-DD"})
-			return
+			query.CreatedAfter, err = time.Parse("2006-01-02", value)
+		}
+		if err != nil {
+			c.Error(domain.Invalid("created_after", "invalid created_after format, expected YYYY-MM-DD or RFC 3339"))
+			return query, false
 		}
 	}
 
-	films, err := h.filmService.ListFilms(title, genre, releaseDate)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch films"})
-		return
+	if value := c.Query("year"); value != "" {
+		query.Year, err = strconv.Atoi(value)
+		if err != nil || query.Year < 1 || query.Year > 9999 {
+			c.Error(domain.Invalid("year", "year must be a number between 1 and 9999"))
+			return query, false
+		}
+	}
+
+	if value := c.Query("created_by"); value != "" {
+		id64, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || id64 == 0 {
+			c.Error(domain.Invalid("created_by", "invalid created_by user ID"))
+			return query, false
+		}
+		query.CreatedBy = uint(id64)
 	}
 
-	c.JSON(http.StatusOK, films)
+	query.Sort = c.Query("sort")
+	if query.Sort != "" && !usecase.IsValidFilmSort(strings.TrimPrefix(query.Sort, "-")) {
+		c.Error(errInvalidFilmSort)
+		return query, false
+	}
+
+	return query, true
+}
+
+// writeFilmPage renders a page of films as a FilmListResponse with links to
+// the neighbouring pages. Keyset pages can only be walked forward.
+func writeFilmPage(c *gin.Context, result *usecase.FilmPage, keyset bool) {
+	resp := FilmListResponse{
+		Items:      result.Films,
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		NextCursor: result.NextCursor,
+	}
+	if resp.Items == nil {
+		resp.Items = []domain.Film{}
+	}
+	if keyset {
+		if result.HasNext() {
+			resp.Next = listLink(c, "cursor", result.NextCursor)
+		}
+	} else {
+		if result.HasNext() {
+			resp.Next = listLink(c, "page", strconv.Itoa(result.Page+1))
+		}
+		if result.HasPrev() {
+			resp.Prev = listLink(c, "page", strconv.Itoa(result.Page-1))
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parsePagination reads the page and page_size query parameters, writing a
+// validation error when either is out of range.
+func parsePagination(c *gin.Context) (page, pageSize int, ok bool) {
+	var err error
+	page = 1
+	if pageStr := c.Query("page"); pageStr != "" {
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			c.Error(domain.Invalid("page", "page must be a positive number"))
+			return 0, 0, false
+		}
+	}
+
+	pageSize = usecase.DefaultPageSize
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		pageSize, err = strconv.Atoi(pageSizeStr)
+		if err != nil || pageSize < 1 || pageSize > usecase.MaxPageSize {
+			c.Error(domain.Invalid("page_size", "page_size must be between 1 and "+strconv.Itoa(usecase.MaxPageSize)))
+			return 0, 0, false
+		}
+	}
+	return page, pageSize, true
+}
+
+// listLink returns the current request URL with the given query parameter replaced.
+func listLink(c *gin.Context, key, value string) string {
+	u := *c.Request.URL
+	q := u.Query()
+	if key == "cursor" {
+		q.Del("page")
+	}
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
 }
 
 // GetFilmDetails godoc
 // @Summary Get details of a specific film
-// @Description Retrieves the details of a film by ID, including the creator user.
+// @Description Retrieves the details of a film by ID, including the creator user. The response carries an ETag; send it back as If-None-Match to get a 304 when the film is unchanged, or as If-Match to update it.
 // @Tags films
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "Film ID"
+// @Param If-None-Match header string false "ETag of a cached copy"
 // @Success 200 {object} domain.Film
-// @Failure 400 {object} map[string]string "Invalid Film ID"
-// @Failure 404 {object} map[string]string "Film not found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Header 200 {string} ETag "Entity tag of the film"
+// @Success 304 "Not Modified"
+// @Failure 400 {object} middleware.Problem "Invalid Film ID"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
 // @Router /films/{id} [get]
 func (h *FilmHandler) GetFilmDetails(c *gin.Context) {
 	idParam := c.Param("id")
 
 	id64, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid film ID"})
+		c.Error(domain.Invalid("id", "invalid film ID"))
 		return
 	}
 	filmID := uint(id64)
 
-	film, err := h.filmService.GetFilmDetails(filmID)
+	film, err := h.filmService.GetFilmDetails(c.Request.Context(), filmID)
 	if err != nil {
-		if err.Error() == "film not found" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "film not found"})
-		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not retrieve film details"})
-		}
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, film)
+	writeFilm(c, http.StatusOK, film)
 }
 
 // CreateFilm godoc
 // @Summary Create a new film
-// @Description Adds a new film to the database, linked to the authenticated user.
+// @Description Adds a new film to the database, linked to the authenticated user. Text fields are trimmed; all invalid fields are reported together in the errors list.
 // @Tags films
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param film body CreateFilmRequest true "Film details"
+// @Param film body FilmRequest true "Film details"
 // @Success 201 {object} domain.Film
-// @Failure 400 {object} map[string]string "Invalid input"
-// @Failure 409 {object} map[string]string "Film already exists"
+// @Header 201 {string} ETag "Entity tag of the film"
+// @Failure 400 {object} middleware.Problem "Invalid fields, unknown genre or invalid credit"
+// @Failure 409 {object} middleware.Problem "Film already exists"
 // @Router /films [post]
 func (h *FilmHandler) CreateFilm(c *gin.Context) {
-	var req CreateFilmRequest
+	var req FilmRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		c.Error(errInvalidBody)
 		return
 	}
 
-	var rd time.Time
-	var err error
-	if req.ReleaseDate != "" {
-		rd, err = time.Parse("2006-01-02", req.ReleaseDate)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid release_date format, expected YYYY-MM-DD"})
-			return
-		}
+	rd, ok := parseReleaseDate(c, req.ReleaseDate)
+	if !ok {
+		return
 	}
 
 	userIDValue, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(errUnauthorized)
 		return
 	}
 
-	film, createErr := h.filmService.CreateFilm(
-		req.Title,
-		req.Director,
-		req.Cast,
-		req.Genre,
-		req.Synopsis,
-		rd,
-		userIDValue.(uint),
-	)
+	film, createErr := h.filmService.CreateFilm(c.Request.Context(), usecase.CreateFilmData{
+		Title:       req.Title,
+		ReleaseDate: rd,
+		Genres:      req.Genres,
+		Credits:     toCreditData(req.Credits),
+		Synopsis:    req.Synopsis,
+	}, userIDValue.(uint))
 	if createErr != nil {
-		c.JSON(http.StatusConflict, gin.H{"error": createErr.Error()})
+		c.Error(createErr)
 		return
 	}
 
-	c.JSON(http.StatusCreated, film)
+	writeFilm(c, http.StatusCreated, film)
 }
 
 // UpdateFilm godoc
-// @Summary Update a film
-// @Description Updates the details of a film, only allowed for the creator user.
+// @Summary Replace a film
+// @Description Replaces all editable fields of a film, only allowed for the creator user, editors and admins. Fields left out are cleared. If-Match must carry the ETag from GET /films/{id}; the update is rejected with 412 when the film changed since.
 // @Tags films
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "Film ID"
-// @Param film body UpdateFilmRequest true "Film details"
+// @Param If-Match header string true "ETag of the film being updated"
+// @Param film body FilmRequest true "Film details"
 // @Success 200 {object} domain.Film
-// @Failure 400 {object} map[string]string "Invalid input"
-// @Failure 403 {object} map[string]string "Forbidden: only creator can update this film"
-// @Failure 404 {object} map[string]string "Film not found"
-// @Failure 409 {object} map[string]string "Could not update film"
+// @Header 200 {string} ETag "Entity tag of the updated film"
+// @Failure 400 {object} middleware.Problem "Invalid fields, unknown genre or invalid credit"
+// @Failure 403 {object} middleware.Problem "Forbidden: only creator can update this film"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 409 {object} middleware.Problem "Film title already taken"
+// @Failure 412 {object} middleware.Problem "Film modified since it was fetched"
+// @Failure 428 {object} middleware.Problem "If-Match header missing"
 // @Router /films/{id} [put]
 func (h *FilmHandler) UpdateFilm(c *gin.Context) {
-	idParam := c.Param("id")
-	id64, err := strconv.ParseUint(idParam, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid film ID"})
+	filmID, ok := parseFilmID(c)
+	if !ok {
 		return
 	}
-	filmID := uint(id64)
-
-	userIDValue, exists := c.Get("userID")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
-	userID, ok := userIDValue.(uint)
+	version, ok := parseIfMatch(c)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
 		return
 	}
 
-	var req UpdateFilmRequest
+	var req FilmRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		c.Error(errInvalidBody)
+		return
+	}
+	h.replaceFilm(c, filmID, userID, version, req)
+}
+
+// PatchFilm godoc
+// @Summary Patch a film
+// @Description Changes some fields of a film with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902), only allowed for the creator user, editors and admins. The patch applies to the FilmRequest representation of the film; null in a merge patch, or removing a field, clears it. If-Match must carry the ETag from GET /films/{id}.
+// @Tags films
+// @Security BearerAuth
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param If-Match header string true "ETag of the film being updated"
+// @Param patch body object true "Merge patch object, or array of JSON Patch operations"
+// @Success 200 {object} domain.Film
+// @Header 200 {string} ETag "Entity tag of the updated film"
+// @Failure 400 {object} middleware.Problem "Invalid patch or invalid fields"
+// @Failure 403 {object} middleware.Problem "Forbidden: only creator can update this film"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 409 {object} middleware.Problem "Patch cannot be applied, or film title already taken"
+// @Failure 412 {object} middleware.Problem "Film modified since it was fetched"
+// @Failure 415 {object} middleware.Problem "Unsupported patch format"
+// @Failure 428 {object} middleware.Problem "If-Match header missing"
+// @Router /films/{id} [patch]
+func (h *FilmHandler) PatchFilm(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	version, ok := parseIfMatch(c)
+	if !ok {
+		return
+	}
+	format, ok := patchFormat(c)
+	if !ok {
+		return
+	}
+	patch, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchSize))
+	if err != nil {
+		c.Error(errInvalidBody)
 		return
 	}
 
-	var releaseDatePtr *time.Time
-	if req.ReleaseDate != nil && *req.ReleaseDate != "" {
-		rd, err := time.Parse("2006-01-02", *req.ReleaseDate)
+	film, err := h.filmService.GetFilmDetails(c.Request.Context(), filmID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	req, err := applyFilmPatch(format, newFilmRequest(film), patch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// The patch was applied to this version, so the update must not overwrite a newer one
+	if version == 0 {
+		version = film.Version
+	}
+	h.replaceFilm(c, filmID, userID, version, req)
+}
+
+// replaceFilm overwrites every editable field of a film with req. A zero
+// version, from If-Match: *, stands for the version current when the request
+// is handled, so that a concurrent update still fails with 412.
+func (h *FilmHandler) replaceFilm(c *gin.Context, filmID, userID, version uint, req FilmRequest) {
+	releaseDate, ok := parseReleaseDate(c, req.ReleaseDate)
+	if !ok {
+		return
+	}
+	if version == 0 {
+		film, err := h.filmService.GetFilmDetails(c.Request.Context(), filmID)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid release_date format, expected YYYY-MM-DD"})
+			c.Error(err)
 			return
 		}
-		releaseDatePtr = &rd
-	}
-
-	data := usecase.UpdateFilmData{
-		Title:       req.Title,
-		Director:    req.Director,
-		Cast:        req.Cast,
-		Genre:       req.Genre,
-		Synopsis:    req.Synopsis,
-		ReleaseDate: releaseDatePtr,
+		version = film.Version
 	}
+	genres := req.Genres
+	credits := toCreditData(req.Credits)
 
-	updated, err := h.filmService.UpdateFilm(filmID, userID, data)
+	updated, err := h.filmService.UpdateFilm(c.Request.Context(), filmID, userID, currentRole(c), usecase.UpdateFilmData{
+		Title:       &req.Title,
+		ReleaseDate: &releaseDate,
+		Genres:      &genres,
+		Credits:     &credits,
+		Synopsis:    &req.Synopsis,
+		Version:     version,
+	})
 	if err != nil {
-		switch err.Error() {
-		case "film not found":
-			c.JSON(http.StatusNotFound, gin.H{"error": "film not found"})
-		case "forbidden: only creator can update this film":
-			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: only creator can update this film"})
-		default:
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-		}
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, updated)
+	writeFilm(c, http.StatusOK, updated)
 }
 
 // DeleteFilm godoc
 // @Summary Delete a film
-// @Description Deletes a film from the database, only allowed for the creator user.
+// @Description Moves a film to the trash, only allowed for the creator user and admins. Trashed films can be restored until they are purged.
 // @Tags films
 // @Security BearerAuth
 // @Param id path int true "Film ID"
 // @Success 204 "No Content"
-// @Failure 400 {object} map[string]string "Invalid Film ID"
-// @Failure 403 {object} map[string]string "Forbidden: only creator can delete this film"
-// @Failure 404 {object} map[string]string "Film not found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Failure 400 {object} middleware.Problem "Invalid Film ID"
+// @Failure 403 {object} middleware.Problem "Forbidden: only creator can delete this film"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
 // @Router /films/{id} [delete]
 func (h *FilmHandler) DeleteFilm(c *gin.Context) {
 	idParam := c.Param("id")
 	id64, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid film ID"})
+		c.Error(domain.Invalid("id", "invalid film ID"))
 		return
 	}
 	filmID := uint(id64)
 
 	userIDVal, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(errUnauthorized)
 		return
 	}
 	userID, ok := userIDVal.(uint)
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user id"})
+		c.Error(errUnauthorized)
 		return
 	}
 
-	err = h.filmService.DeleteFilm(filmID, userID)
+	err = h.filmService.DeleteFilm(c.Request.Context(), filmID, userID, currentRole(c))
 	if err != nil {
-		switch err.Error() {
-		case "film not found":
-			c.JSON(http.StatusNotFound, gin.H{"error": "film not found"})
-		case "forbidden: only creator can delete this film":
-			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden: only creator can delete this film"})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not delete film"})
-		}
+		c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+func toCreditData(reqs []CreditRequest) []usecase.CreditData {
+	var credits []usecase.CreditData
+	for _, r := range reqs {
+		credits = append(credits, usecase.CreditData{
+			PersonID:     r.PersonID,
+			Role:         domain.CreditRole(r.Role),
+			Character:    r.Character,
+			BillingOrder: r.BillingOrder,
+		})
+	}
+	return credits
+}
+
+// newFilmRequest returns the editable fields of film, the document patches apply to.
+func newFilmRequest(film *domain.Film) FilmRequest {
+	req := FilmRequest{
+		Title:    film.Title,
+		Genres:   []string{},
+		Credits:  []CreditRequest{},
+		Synopsis: film.Synopsis,
+	}
+	if film.ReleaseDate != nil {
+		req.ReleaseDate = film.ReleaseDate.Format("2006-01-02")
+	}
+	for _, g := range film.Genres {
+		req.Genres = append(req.Genres, g.Slug)
+	}
+	for _, credit := range film.Credits {
+		billingOrder := credit.BillingOrder
+		req.Credits = append(req.Credits, CreditRequest{
+			PersonID:     credit.PersonID,
+			Role:         string(credit.Role),
+			Character:    credit.Character,
+			BillingOrder: &billingOrder,
+		})
+	}
+	return req
+}
+
+// parseReleaseDate parses a YYYY-MM-DD date, where an empty value means unknown.
+func parseReleaseDate(c *gin.Context, value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, true
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		c.Error(domain.Invalid("release_date", "invalid release_date format, expected YYYY-MM-DD"))
+		return time.Time{}, false
+	}
+	return date, true
+}
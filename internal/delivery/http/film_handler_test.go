@@ -2,7 +2,8 @@ package http_test
 
 import (
 	"bytes"
-	"errors"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	filmHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/delivery/http/middleware"
 	"go-films-api/internal/domain"
 	usecase "go-films-api/internal/usecase"
 
@@ -18,61 +20,114 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// newTestRouter returns a router that renders handler errors like the server does.
+func newTestRouter() *gin.Engine {
+	r := gin.Default()
+	r.Use(middleware.ErrorHandler())
+	return r
+}
+
 type MockFilmService struct {
 	mock.Mock
 }
 
-func (m *MockFilmService) ListFilms(title, genre string, releaseDate time.Time) ([]domain.Film, error) {
-	args := m.Called(title, genre, releaseDate)
-	if films, ok := args.Get(0).([]domain.Film); ok {
-		return films, args.Error(1)
+func (m *MockFilmService) ListFilms(ctx context.Context, query usecase.ListFilmsQuery) (*usecase.FilmPage, error) {
+	args := m.Called(ctx, query)
+	if page, ok := args.Get(0).(*usecase.FilmPage); ok {
+		return page, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockFilmService) GetFilmDetails(id uint) (*domain.Film, error) {
-	args := m.Called(id)
+func (m *MockFilmService) GetFilmDetails(ctx context.Context, id uint) (*domain.Film, error) {
+	args := m.Called(ctx, id)
 	if film, ok := args.Get(0).(*domain.Film); ok {
 		return film, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockFilmService) CreateFilm(title, director, cast, genre, synopsis string, releaseDate time.Time, userID uint) (*domain.Film, error) {
-	args := m.Called(title, director, cast, genre, synopsis, releaseDate, userID)
+func (m *MockFilmService) CreateFilm(ctx context.Context, data usecase.CreateFilmData, userID uint) (*domain.Film, error) {
+	args := m.Called(ctx, data, userID)
+	if film, ok := args.Get(0).(*domain.Film); ok {
+		return film, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+func (m *MockFilmService) UpdateFilm(ctx context.Context, id uint, userID uint, role domain.Role, data usecase.UpdateFilmData) (*domain.Film, error) {
+	args := m.Called(ctx, id, userID, role, data)
 	if film, ok := args.Get(0).(*domain.Film); ok {
 		return film, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
-func (m *MockFilmService) UpdateFilm(id uint, userID uint, data usecase.UpdateFilmData) (*domain.Film, error) {
-	args := m.Called(id, userID, data)
+
+func (m *MockFilmService) DeleteFilm(ctx context.Context, id, userID uint, role domain.Role) error {
+	args := m.Called(ctx, id, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockFilmService) ListTrash(ctx context.Context, userID uint, page, pageSize int) (*usecase.FilmPage, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	if page, ok := args.Get(0).(*usecase.FilmPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFilmService) RestoreFilm(ctx context.Context, id, userID uint, role domain.Role) (*domain.Film, error) {
+	args := m.Called(ctx, id, userID, role)
 	if film, ok := args.Get(0).(*domain.Film); ok {
 		return film, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockFilmService) DeleteFilm(id, userID uint) error {
-	args := m.Called(id, userID)
+func (m *MockFilmService) PurgeFilm(ctx context.Context, id, actorID uint) error {
+	args := m.Called(ctx, id, actorID)
 	return args.Error(0)
 }
 
+func (m *MockFilmService) ImportFilms(ctx context.Context, source usecase.ImportSource, opts usecase.ImportOptions, userID uint) (*usecase.ImportReport, error) {
+	args := m.Called(ctx, source, opts, userID)
+	if report, ok := args.Get(0).(*usecase.ImportReport); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ExportFilms passes the films the expectation returns to fn as one batch.
+func (m *MockFilmService) ExportFilms(ctx context.Context, query usecase.ListFilmsQuery, fn func(films []domain.Film) error) error {
+	args := m.Called(ctx, query)
+	if films, ok := args.Get(0).([]domain.Film); ok {
+		if err := fn(films); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockFilmService) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestGetFilms_NoFilters(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 	r.GET("/films", filmHandler.GetFilms)
 
 	expectedFilms := []domain.Film{
-		{ID: 1, Title: "Film One", Genre: "Action"},
-		{ID: 2, Title: "Film Two", Genre: "Drama"},
+		{ID: 1, Title: "Film One"},
+		{ID: 2, Title: "Film Two"},
 	}
 
-	mockService.On("ListFilms", "", "", time.Time{}).Return(expectedFilms, nil)
+	mockService.On("ListFilms", mock.Anything, usecase.ListFilmsQuery{Page: 1, PageSize: 20}).
+		Return(&usecase.FilmPage{Films: expectedFilms, Total: 2, Page: 1, PageSize: 20}, nil)
 
 	req, _ := http.NewRequest("GET", "/films", nil)
 	w := httptest.NewRecorder()
@@ -92,16 +147,23 @@ func TestGetFilms_WithFilters(t *testing.T) {
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 	r.GET("/films", filmHandler.GetFilms)
 
 	date, _ := time.Parse("2006-01-02", "2023-01-01")
 	expectedFilms := []domain.Film{
-		{ID: 10, Title: "Action Film", Genre: "Action"},
+		{ID: 10, Title: "Action Film"},
 	}
 
-	mockService.On("ListFilms", "Action", "Action", date).
-		Return(expectedFilms, nil)
+	query := usecase.ListFilmsQuery{
+		Title:       "Action",
+		Genres:      []string{"Action"},
+		ReleaseDate: date,
+		Page:        1,
+		PageSize:    20,
+	}
+	mockService.On("ListFilms", mock.Anything, query).
+		Return(&usecase.FilmPage{Films: expectedFilms, Total: 1, Page: 1, PageSize: 20}, nil)
 
 	req, _ := http.NewRequest("GET", "/films?title=Action&genre=Action&release_date=2023-01-01", nil)
 	w := httptest.NewRecorder()
@@ -113,13 +175,79 @@ func TestGetFilms_WithFilters(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestGetFilms_RangeAndMultiValueFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films", filmHandler.GetFilms)
+
+	from, _ := time.Parse("2006-01-02", "1990-01-01")
+	to, _ := time.Parse("2006-01-02", "1999-12-31")
+	createdAfter, _ := time.Parse("2006-01-02", "2024-05-01")
+	query := usecase.ListFilmsQuery{
+		Director:        "Scorsese",
+		Genres:          []string{"Action", "Drama"},
+		ReleaseDateFrom: from,
+		ReleaseDateTo:   to,
+		Year:            1995,
+		CreatedBy:       3,
+		CreatedAfter:    createdAfter,
+		Page:            1,
+		PageSize:        20,
+	}
+	mockService.On("ListFilms", mock.Anything, query).
+		Return(&usecase.FilmPage{Films: []domain.Film{{ID: 8, Title: "Casino"}}, Total: 1, Page: 1, PageSize: 20}, nil)
+
+	url := "/films?director=Scorsese&genre=Action,%20Drama&release_date_from=1990-01-01&release_date_to=1999-12-31" +
+		"&year=1995&created_by=3&created_after=2024-05-01"
+	req, _ := http.NewRequest("GET", url, nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Casino")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetFilms_InvalidRangeFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films", filmHandler.GetFilms)
+
+	tests := map[string]string{
+		"/films?release_date_from=2000-01-01&release_date_to=1999-01-01": "release_date_from must not be after release_date_to",
+		"/films?release_date_to=31-12-1999":                              "invalid release_date_to format",
+		"/films?year=nineteen":                                           "year must be a number",
+		"/films?created_by=-1":                                           "invalid created_by user ID",
+		"/films?created_after=yesterday":                                 "invalid created_after format",
+	}
+	for url, msg := range tests {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+		assert.Contains(t, w.Body.String(), msg, url)
+	}
+
+	mockService.AssertNotCalled(t, "ListFilms", mock.Anything, mock.Anything)
+}
+
 func TestGetFilms_InvalidDate(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 	r.GET("/films", filmHandler.GetFilms)
 
 	req, _ := http.NewRequest("GET", "/films?release_date=invalid-date", nil)
@@ -136,10 +264,10 @@ func TestGetFilms_ServiceError(t *testing.T) {
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 	r.GET("/films", filmHandler.GetFilms)
 
-	mockService.On("ListFilms", "", "", time.Time{}).
+	mockService.On("ListFilms", mock.Anything, mock.Anything).
 		Return(nil, fmt.Errorf("some db error"))
 
 	req, _ := http.NewRequest("GET", "/films", nil)
@@ -147,18 +275,157 @@ func TestGetFilms_ServiceError(t *testing.T) {
 
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Contains(t, w.Body.String(), "failed to fetch films")
+	assert.Contains(t, w.Body.String(), `"code":"internal_error"`)
+	assert.NotContains(t, w.Body.String(), "some db error")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetFilms_Pagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films", filmHandler.GetFilms)
+
+	query := usecase.ListFilmsQuery{Page: 2, PageSize: 1, Sort: "-release_date"}
+	mockService.On("ListFilms", mock.Anything, query).
+		Return(&usecase.FilmPage{
+			Films:      []domain.Film{{ID: 2, Title: "Film Two"}},
+			Total:      3,
+			Page:       2,
+			PageSize:   1,
+			NextCursor: "abc.def",
+			HasMore:    true,
+		}, nil)
+
+	req, _ := http.NewRequest("GET", "/films?page=2&page_size=1&sort=-release_date", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp filmHttp.FilmListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, int64(3), resp.Total)
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, "/films?page=3&page_size=1&sort=-release_date", resp.Next)
+	assert.Equal(t, "/films?page=1&page_size=1&sort=-release_date", resp.Prev)
+	assert.Equal(t, "abc.def", resp.NextCursor)
 
 	mockService.AssertExpectations(t)
 }
 
+func TestGetFilms_Cursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films", filmHandler.GetFilms)
+
+	mockService.On("ListFilms", mock.Anything, usecase.ListFilmsQuery{Page: 1, PageSize: 20, Cursor: "abc.def"}).
+		Return(&usecase.FilmPage{
+			Films:      []domain.Film{{ID: 3, Title: "Film Three"}},
+			Total:      30,
+			Page:       1,
+			PageSize:   20,
+			NextCursor: "ghi.jkl",
+			HasMore:    true,
+		}, nil)
+
+	req, _ := http.NewRequest("GET", "/films?cursor=abc.def", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp filmHttp.FilmListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "/films?cursor=ghi.jkl", resp.Next)
+	assert.Empty(t, resp.Prev)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetFilms_Search(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films", filmHandler.GetFilms)
+
+	found := domain.Film{
+		ID:         7,
+		Title:      "Heat",
+		Score:      1.5,
+		Highlights: map[string]string{"cast": "Al Pacino, Robert <em>De Niro</em>"},
+	}
+	mockService.On("ListFilms", mock.Anything, usecase.ListFilmsQuery{Query: "de niro", Page: 1, PageSize: 20}).
+		Return(&usecase.FilmPage{Films: []domain.Film{found}, Total: 1, Page: 1, PageSize: 20}, nil)
+
+	req, _ := http.NewRequest("GET", "/films?q=de+niro", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"Score":1.5`)
+	assert.Contains(t, w.Body.String(), `"Highlights":{"cast":`)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetFilms_InvalidCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films", filmHandler.GetFilms)
+
+	mockService.On("ListFilms", mock.Anything, mock.Anything).Return(nil, usecase.ErrInvalidCursor)
+
+	req, _ := http.NewRequest("GET", "/films?cursor=tampered", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid cursor")
+}
+
+func TestGetFilms_InvalidPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films", filmHandler.GetFilms)
+
+	for _, url := range []string{"/films?page=0", "/films?page_size=500", "/films?sort=synopsis"} {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+
+	mockService.AssertNotCalled(t, "ListFilms", mock.Anything, mock.Anything)
+}
+
 func TestGetFilmDetails_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 	r.GET("/films/:id", filmHandler.GetFilmDetails)
 
 	expectedFilm := &domain.Film{
@@ -168,7 +435,7 @@ func TestGetFilmDetails_Success(t *testing.T) {
 	}
 
 	mockService.
-		On("GetFilmDetails", uint(1)).
+		On("GetFilmDetails", mock.Anything, uint(1)).
 		Return(expectedFilm, nil)
 
 	w := httptest.NewRecorder()
@@ -182,18 +449,60 @@ func TestGetFilmDetails_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestGetFilmDetails_ETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.GET("/films/:id", filmHandler.GetFilmDetails)
+
+	film := &domain.Film{ID: 1, Title: "Heat", Version: 3}
+	mockService.On("GetFilmDetails", mock.Anything, uint(1)).Return(film, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/films/1", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^"3-[0-9a-f]{16}"$`, etag)
+
+	// The cached copy is still current
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/films/1", nil)
+	req.Header.Set("If-None-Match", `"other", W/`+etag)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Empty(t, w.Body.String())
+
+	// A new review changes the body but not the version
+	film.ReviewCount = 1
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/films/1", nil)
+	req.Header.Set("If-None-Match", etag)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"ReviewCount":1`)
+}
+
 func TestGetFilmDetails_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 	r.GET("/films/:id", filmHandler.GetFilmDetails)
 
 	mockService.
-		On("GetFilmDetails", uint(99)).
-		Return(nil, errors.New("film not found"))
+		On("GetFilmDetails", mock.Anything, uint(99)).
+		Return(nil, domain.NotFound("film_not_found", "film not found"))
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/films/99", nil)
@@ -211,7 +520,7 @@ func TestCreateFilm_Success(t *testing.T) {
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 
 	ginUserIDMiddleware := func(c *gin.Context) {
 		c.Set("userID", uint(5))
@@ -227,10 +536,21 @@ func TestCreateFilm_Success(t *testing.T) {
 		Title:  "New Film",
 	}
 
-	mockService.On("CreateFilm", "New Film", "Dir", "Cast", "Genre", "Syn", mock.Anything, uint(5)).
+	billing := 3
+	data := usecase.CreateFilmData{
+		Title:  "New Film",
+		Genres: []string{"drama"},
+		Credits: []usecase.CreditData{
+			{PersonID: 7, Role: domain.CreditRoleDirector},
+			{PersonID: 8, Role: domain.CreditRoleActor, Character: "Hero", BillingOrder: &billing},
+		},
+		Synopsis: "Syn",
+	}
+	mockService.On("CreateFilm", mock.Anything, data, uint(5)).
 		Return(mockFilm, nil)
 
-	body := `{"title":"New Film","director":"Dir","cast":"Cast","genre":"Genre","synopsis":"Syn"}`
+	body := `{"title":"New Film","genres":["drama"],"synopsis":"Syn","credits":[` +
+		`{"person_id":7,"role":"director"},{"person_id":8,"role":"actor","character":"Hero","billing_order":3}]}`
 	req, _ := http.NewRequest("POST", "/films", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -248,7 +568,7 @@ func TestCreateFilm_DuplicateTitle(t *testing.T) {
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 
 	ginUserIDMiddleware := func(c *gin.Context) {
 		c.Set("userID", uint(5))
@@ -258,10 +578,10 @@ func TestCreateFilm_DuplicateTitle(t *testing.T) {
 
 	r.POST("/films", filmHandler.CreateFilm)
 
-	mockService.On("CreateFilm", "Duplicate", "", "", "", "", mock.Anything, uint(5)).
-		Return(nil, fmt.Errorf("film with title 'Duplicate' already exists"))
+	mockService.On("CreateFilm", mock.Anything, usecase.CreateFilmData{Title: "Duplicate"}, uint(5)).
+		Return(nil, domain.Conflict("film_title_taken", "film with title 'Duplicate' already exists"))
 
-	body := `{"title":"Duplicate","director":"","cast":"","genre":"","synopsis":""}`
+	body := `{"title":"Duplicate","director":"","cast":"","synopsis":""}`
 	req, _ := http.NewRequest("POST", "/films", bytes.NewBufferString(body))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -273,13 +593,103 @@ func TestCreateFilm_DuplicateTitle(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestCreateFilm_FieldErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.POST("/films", filmHandler.CreateFilm)
+
+	// A missing title is reported by the service together with the other fields
+	mockService.On("CreateFilm", mock.Anything, usecase.CreateFilmData{Synopsis: "Syn"}, uint(5)).
+		Return(nil, domain.ValidationFailed([]domain.FieldError{
+			{Field: "title", Message: "title is required"},
+			{Field: "synopsis", Message: "synopsis must be at most 10000 characters"},
+		}))
+
+	req, _ := http.NewRequest("POST", "/films", bytes.NewBufferString(`{"synopsis":"Syn"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"code":"validation_failed"`)
+	assert.Contains(t, w.Body.String(), `"errors":[{"field":"title","message":"title is required"},`+
+		`{"field":"synopsis","message":"synopsis must be at most 10000 characters"}]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateFilm_UnknownGenre(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.POST("/films", filmHandler.CreateFilm)
+
+	mockService.On("CreateFilm", mock.Anything, mock.Anything, uint(5)).
+		Return(nil, &domain.Error{Kind: domain.ErrValidation, Code: "unknown_genre", Message: "unknown genre 'scifi'", Err: usecase.ErrUnknownGenre})
+
+	body := `{"title":"New Film","genres":["scifi"]}`
+	req, _ := http.NewRequest("POST", "/films", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown genre 'scifi'")
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateFilm_InvalidCredit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.POST("/films", filmHandler.CreateFilm)
+
+	mockService.On("CreateFilm", mock.Anything, mock.Anything, uint(5)).
+		Return(nil, &domain.Error{Kind: domain.ErrValidation, Code: "invalid_credit", Message: "invalid credit: person 42 not found", Err: usecase.ErrInvalidCredit})
+
+	body := `{"title":"New Film","credits":[{"person_id":42,"role":"actor"}]}`
+	req, _ := http.NewRequest("POST", "/films", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid credit: person 42 not found")
+	mockService.AssertExpectations(t)
+}
+
 func TestUpdateFilm_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 
 	ginUserIDMiddleware := func(c *gin.Context) {
 		c.Set("userID", uint(5))
@@ -301,12 +711,13 @@ func TestUpdateFilm_Success(t *testing.T) {
 	}
 
 	mockService.
-		On("UpdateFilm", uint(10), uint(5), mock.Anything).
+		On("UpdateFilm", mock.Anything, uint(10), uint(5), domain.RoleUser, mock.Anything).
 		Return(existingFilm, nil)
 
 	reqBody := `{"title":"Updated Title"}`
 	req, _ := http.NewRequest("PUT", "/films/10", bytes.NewBufferString(reqBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
 
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
@@ -322,7 +733,7 @@ func TestUpdateFilm_NotFound(t *testing.T) {
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 
 	ginUserIDMiddleware := func(c *gin.Context) {
 		c.Set("userID", uint(5))
@@ -338,12 +749,13 @@ func TestUpdateFilm_NotFound(t *testing.T) {
 	})
 
 	mockService.
-		On("UpdateFilm", uint(99), uint(5), mock.Anything).
-		Return(nil, errors.New("film not found"))
+		On("UpdateFilm", mock.Anything, uint(99), uint(5), domain.RoleUser, mock.Anything).
+		Return(nil, domain.NotFound("film_not_found", "film not found"))
 
 	reqBody := `{"title":"Updated Film"}`
 	req, _ := http.NewRequest("PUT", "/films/99", bytes.NewBufferString(reqBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
 
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
@@ -360,7 +772,7 @@ func TestUpdateFilm_Forbidden(t *testing.T) {
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 
 	ginUserIDMiddleware := func(c *gin.Context) {
 		c.Set("userID", uint(5))
@@ -376,12 +788,13 @@ func TestUpdateFilm_Forbidden(t *testing.T) {
 	})
 
 	mockService.
-		On("UpdateFilm", uint(100), uint(5), mock.Anything).
-		Return(nil, errors.New("forbidden: only creator can update this film"))
+		On("UpdateFilm", mock.Anything, uint(100), uint(5), domain.RoleUser, mock.Anything).
+		Return(nil, domain.Forbidden("not_film_creator", "forbidden: only creator can update this film"))
 
 	reqBody := `{"title":"Attempted Update"}`
 	req, _ := http.NewRequest("PUT", "/films/100", bytes.NewBufferString(reqBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
 
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
@@ -391,13 +804,226 @@ func TestUpdateFilm_Forbidden(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestUpdateFilm_IfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.PUT("/films/:id", filmHandler.UpdateFilm)
+
+	updated := &domain.Film{ID: 10, UserID: 5, Title: "Updated Title", Version: 4}
+	mockService.
+		On("UpdateFilm", mock.Anything, uint(10), uint(5), domain.RoleUser, mock.MatchedBy(func(data usecase.UpdateFilmData) bool {
+			return data.Version == 3
+		})).
+		Return(updated, nil).Twice()
+	mockService.
+		On("UpdateFilm", mock.Anything, uint(10), uint(5), domain.RoleUser, mock.MatchedBy(func(data usecase.UpdateFilmData) bool {
+			return data.Version == 2
+		})).
+		Return(nil, domain.ErrFilmModified).Once()
+	// If-Match: * replaces the version that is current, and no newer one
+	mockService.On("GetFilmDetails", mock.Anything, uint(10)).Return(&domain.Film{ID: 10, UserID: 5, Version: 3}, nil).Once()
+
+	cases := map[string]struct {
+		ifMatch string
+		status  int
+		code    string
+	}{
+		"current etag":  {`"3-0123456789abcdef"`, http.StatusOK, ""},
+		"any version":   {"*", http.StatusOK, ""},
+		"stale version": {`"2"`, http.StatusPreconditionFailed, "film_modified"},
+		"missing":       {"", http.StatusPreconditionRequired, "if_match_required"},
+		"weak":          {`W/"3-0123456789abcdef"`, http.StatusPreconditionFailed, "film_modified"},
+		"not an etag":   {"3", http.StatusBadRequest, "validation_failed"},
+	}
+	for name, tc := range cases {
+		req, _ := http.NewRequest("PUT", "/films/10", bytes.NewBufferString(`{"title":"Updated Title"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if tc.ifMatch != "" {
+			req.Header.Set("If-Match", tc.ifMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, name)
+		if tc.code != "" {
+			assert.Contains(t, w.Body.String(), `"code":"`+tc.code+`"`, name)
+		} else {
+			assert.Regexp(t, `^"4-[0-9a-f]{16}"$`, w.Header().Get("ETag"), name)
+		}
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateFilm_ReplacesAllFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.PUT("/films/:id", filmHandler.UpdateFilm)
+
+	// Fields missing from the body are cleared rather than left untouched
+	mockService.
+		On("UpdateFilm", mock.Anything, uint(10), uint(5), domain.RoleUser, mock.MatchedBy(func(data usecase.UpdateFilmData) bool {
+			return *data.Title == "Heat" && data.ReleaseDate.IsZero() && len(*data.Genres) == 0 &&
+				len(*data.Credits) == 0 && *data.Synopsis == "" && data.Version == 2
+		})).
+		Return(&domain.Film{ID: 10, Title: "Heat", Version: 3}, nil)
+
+	req, _ := http.NewRequest("PUT", "/films/10", bytes.NewBufferString(`{"title":"Heat","release_date":""}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"2"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func setupPatchRouter(mockService *MockFilmService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.PATCH("/films/:id", filmHandler.PatchFilm)
+
+	mockService.On("GetFilmDetails", mock.Anything, uint(10)).Return(&domain.Film{
+		ID:          10,
+		UserID:      5,
+		Title:       "Heat",
+		ReleaseDate: datePtr(1995, time.December, 15),
+		Synopsis:    "A heist.",
+		Version:     2,
+		Genres:      []domain.Genre{{ID: 1, Name: "Crime", Slug: "crime"}, {ID: 2, Name: "Drama", Slug: "drama"}},
+		Credits: []domain.FilmCredit{
+			{PersonID: 7, Role: domain.CreditRoleDirector, BillingOrder: 0},
+			{PersonID: 8, Role: domain.CreditRoleActor, Character: "Vincent Hanna", BillingOrder: 1},
+		},
+	}, nil)
+	return r
+}
+
+func doPatch(r *gin.Engine, contentType, ifMatch, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PATCH", "/films/10", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestPatchFilm_MergePatch(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupPatchRouter(mockService)
+
+	mockService.
+		On("UpdateFilm", mock.Anything, uint(10), uint(5), domain.RoleUser, mock.MatchedBy(func(data usecase.UpdateFilmData) bool {
+			return *data.Title == "Heat" && data.ReleaseDate.IsZero() && *data.Synopsis == "A better heist." &&
+				assert.ObjectsAreEqual([]string{"crime", "drama"}, *data.Genres) &&
+				len(*data.Credits) == 2 && (*data.Credits)[1].Character == "Vincent Hanna" &&
+				data.Version == 2
+		})).
+		Return(&domain.Film{ID: 10, Title: "Heat", Version: 3}, nil)
+
+	// null clears the release date, absent fields keep their value
+	w := doPatch(r, "application/merge-patch+json", `"2"`, `{"release_date":null,"synopsis":"A better heist."}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Regexp(t, `^"3-`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"ReleaseDate":null`)
+	mockService.AssertExpectations(t)
+}
+
+func TestPatchFilm_JSONPatch(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupPatchRouter(mockService)
+
+	billing := 2
+	mockService.
+		On("UpdateFilm", mock.Anything, uint(10), uint(5), domain.RoleUser, mock.MatchedBy(func(data usecase.UpdateFilmData) bool {
+			return *data.Title == "Heat (1995)" && data.ReleaseDate.Year() == 1995 &&
+				assert.ObjectsAreEqual([]string{"drama"}, *data.Genres) &&
+				assert.ObjectsAreEqual([]usecase.CreditData{
+					{PersonID: 7, Role: domain.CreditRoleDirector, BillingOrder: intPtr(0)},
+					{PersonID: 8, Role: domain.CreditRoleActor, Character: "Vincent Hanna", BillingOrder: intPtr(1)},
+					{PersonID: 9, Role: domain.CreditRoleActor, Character: "Neil McCauley", BillingOrder: &billing},
+				}, *data.Credits) &&
+				// If-Match: * updates whatever version the patch was applied to
+				data.Version == 2
+		})).
+		Return(&domain.Film{ID: 10, Title: "Heat (1995)", Version: 3}, nil)
+
+	w := doPatch(r, "application/json-patch+json", "*", `[
+		{"op": "test", "path": "/title", "value": "Heat"},
+		{"op": "replace", "path": "/title", "value": "Heat (1995)"},
+		{"op": "remove", "path": "/genres/0"},
+		{"op": "add", "path": "/credits/-", "value": {"person_id": 9, "role": "actor", "character": "Neil McCauley", "billing_order": 2}}
+	]`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestPatchFilm_Errors(t *testing.T) {
+	cases := map[string]struct {
+		contentType string
+		ifMatch     string
+		body        string
+		status      int
+		code        string
+	}{
+		"plain json":      {"application/json", `"2"`, `{}`, http.StatusUnsupportedMediaType, "unsupported_patch_format"},
+		"no if-match":     {"application/merge-patch+json", "", `{}`, http.StatusPreconditionRequired, "if_match_required"},
+		"unknown field":   {"application/merge-patch+json", `"2"`, `{"director":"Michael Mann"}`, http.StatusBadRequest, "invalid_patch"},
+		"wrong type":      {"application/merge-patch+json", `"2"`, `{"title":42}`, http.StatusBadRequest, "invalid_patch"},
+		"not an array":    {"application/json-patch+json", `"2"`, `{"op":"remove","path":"/title"}`, http.StatusBadRequest, "invalid_patch"},
+		"unknown op":      {"application/json-patch+json", `"2"`, `[{"op":"rename","path":"/title"}]`, http.StatusBadRequest, "invalid_patch"},
+		"missing value":   {"application/json-patch+json", `"2"`, `[{"op":"replace","path":"/title"}]`, http.StatusBadRequest, "invalid_patch"},
+		"failed test":     {"application/json-patch+json", `"2"`, `[{"op":"test","path":"/title","value":"Ronin"}]`, http.StatusConflict, "patch_conflict"},
+		"missing path":    {"application/json-patch+json", `"2"`, `[{"op":"remove","path":"/genres/5"}]`, http.StatusConflict, "patch_conflict"},
+		"replace missing": {"application/json-patch+json", `"2"`, `[{"op":"replace","path":"/director","value":"x"}]`, http.StatusConflict, "patch_conflict"},
+	}
+	for name, tc := range cases {
+		mockService := new(MockFilmService)
+		r := setupPatchRouter(mockService)
+
+		w := doPatch(r, tc.contentType, tc.ifMatch, tc.body)
+
+		assert.Equal(t, tc.status, w.Code, name)
+		assert.Contains(t, w.Body.String(), `"code":"`+tc.code+`"`, name)
+		mockService.AssertNotCalled(t, "UpdateFilm", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		if tc.status == http.StatusUnsupportedMediaType {
+			assert.Equal(t, "application/merge-patch+json, application/json-patch+json", w.Header().Get("Accept-Patch"))
+		}
+	}
+}
+
 func TestDeleteFilm_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 
 	ginUserIDMiddleware := func(c *gin.Context) {
 		c.Set("userID", uint(5))
@@ -413,7 +1039,7 @@ func TestDeleteFilm_Success(t *testing.T) {
 	})
 
 	mockService.
-		On("DeleteFilm", uint(10), uint(5)).
+		On("DeleteFilm", mock.Anything, uint(10), uint(5), domain.RoleUser).
 		Return(nil)
 
 	req, _ := http.NewRequest("DELETE", "/films/10", nil)
@@ -431,7 +1057,7 @@ func TestDeleteFilm_NotFound(t *testing.T) {
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 
 	ginUserIDMiddleware := func(c *gin.Context) {
 		c.Set("userID", uint(5))
@@ -447,8 +1073,8 @@ func TestDeleteFilm_NotFound(t *testing.T) {
 	})
 
 	mockService.
-		On("DeleteFilm", uint(99), uint(5)).
-		Return(errors.New("film not found"))
+		On("DeleteFilm", mock.Anything, uint(99), uint(5), domain.RoleUser).
+		Return(domain.NotFound("film_not_found", "film not found"))
 
 	req, _ := http.NewRequest("DELETE", "/films/99", nil)
 	w := httptest.NewRecorder()
@@ -467,7 +1093,7 @@ func TestDeleteFilm_Forbidden(t *testing.T) {
 	mockService := new(MockFilmService)
 	filmHandler := filmHttp.NewFilmHandler(mockService)
 
-	r := gin.Default()
+	r := newTestRouter()
 
 	ginUserIDMiddleware := func(c *gin.Context) {
 		c.Set("userID", uint(5))
@@ -483,8 +1109,8 @@ func TestDeleteFilm_Forbidden(t *testing.T) {
 	})
 
 	mockService.
-		On("DeleteFilm", uint(100), uint(5)).
-		Return(errors.New("forbidden: only creator can delete this film"))
+		On("DeleteFilm", mock.Anything, uint(100), uint(5), domain.RoleUser).
+		Return(domain.Forbidden("not_film_creator", "forbidden: only creator can delete this film"))
 
 	req, _ := http.NewRequest("DELETE", "/films/100", nil)
 	w := httptest.NewRecorder()
@@ -496,3 +1122,12 @@ func TestDeleteFilm_Forbidden(t *testing.T) {
 	mockService.AssertExpectations(t)
 
 }
+
+func datePtr(year int, month time.Month, day int) *time.Time {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+func intPtr(i int) *int {
+	return &i
+}
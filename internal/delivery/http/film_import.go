@@ -0,0 +1,342 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Import formats accepted by POST /films/import
+const (
+	csvImportType    = "text/csv"
+	ndjsonImportType = "application/x-ndjson"
+
+	maxImportSize = 32 << 20
+)
+
+// csvImportColumns are the columns a CSV import reads; only title is
+// required. The other columns of an export are accepted and ignored.
+var csvImportColumns = []string{"title", "release_date", "synopsis", "genres", "credits"}
+
+var errUnsupportedImport = domain.UnsupportedMediaType("unsupported_import_format",
+	"Content-Type must be "+csvImportType+" or "+ndjsonImportType)
+
+type ImportResponse struct {
+	Mode       string              `json:"mode" enums:"atomic,best_effort"`
+	DryRun     bool                `json:"dry_run"`
+	Committed  bool                `json:"committed"`
+	Total      int                 `json:"total"`
+	Created    int                 `json:"created"`
+	Duplicates int                 `json:"duplicates"`
+	Failed     int                 `json:"failed"`
+	Rows       []ImportRowResponse `json:"rows"`
+}
+
+type ImportRowResponse struct {
+	Row     int                 `json:"row"`
+	Title   string              `json:"title,omitempty"`
+	Status  string              `json:"status" enums:"created,duplicate,failed"`
+	FilmID  uint                `json:"film_id,omitempty"`
+	Code    string              `json:"code,omitempty"`
+	Message string              `json:"message,omitempty"`
+	Errors  []domain.FieldError `json:"errors,omitempty"`
+}
+
+// ImportFilms godoc
+// @Summary Import films
+// @Description Creates films from a CSV file with a header row (columns title, release_date, synopsis, genres and credits; genres are slugs separated by ";", credits are role:person_id or actor:person_id:character entries separated by ";") or from JSON Lines holding one film object per line. Rows are validated like POST /films and streamed, so files of up to 32 MiB are accepted. In atomic mode nothing is written if any row fails; best_effort mode writes every valid row. Rows whose title is taken are skipped as duplicates in both modes. With dry_run nothing is written. An exported CSV file can be imported as is: its other columns are ignored and the quote it puts in front of formula-like values is removed.
+// @Tags films
+// @Security BearerAuth
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param mode query string false "What to do when a row fails (default atomic)" Enums(atomic, best_effort)
+// @Param dry_run query bool false "Validate every row without writing anything"
+// @Success 200 {object} ImportResponse
+// @Failure 400 {object} middleware.Problem "Invalid mode or malformed file"
+// @Failure 415 {object} middleware.Problem "Unsupported import format"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/import [post]
+func (h *FilmHandler) ImportFilms(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.Error(domain.Invalid("dry_run", "dry_run must be true or false"))
+		return
+	}
+	mode := usecase.ImportMode(c.DefaultQuery("mode", string(usecase.ImportAtomic)))
+	if !mode.IsValid() {
+		c.Error(domain.Invalid("mode", "mode must be atomic or best_effort"))
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	var source usecase.ImportSource
+	switch mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type")); mediaType {
+	case csvImportType:
+		if source, err = newCSVImportSource(body); err != nil {
+			c.Error(err)
+			return
+		}
+	case ndjsonImportType:
+		source = newNDJSONImportSource(body)
+	default:
+		c.Error(errUnsupportedImport)
+		return
+	}
+
+	report, err := h.filmService.ImportFilms(c.Request.Context(), source, usecase.ImportOptions{
+		Mode:   mode,
+		DryRun: dryRun,
+	}, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp := ImportResponse{
+		Mode:       string(report.Mode),
+		DryRun:     report.DryRun,
+		Committed:  report.Committed,
+		Total:      report.Total,
+		Created:    report.Created,
+		Duplicates: report.Duplicates,
+		Failed:     report.Failed,
+		Rows:       make([]ImportRowResponse, len(report.Rows)),
+	}
+	for i, row := range report.Rows {
+		resp.Rows[i] = ImportRowResponse{
+			Row:     row.Row,
+			Title:   row.Title,
+			Status:  row.Status,
+			FilmID:  row.FilmID,
+			Code:    row.Code,
+			Message: row.Message,
+			Errors:  row.Fields,
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// invalidImport reports a file that cannot be read any further.
+func invalidImport(format string, args ...interface{}) error {
+	message := "invalid import: " + fmt.Sprintf(format, args...)
+	return &domain.Error{
+		Kind:    domain.ErrValidation,
+		Code:    "invalid_import",
+		Message: message,
+		Fields:  []domain.FieldError{{Field: "body", Message: message}},
+	}
+}
+
+// invalidImportRow reports a row that cannot be decoded; the rows after it
+// are still imported.
+func invalidImportRow(format string, args ...interface{}) error {
+	return &domain.Error{
+		Kind:    domain.ErrValidation,
+		Code:    "invalid_row",
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// importReadError turns a failure to read the request body into a client
+// error, since it is almost always an oversized or interrupted upload.
+func importReadError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return invalidImport("file must be at most %d MiB", maxImportSize>>20)
+	}
+	return invalidImport("could not read body: %v", err)
+}
+
+// importRow converts a decoded film into a row of the import.
+func importRow(req FilmRequest) usecase.ImportRow {
+	row := usecase.ImportRow{Data: usecase.CreateFilmData{
+		Title:    req.Title,
+		Genres:   req.Genres,
+		Credits:  toCreditData(req.Credits),
+		Synopsis: req.Synopsis,
+	}}
+	if req.ReleaseDate != "" {
+		date, err := time.Parse("2006-01-02", req.ReleaseDate)
+		if err != nil {
+			row.Err = domain.Invalid("release_date", "invalid release_date format, expected YYYY-MM-DD")
+		}
+		row.Data.ReleaseDate = date
+	}
+	return row
+}
+
+type csvImportSource struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// newCSVImportSource reads the header row and checks its columns.
+func newCSVImportSource(r io.Reader) (*csvImportSource, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, invalidImport("CSV file must start with a header row")
+	}
+	if err != nil {
+		return nil, csvReadError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(csvImportColumns, name) && !slices.Contains(csvExportColumns, name) {
+			return nil, invalidImport("unknown column %q, expected %s", name, strings.Join(csvImportColumns, ", "))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, invalidImport("duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, invalidImport("missing column \"title\"")
+	}
+
+	reader.FieldsPerRecord = len(header)
+	return &csvImportSource{reader: reader, columns: columns}, nil
+}
+
+func (s *csvImportSource) Next() (usecase.ImportRow, error) {
+	record, err := s.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return usecase.ImportRow{}, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+		return usecase.ImportRow{Err: invalidImportRow("row has %d fields, expected %d",
+			len(record), s.reader.FieldsPerRecord)}, nil
+	}
+	if err != nil {
+		return usecase.ImportRow{}, csvReadError(err)
+	}
+
+	req := FilmRequest{
+		Title:       s.field(record, "title"),
+		ReleaseDate: strings.TrimSpace(s.field(record, "release_date")),
+		Synopsis:    s.field(record, "synopsis"),
+	}
+	for _, slug := range strings.Split(s.field(record, "genres"), ";") {
+		if slug = strings.TrimSpace(slug); slug != "" {
+			req.Genres = append(req.Genres, slug)
+		}
+	}
+
+	row := importRow(req)
+	if row.Err == nil {
+		row.Data.Credits, row.Err = parseCSVCredits(s.field(record, "credits"))
+	}
+	return row, nil
+}
+
+// field returns the value of column, undoing the quote an export puts in
+// front of values that spreadsheets would read as formulas.
+func (s *csvImportSource) field(record []string, column string) string {
+	i, ok := s.columns[column]
+	if !ok {
+		return ""
+	}
+	value := record[i]
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func csvReadError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return invalidImport("line %d: %v", parseErr.Line, parseErr.Err)
+	}
+	return importReadError(err)
+}
+
+// parseCSVCredits parses credits written as role:person_id or
+// role:person_id:character entries separated by semicolons, in billing order.
+func parseCSVCredits(value string) ([]usecase.CreditData, error) {
+	var credits []usecase.CreditData
+	for _, entry := range strings.Split(value, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		field := fmt.Sprintf("credits[%d]", len(credits))
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) < 2 {
+			return nil, domain.Invalid(field, "credit must be role:person_id or role:person_id:character")
+		}
+		personID, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 32)
+		if err != nil {
+			return nil, domain.Invalid(field+".person_id", "person_id must be a positive number")
+		}
+
+		billingOrder := len(credits)
+		credit := usecase.CreditData{
+			PersonID:     uint(personID),
+			Role:         domain.CreditRole(strings.TrimSpace(parts[0])),
+			BillingOrder: &billingOrder,
+		}
+		if len(parts) == 3 {
+			credit.Character = parts[2]
+		}
+		credits = append(credits, credit)
+	}
+	return credits, nil
+}
+
+// ndjsonImportSource reads one film object per line, as in a FilmRequest.
+// Blank lines are skipped.
+type ndjsonImportSource struct {
+	reader *bufio.Reader
+}
+
+func newNDJSONImportSource(r io.Reader) *ndjsonImportSource {
+	return &ndjsonImportSource{reader: bufio.NewReader(r)}
+}
+
+func (s *ndjsonImportSource) Next() (usecase.ImportRow, error) {
+	for {
+		line, err := s.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return usecase.ImportRow{}, importReadError(err)
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			if err != nil {
+				return usecase.ImportRow{}, io.EOF
+			}
+			continue
+		}
+
+		var req FilmRequest
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			return usecase.ImportRow{Err: invalidImportRow("row is not a valid film object: %v", err)}, nil
+		}
+		return importRow(req), nil
+	}
+}
@@ -0,0 +1,200 @@
+package http_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	filmHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	usecase "go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// serveImport posts body to /films/import and returns the rows the service
+// read from the import source.
+func serveImport(t *testing.T, contentType, query, body string) (*httptest.ResponseRecorder, []usecase.ImportRow) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.POST("/films/import", filmHandler.ImportFilms)
+
+	var rows []usecase.ImportRow
+	mockService.On("ImportFilms", mock.Anything, mock.Anything, mock.Anything, uint(5)).
+		Return(&usecase.ImportReport{Mode: usecase.ImportAtomic, Committed: true}, nil).
+		Run(func(args mock.Arguments) {
+			source := args.Get(1).(usecase.ImportSource)
+			for {
+				row, err := source.Next()
+				if errors.Is(err, io.EOF) {
+					return
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				rows = append(rows, row)
+			}
+		})
+
+	req, _ := http.NewRequest("POST", "/films/import"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w, rows
+}
+
+func TestImportFilms_CSV(t *testing.T) {
+	body := "title,release_date,genres,credits,synopsis\n" +
+		"Heat,1995-12-15,crime; drama,director:7;actor:8:Vincent Hanna,\"A heist, and a chase.\"\n" +
+		"Ronin,not a date,,,\n" +
+		"Thief,1981-03-27\n" +
+		"Collateral,,,director:x,\n"
+	w, rows := serveImport(t, "text/csv; charset=utf-8", "", body)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, rows, 4)
+
+	billing0, billing1 := 0, 1
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, usecase.CreateFilmData{
+		Title:       "Heat",
+		ReleaseDate: time.Date(1995, time.December, 15, 0, 0, 0, 0, time.UTC),
+		Genres:      []string{"crime", "drama"},
+		Credits: []usecase.CreditData{
+			{PersonID: 7, Role: domain.CreditRoleDirector, BillingOrder: &billing0},
+			{PersonID: 8, Role: domain.CreditRoleActor, Character: "Vincent Hanna", BillingOrder: &billing1},
+		},
+		Synopsis: "A heist, and a chase.",
+	}, rows[0].Data)
+
+	assert.Equal(t, "validation_failed", domain.ErrorCode(rows[1].Err))
+	assert.Equal(t, "Ronin", rows[1].Data.Title)
+	assert.Equal(t, "invalid_row", domain.ErrorCode(rows[2].Err))
+	assert.ErrorContains(t, rows[3].Err, "person_id must be a positive number")
+}
+
+func TestImportFilms_CSVRoundTrip(t *testing.T) {
+	mockService := new(MockFilmService)
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockService.On("ExportFilms", mock.Anything, usecase.ListFilmsQuery{}).Return([]domain.Film{
+		{ID: 1, Title: "-30-", Synopsis: "=the end", CreatedAt: created, UpdatedAt: created},
+		{ID: 2, Title: "'Til Death", Synopsis: "@home", CreatedAt: created, UpdatedAt: created},
+	}, nil)
+	req, _ := http.NewRequest("GET", "/films/export", nil)
+	exported := httptest.NewRecorder()
+	setupExportRouter(mockService).ServeHTTP(exported, req)
+	if !assert.Equal(t, http.StatusOK, exported.Code) {
+		return
+	}
+
+	w, rows := serveImport(t, "text/csv", "", exported.Body.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	if assert.Len(t, rows, 2) {
+		assert.Equal(t, "-30-", rows[0].Data.Title)
+		assert.Equal(t, "=the end", rows[0].Data.Synopsis)
+		assert.Equal(t, "'Til Death", rows[1].Data.Title, "only quotes the export added are dropped")
+		assert.Equal(t, "@home", rows[1].Data.Synopsis)
+	}
+}
+
+func TestImportFilms_CSVHeader(t *testing.T) {
+	for body, message := range map[string]string{
+		"":                           "header row",
+		"title,director\nHeat,":      `unknown column \"director\"`,
+		"release_date\n1995-12-15\n": `missing column \"title\"`,
+	} {
+		w, _ := serveImport(t, "text/csv", "", body)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		assert.Contains(t, w.Body.String(), `"code":"invalid_import"`, body)
+		assert.Contains(t, w.Body.String(), message, body)
+	}
+}
+
+func TestImportFilms_NDJSON(t *testing.T) {
+	body := `{"title": "Heat", "release_date": "1995-12-15", "genres": ["crime"]}` + "\n" +
+		"\n" +
+		`{"title": "Ronin", "director": "John Frankenheimer"}` + "\n" +
+		`{"title": "Thief"` + "\n" +
+		`{"title": "Collateral", "credits": [{"person_id": 7, "role": "director"}]}`
+	w, rows := serveImport(t, "application/x-ndjson", "?mode=best_effort", body)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, rows, 4)
+	assert.NoError(t, rows[0].Err)
+	assert.Equal(t, "Heat", rows[0].Data.Title)
+	assert.Equal(t, []string{"crime"}, rows[0].Data.Genres)
+	assert.Equal(t, "invalid_row", domain.ErrorCode(rows[1].Err))
+	assert.Equal(t, "invalid_row", domain.ErrorCode(rows[2].Err))
+	assert.NoError(t, rows[3].Err)
+	assert.Equal(t, uint(7), rows[3].Data.Credits[0].PersonID)
+}
+
+func TestImportFilms_Report(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockFilmService)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.POST("/films/import", filmHandler.ImportFilms)
+
+	mockService.On("ImportFilms", mock.Anything, mock.Anything, usecase.ImportOptions{Mode: usecase.ImportAtomic, DryRun: true}, uint(5)).
+		Return(&usecase.ImportReport{
+			Mode:       usecase.ImportAtomic,
+			DryRun:     true,
+			Total:      2,
+			Created:    1,
+			Duplicates: 1,
+			Rows: []usecase.ImportRowResult{
+				{Row: 1, Title: "Heat", Status: usecase.ImportRowCreated},
+				{Row: 2, Title: "Ronin", Status: usecase.ImportRowDuplicate, Code: "film_title_taken", Message: "film with title 'Ronin' already exists"},
+			},
+		}, nil)
+
+	req, _ := http.NewRequest("POST", "/films/import?dry_run=true", strings.NewReader("title\nHeat\nRonin\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp filmHttp.ImportResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.DryRun)
+	assert.False(t, resp.Committed)
+	assert.Equal(t, 1, resp.Duplicates)
+	assert.Equal(t, "duplicate", resp.Rows[1].Status)
+	assert.Equal(t, "film_title_taken", resp.Rows[1].Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestImportFilms_BadRequest(t *testing.T) {
+	w, _ := serveImport(t, "application/json", "", `[{"title": "Heat"}]`)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"unsupported_import_format"`)
+
+	w, _ = serveImport(t, "text/csv", "?dry_run=maybe", "title\nHeat\n")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// The mode is checked before the body is read
+	w, rows := serveImport(t, "text/csv", "?mode=all_or_nothing", "director\nMichael Mann\n")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"mode"`)
+	assert.Empty(t, rows)
+}
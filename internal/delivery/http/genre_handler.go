@@ -0,0 +1,147 @@
+package http
+
+import (
+	"net/http"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GenreHandler struct {
+	genreService usecase.GenreService
+}
+
+type GenreRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func NewGenreHandler(gs usecase.GenreService) *GenreHandler {
+	return &GenreHandler{genreService: gs}
+}
+
+// GetGenres godoc
+// @Summary List genres
+// @Description Retrieves every genre, ordered by name.
+// @Tags genres
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} domain.Genre
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /genres [get]
+func (h *GenreHandler) GetGenres(c *gin.Context) {
+	genres, err := h.genreService.ListGenres(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if genres == nil {
+		genres = []domain.Genre{}
+	}
+
+	c.JSON(http.StatusOK, genres)
+}
+
+// GetGenre godoc
+// @Summary Get a genre
+// @Description Retrieves a genre by its slug.
+// @Tags genres
+// @Security BearerAuth
+// @Produce json
+// @Param slug path string true "Genre slug"
+// @Success 200 {object} domain.Genre
+// @Failure 404 {object} middleware.Problem "Genre not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /genres/{slug} [get]
+func (h *GenreHandler) GetGenre(c *gin.Context) {
+	genre, err := h.genreService.GetGenre(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, genre)
+}
+
+// CreateGenre godoc
+// @Summary Create a genre
+// @Description Adds a new genre. The slug is derived from the name. Editors and admins only.
+// @Tags genres
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param genre body GenreRequest true "Genre name"
+// @Success 201 {object} domain.Genre
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 409 {object} middleware.Problem "Genre already exists"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /genres [post]
+func (h *GenreHandler) CreateGenre(c *gin.Context) {
+	var req GenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	genre, err := h.genreService.CreateGenre(c.Request.Context(), req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, genre)
+}
+
+// UpdateGenre godoc
+// @Summary Rename a genre
+// @Description Renames a genre; its slug follows the new name. Editors and admins only.
+// @Tags genres
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param slug path string true "Genre slug"
+// @Param genre body GenreRequest true "Genre name"
+// @Success 200 {object} domain.Genre
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 404 {object} middleware.Problem "Genre not found"
+// @Failure 409 {object} middleware.Problem "Genre already exists"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /genres/{slug} [put]
+func (h *GenreHandler) UpdateGenre(c *gin.Context) {
+	var req GenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	genre, err := h.genreService.UpdateGenre(c.Request.Context(), c.Param("slug"), req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, genre)
+}
+
+// DeleteGenre godoc
+// @Summary Delete a genre
+// @Description Deletes a genre and removes it from every film. Editors and admins only.
+// @Tags genres
+// @Security BearerAuth
+// @Param slug path string true "Genre slug"
+// @Success 204 "No Content"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 404 {object} middleware.Problem "Genre not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /genres/{slug} [delete]
+func (h *GenreHandler) DeleteGenre(c *gin.Context) {
+	if err := h.genreService.DeleteGenre(c.Request.Context(), c.Param("slug")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,164 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	genreHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGenreService struct {
+	mock.Mock
+}
+
+func (m *MockGenreService) ListGenres(ctx context.Context) ([]domain.Genre, error) {
+	args := m.Called(ctx)
+	if genres, ok := args.Get(0).([]domain.Genre); ok {
+		return genres, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockGenreService) GetGenre(ctx context.Context, slug string) (*domain.Genre, error) {
+	args := m.Called(ctx, slug)
+	if genre, ok := args.Get(0).(*domain.Genre); ok {
+		return genre, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockGenreService) CreateGenre(ctx context.Context, name string) (*domain.Genre, error) {
+	args := m.Called(ctx, name)
+	if genre, ok := args.Get(0).(*domain.Genre); ok {
+		return genre, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockGenreService) UpdateGenre(ctx context.Context, slug, name string) (*domain.Genre, error) {
+	args := m.Called(ctx, slug, name)
+	if genre, ok := args.Get(0).(*domain.Genre); ok {
+		return genre, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockGenreService) DeleteGenre(ctx context.Context, slug string) error {
+	args := m.Called(ctx, slug)
+	return args.Error(0)
+}
+
+func setupGenreRouter(service *MockGenreService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	handler := genreHttp.NewGenreHandler(service)
+	r := newTestRouter()
+	r.GET("/genres", handler.GetGenres)
+	r.GET("/genres/:slug", handler.GetGenre)
+	r.POST("/genres", handler.CreateGenre)
+	r.PUT("/genres/:slug", handler.UpdateGenre)
+	r.DELETE("/genres/:slug", handler.DeleteGenre)
+	return r
+}
+
+func TestGetGenres(t *testing.T) {
+	mockService := new(MockGenreService)
+	r := setupGenreRouter(mockService)
+
+	mockService.On("ListGenres", mock.Anything, mock.Anything).Return([]domain.Genre{
+		{ID: 1, Name: "Action", Slug: "action"},
+		{ID: 2, Name: "Drama", Slug: "drama"},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/genres", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"Slug":"drama"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetGenre_NotFound(t *testing.T) {
+	mockService := new(MockGenreService)
+	r := setupGenreRouter(mockService)
+
+	mockService.On("GetGenre", mock.Anything, "unknown").Return(nil, domain.NotFound("genre_not_found", "genre not found"))
+
+	req, _ := http.NewRequest("GET", "/genres/unknown", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateGenre_Success(t *testing.T) {
+	mockService := new(MockGenreService)
+	r := setupGenreRouter(mockService)
+
+	mockService.On("CreateGenre", mock.Anything, "Science Fiction").
+		Return(&domain.Genre{ID: 5, Name: "Science Fiction", Slug: "science-fiction"}, nil)
+
+	req, _ := http.NewRequest("POST", "/genres", bytes.NewBufferString(`{"name":"Science Fiction"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"Slug":"science-fiction"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateGenre_Conflict(t *testing.T) {
+	mockService := new(MockGenreService)
+	r := setupGenreRouter(mockService)
+
+	mockService.On("CreateGenre", mock.Anything, "Drama").Return(nil, domain.Conflict("genre_exists", "genre 'Drama' already exists"))
+
+	req, _ := http.NewRequest("POST", "/genres", bytes.NewBufferString(`{"name":"Drama"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateGenre_InvalidName(t *testing.T) {
+	mockService := new(MockGenreService)
+	r := setupGenreRouter(mockService)
+
+	mockService.On("UpdateGenre", mock.Anything, "drama", "!!!").
+		Return(nil, domain.Invalid("name", "name must contain at least one letter or digit"))
+
+	req, _ := http.NewRequest("PUT", "/genres/drama", bytes.NewBufferString(`{"name":"!!!"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteGenre_Success(t *testing.T) {
+	mockService := new(MockGenreService)
+	r := setupGenreRouter(mockService)
+
+	mockService.On("DeleteGenre", mock.Anything, "drama").Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/genres/drama", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
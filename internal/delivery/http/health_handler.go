@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"go-films-api/internal/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readyTimeout bounds the database check of a readiness probe, which should
+// answer well before the orchestrator gives up on it.
+const readyTimeout = 2 * time.Second
+
+// DatabasePinger checks that the database is reachable and returns the
+// migration it is at, like database.Ping.
+type DatabasePinger func(ctx context.Context) (*database.MigrationStatus, error)
+
+type HealthHandler struct {
+	ping DatabasePinger
+}
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status           string `json:"status"`
+	Database         string `json:"database"`
+	MigrationVersion uint   `json:"migration_version"`
+	MigrationDirty   bool   `json:"migration_dirty"`
+	Error            string `json:"error,omitempty"`
+}
+
+func NewHealthHandler(ping DatabasePinger) *HealthHandler {
+	return &HealthHandler{ping: ping}
+}
+
+// Healthz godoc
+// @Summary Liveness probe
+// @Description Answers as long as the server is running, without checking its dependencies.
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Pings the database and reports the migration it is at. Not ready while the database is unreachable or a migration failed halfway.
+// @Tags health
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse "Database unreachable or migration dirty"
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readyTimeout)
+	defer cancel()
+
+	status, err := h.ping(ctx)
+	if err != nil {
+		// The cause may name hosts or credentials, so it only goes to the log
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: "unavailable", Database: "down", Error: "database unavailable"})
+		return
+	}
+
+	resp := ReadinessResponse{
+		Status:           "ready",
+		Database:         "up",
+		MigrationVersion: status.Version,
+		MigrationDirty:   status.Dirty,
+	}
+	if status.Dirty {
+		resp.Status = "unavailable"
+		resp.Error = "the last migration failed, fix it and force its version"
+		c.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
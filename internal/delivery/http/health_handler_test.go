@@ -0,0 +1,81 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-films-api/internal/database"
+	healthHttp "go-films-api/internal/delivery/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveHealth(ping healthHttp.DatabasePinger, path string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	healthHandler := healthHttp.NewHealthHandler(ping)
+
+	r := newTestRouter()
+	r.GET("/healthz", healthHandler.Healthz)
+	r.GET("/readyz", healthHandler.Readyz)
+
+	req, _ := http.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestHealthz(t *testing.T) {
+	w := serveHealth(func(ctx context.Context) (*database.MigrationStatus, error) {
+		t.Fatal("liveness must not touch the database")
+		return nil, nil
+	}, "/healthz")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, w.Body.String())
+}
+
+func TestReadyz(t *testing.T) {
+	w := serveHealth(func(ctx context.Context) (*database.MigrationStatus, error) {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return &database.MigrationStatus{Version: 14}, nil
+	}, "/readyz")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp healthHttp.ReadinessResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "ready", resp.Status)
+	assert.Equal(t, "up", resp.Database)
+	assert.Equal(t, uint(14), resp.MigrationVersion)
+}
+
+func TestReadyz_DatabaseDown(t *testing.T) {
+	w := serveHealth(func(ctx context.Context) (*database.MigrationStatus, error) {
+		return nil, errors.New("could not reach database: dial tcp db.internal:3306: connection refused")
+	}, "/readyz")
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var resp healthHttp.ReadinessResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "unavailable", resp.Status)
+	assert.Equal(t, "down", resp.Database)
+	assert.Equal(t, "database unavailable", resp.Error, "the cause is only logged")
+}
+
+func TestReadyz_DirtyMigration(t *testing.T) {
+	w := serveHealth(func(ctx context.Context) (*database.MigrationStatus, error) {
+		return &database.MigrationStatus{Version: 15, Dirty: true}, nil
+	}, "/readyz")
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var resp healthHttp.ReadinessResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "up", resp.Database)
+	assert.Equal(t, uint(15), resp.MigrationVersion)
+	assert.True(t, resp.MigrationDirty)
+}
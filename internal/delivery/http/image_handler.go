@@ -0,0 +1,161 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImageHandler struct {
+	imageService usecase.ImageService
+}
+
+func NewImageHandler(is usecase.ImageService) *ImageHandler {
+	return &ImageHandler{imageService: is}
+}
+
+// The multipart body may be this much larger than the image it carries
+const maxMultipartOverhead = 1 << 20
+
+var errImageTooLarge = domain.Invalid("image", fmt.Sprintf("image must be at most %d MiB", usecase.MaxImageSize>>20))
+
+// UploadFilmImage godoc
+// @Summary Upload an image of a film
+// @Description Adds a poster, backdrop or still to a film. The file is sent as the image field of a multipart form and must be a JPEG, PNG or GIF of at most 10 MiB and 40 megapixels; its type is detected from its content. Thumbnails of at most 1024, 480 and 160 pixels are made from it. Allowed for whoever may update the film.
+// @Tags films
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param image formData file true "Image file"
+// @Param kind formData string false "What the image shows (default poster)" Enums(poster, backdrop, still)
+// @Success 201 {object} domain.FilmImage
+// @Failure 400 {object} middleware.Problem "Invalid Film ID or image"
+// @Failure 403 {object} middleware.Problem "Forbidden: only creator can change the images of this film"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 415 {object} middleware.Problem "Not a multipart form, or not a JPEG, PNG or GIF image"
+// @Failure 503 {object} middleware.Problem "Image storage unavailable"
+// @Router /films/{id}/images [post]
+func (h *ImageHandler) UploadFilmImage(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	upload, err := readImageUpload(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	image, err := h.imageService.UploadFilmImage(c.Request.Context(), filmID, userID, currentRole(c), upload)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, image)
+}
+
+// DeleteFilmImage godoc
+// @Summary Delete an image of a film
+// @Description Removes an image and its thumbnails. Allowed for whoever may update the film.
+// @Tags films
+// @Security BearerAuth
+// @Param id path int true "Film ID"
+// @Param imageID path int true "Image ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} middleware.Problem "Invalid Film ID or Image ID"
+// @Failure 403 {object} middleware.Problem "Forbidden: only creator can change the images of this film"
+// @Failure 404 {object} middleware.Problem "Film or image not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/{id}/images/{imageID} [delete]
+func (h *ImageHandler) DeleteFilmImage(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	id64, err := strconv.ParseUint(c.Param("imageID"), 10, 32)
+	if err != nil {
+		c.Error(domain.Invalid("imageID", "invalid image ID"))
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.imageService.DeleteFilmImage(c.Request.Context(), filmID, uint(id64), userID, currentRole(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// readImageUpload streams the multipart body, keeping only the image and
+// kind fields. An image over the size limit is cut off one byte past it, so
+// the service can reject it without the rest being read.
+func readImageUpload(c *gin.Context) (usecase.ImageUpload, error) {
+	var upload usecase.ImageUpload
+	if mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type")); mediaType != "multipart/form-data" {
+		return upload, domain.UnsupportedMediaType("multipart_required", "Content-Type must be multipart/form-data")
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, usecase.MaxImageSize+maxMultipartOverhead)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return upload, domain.Invalid("body", "invalid multipart form")
+	}
+
+	found := false
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return upload, multipartReadError(err)
+		}
+
+		switch part.FormName() {
+		case "image":
+			upload.Data, err = io.ReadAll(io.LimitReader(part, usecase.MaxImageSize+1))
+			found = true
+		case "kind":
+			var kind []byte
+			kind, err = io.ReadAll(io.LimitReader(part, 64))
+			upload.Kind = domain.ImageKind(strings.TrimSpace(string(kind)))
+		}
+		part.Close()
+		if err != nil {
+			return upload, multipartReadError(err)
+		}
+		if len(upload.Data) > usecase.MaxImageSize {
+			return upload, errImageTooLarge
+		}
+	}
+	if !found {
+		return upload, domain.Invalid("image", "image is required")
+	}
+	return upload, nil
+}
+
+func multipartReadError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return errImageTooLarge
+	}
+	return domain.Invalid("body", "invalid multipart form")
+}
@@ -0,0 +1,158 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	filmHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImageService struct {
+	mock.Mock
+}
+
+func (m *MockImageService) UploadFilmImage(ctx context.Context, filmID, userID uint, role domain.Role, upload usecase.ImageUpload) (*domain.FilmImage, error) {
+	args := m.Called(ctx, filmID, userID, role, upload)
+	if image, ok := args.Get(0).(*domain.FilmImage); ok {
+		return image, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockImageService) DeleteFilmImage(ctx context.Context, filmID, imageID, userID uint, role domain.Role) error {
+	args := m.Called(ctx, filmID, imageID, userID, role)
+	return args.Error(0)
+}
+
+func setupImageRouter(mockService *MockImageService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	imageHandler := filmHttp.NewImageHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Set("role", domain.RoleUser)
+		c.Next()
+	})
+	r.POST("/films/:id/images", imageHandler.UploadFilmImage)
+	r.DELETE("/films/:id/images/:imageID", imageHandler.DeleteFilmImage)
+	return r
+}
+
+// multipartBody builds a form with the given fields; the "image" field is
+// sent as a file.
+func multipartBody(fields map[string][]byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		var w io.Writer
+		if name == "image" {
+			w, _ = writer.CreateFormFile(name, "poster.png")
+		} else {
+			w, _ = writer.CreateFormField(name)
+		}
+		_, _ = w.Write(value)
+	}
+	_ = writer.Close()
+	return &body, writer.FormDataContentType()
+}
+
+func TestUploadFilmImage(t *testing.T) {
+	mockService := new(MockImageService)
+	r := setupImageRouter(mockService)
+
+	mockService.On("UploadFilmImage", mock.Anything, uint(1), uint(5), domain.RoleUser, usecase.ImageUpload{
+		Kind: domain.ImageKindBackdrop,
+		Data: []byte("\x89PNG..."),
+	}).Return(&domain.FilmImage{ID: 3, FilmID: 1, Kind: domain.ImageKindBackdrop, URL: "/media/films/1/abc/original.png"}, nil)
+
+	body, contentType := multipartBody(map[string][]byte{"image": []byte("\x89PNG..."), "kind": []byte("backdrop")})
+	req, _ := http.NewRequest("POST", "/films/1/images", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var image domain.FilmImage
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &image))
+	assert.Equal(t, "/media/films/1/abc/original.png", image.URL)
+	mockService.AssertExpectations(t)
+}
+
+func TestUploadFilmImage_Errors(t *testing.T) {
+	mockService := new(MockImageService)
+	r := setupImageRouter(mockService)
+
+	mockService.On("UploadFilmImage", mock.Anything, uint(2), uint(5), domain.RoleUser, mock.Anything).
+		Return(nil, domain.Forbidden("not_film_creator", "forbidden: only creator can change the images of this film"))
+
+	send := func(path string, fields map[string][]byte) *httptest.ResponseRecorder {
+		body, contentType := multipartBody(fields)
+		req, _ := http.NewRequest("POST", path, body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("/films/1/images", map[string][]byte{"kind": []byte("poster")})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "image is required")
+
+	w = send("/films/1/images", map[string][]byte{"image": make([]byte, usecase.MaxImageSize+1)})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "image must be at most 10 MiB")
+
+	w = send("/films/abc/images", map[string][]byte{"image": []byte("x")})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = send("/films/2/images", map[string][]byte{"image": []byte("x")})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req, _ := http.NewRequest("POST", "/films/1/images", bytes.NewBufferString(`{"image": "x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"multipart_required"`)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteFilmImage(t *testing.T) {
+	mockService := new(MockImageService)
+	r := setupImageRouter(mockService)
+
+	mockService.On("DeleteFilmImage", mock.Anything, uint(1), uint(3), uint(5), domain.RoleUser).Return(nil)
+	mockService.On("DeleteFilmImage", mock.Anything, uint(1), uint(4), uint(5), domain.RoleUser).
+		Return(domain.NotFound("image_not_found", "image not found"))
+
+	req, _ := http.NewRequest("DELETE", "/films/1/images/3", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	req, _ = http.NewRequest("DELETE", "/films/1/images/4", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest("DELETE", "/films/1/images/abc", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-films-api/internal/domain"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. Code is a stable identifier
+// clients can switch on; Errors lists the rejected fields of a validation error.
+type Problem struct {
+	Type     string              `json:"type" example:"about:blank"`
+	Title    string              `json:"title" example:"Not Found"`
+	Status   int                 `json:"status" example:"404"`
+	Detail   string              `json:"detail" example:"film not found"`
+	Instance string              `json:"instance,omitempty" example:"/films/42"`
+	Code     string              `json:"code" example:"film_not_found"`
+	Errors   []domain.FieldError `json:"errors,omitempty"`
+}
+
+// errorStatuses maps the domain error kinds to HTTP status codes.
+var errorStatuses = []struct {
+	kind   error
+	status int
+}{
+	{domain.ErrValidation, http.StatusBadRequest},
+	{domain.ErrUnauthorized, http.StatusUnauthorized},
+	{domain.ErrForbidden, http.StatusForbidden},
+	{domain.ErrNotFound, http.StatusNotFound},
+	{domain.ErrConflict, http.StatusConflict},
+	{domain.ErrPreconditionFailed, http.StatusPreconditionFailed},
+	{domain.ErrPreconditionRequired, http.StatusPreconditionRequired},
+	{domain.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType},
+	{domain.ErrUnavailable, http.StatusServiceUnavailable},
+	{domain.ErrTimeout, http.StatusGatewayTimeout},
+}
+
+// ErrorHandler renders the last error a handler recorded with c.Error as a
+// problem+json response. Untyped errors become a 500 without details; their
+// cause is only logged. Errors recorded after the response was written, such
+// as a download that failed halfway, are only logged.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+		err := c.Errors.Last().Err
+		if c.Writer.Written() {
+			log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			return
+		}
+		problem := NewProblem(err)
+		problem.Instance = c.Request.URL.Path
+		if problem.Status >= http.StatusInternalServerError {
+			log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		}
+
+		c.Header("Content-Type", ProblemContentType)
+		c.JSON(problem.Status, problem)
+	}
+}
+
+// NewProblem builds the problem details describing err.
+func NewProblem(err error) Problem {
+	problem := Problem{
+		Type:   "about:blank",
+		Status: http.StatusInternalServerError,
+		Detail: "internal server error",
+		Code:   domain.ErrorCode(err),
+	}
+
+	var typed *domain.Error
+	if errors.As(err, &typed) {
+		for _, s := range errorStatuses {
+			if errors.Is(typed, s.kind) {
+				problem.Status = s.status
+				break
+			}
+		}
+		problem.Detail = typed.Message
+		problem.Errors = typed.Fields
+	}
+	if problem.Status == http.StatusInternalServerError {
+		problem.Detail = "internal server error"
+		problem.Code = "internal_error"
+	}
+
+	problem.Title = http.StatusText(problem.Status)
+	return problem
+}
+
+// abortWithError records err for ErrorHandler and stops the handler chain.
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,111 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-films-api/internal/delivery/http/middleware"
+	"go-films-api/internal/domain"
+)
+
+func serveError(err error) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.GET("/films/:id", func(c *gin.Context) {
+		_ = c.Error(err)
+	})
+
+	req, _ := http.NewRequest("GET", "/films/42", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestErrorHandler_Kinds(t *testing.T) {
+	cases := map[string]struct {
+		err    error
+		status int
+		code   string
+	}{
+		"validation":    {domain.Invalid("title", "title is required"), http.StatusBadRequest, "validation_failed"},
+		"unauthorized":  {domain.Unauthorized("invalid_credentials", "invalid credentials"), http.StatusUnauthorized, "invalid_credentials"},
+		"forbidden":     {domain.Forbidden("not_film_creator", "forbidden"), http.StatusForbidden, "not_film_creator"},
+		"not found":     {domain.NotFound("film_not_found", "film not found"), http.StatusNotFound, "film_not_found"},
+		"conflict":      {domain.Conflict("film_title_taken", "taken"), http.StatusConflict, "film_title_taken"},
+		"stale":         {domain.PreconditionFailed("film_modified", "modified"), http.StatusPreconditionFailed, "film_modified"},
+		"unconditional": {domain.PreconditionRequired("if_match_required", "If-Match required"), http.StatusPreconditionRequired, "if_match_required"},
+		"media type":    {domain.UnsupportedMediaType("unsupported_patch_format", "unsupported"), http.StatusUnsupportedMediaType, "unsupported_patch_format"},
+		"unavailable":   {domain.Unavailable(errors.New("dial tcp: refused")), http.StatusServiceUnavailable, "service_unavailable"},
+		"timeout":       {domain.Timeout(context.DeadlineExceeded), http.StatusGatewayTimeout, "request_timeout"},
+		"wrapped":       {fmt.Errorf("repository error: %w", domain.NotFound("film_not_found", "film not found")), http.StatusNotFound, "film_not_found"},
+		"untyped":       {errors.New("boom"), http.StatusInternalServerError, "internal_error"},
+	}
+	for name, tc := range cases {
+		w := serveError(tc.err)
+
+		assert.Equal(t, tc.status, w.Code, name)
+		assert.Equal(t, middleware.ProblemContentType, w.Header().Get("Content-Type"), name)
+		var problem middleware.Problem
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem), name)
+		assert.Equal(t, tc.code, problem.Code, name)
+		assert.Equal(t, tc.status, problem.Status, name)
+		assert.Equal(t, http.StatusText(tc.status), problem.Title, name)
+		assert.Equal(t, "about:blank", problem.Type, name)
+		assert.Equal(t, "/films/42", problem.Instance, name)
+	}
+}
+
+func TestErrorHandler_HidesInternalDetails(t *testing.T) {
+	w := serveError(errors.New("could not list films: Error 1146: table missing"))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "1146")
+	assert.Contains(t, w.Body.String(), `"detail":"internal server error"`)
+}
+
+func TestErrorHandler_FieldErrors(t *testing.T) {
+	w := serveError(domain.ValidationFailed([]domain.FieldError{
+		{Field: "title", Message: "title is required"},
+		{Field: "release_date", Message: "release_date must not be in the future"},
+	}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "title is required; release_date must not be in the future",
+		"instance": "/films/42",
+		"code": "validation_failed",
+		"errors": [
+			{"field": "title", "message": "title is required"},
+			{"field": "release_date", "message": "release_date must not be in the future"}
+		]
+	}`, w.Body.String())
+}
+
+func TestErrorHandler_KeepsWrittenResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.GET("/films", func(c *gin.Context) {
+		_ = c.Error(errors.New("logged only"))
+		c.JSON(http.StatusOK, gin.H{"items": []string{}})
+	})
+
+	req, _ := http.NewRequest("GET", "/films", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":[]}`, w.Body.String())
+}
@@ -1,24 +1,34 @@
 package middleware
 
 import (
-	"net/http"
-	"os"
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+
+	"go-films-api/internal/config"
+	"go-films-api/internal/domain"
 )
 
-func JWTMiddleware() gin.HandlerFunc {
+// RevocationStore reports whether an access token, identified by its jti claim, has been revoked.
+type RevocationStore interface {
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// JWTMiddleware accepts requests bearing an access token signed with
+// auth.JWTSecret that has not been revoked.
+func JWTMiddleware(auth config.Auth, revocations RevocationStore) gin.HandlerFunc {
+	secret := []byte(auth.JWTSecret)
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid auth header"})
+			abortWithError(c, domain.Unauthorized("missing_token", "missing or invalid auth header"))
 			return
 		}
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ") // Remove "Bearer " prefix, if present (Swagger UI does not include it)
 
-		secret := []byte(os.Getenv("JWT_SECRET"))
 		token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
 			// Check if the signing method is HMAC
 			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -28,16 +38,44 @@ func JWTMiddleware() gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			abortWithError(c, domain.Unauthorized("invalid_token", "invalid token"))
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
+			abortWithError(c, domain.Unauthorized("invalid_token_claims", "invalid token claims"))
+			return
+		}
+		sub, subOK := claims["sub"].(float64)
+		jti, jtiOK := claims["jti"].(string)
+		sid, _ := claims["sid"].(string)
+		roleClaim, _ := claims["role"].(string)
+		role := domain.Role(roleClaim)
+		// Tokens issued before roles existed belong to plain users
+		if !role.IsValid() {
+			role = domain.RoleUser
+		}
+		// Tokens without a jti cannot be revoked, so they are not accepted either
+		if !subOK || !jtiOK || jti == "" {
+			abortWithError(c, domain.Unauthorized("invalid_token_claims", "invalid token claims"))
 			return
 		}
-		c.Set("userID", uint(claims["sub"].(float64)))
+
+		revoked, err := revocations.IsTokenRevoked(c.Request.Context(), jti)
+		if err != nil {
+			abortWithError(c, fmt.Errorf("could not verify token: %w", err))
+			return
+		}
+		if revoked {
+			abortWithError(c, domain.Unauthorized("token_revoked", "token has been revoked"))
+			return
+		}
+
+		c.Set("userID", uint(sub))
+		c.Set("tokenID", jti)
+		c.Set("sessionID", sid)
+		c.Set("role", role)
 
 		c.Next()
 	}
@@ -0,0 +1,103 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/config"
+	"go-films-api/internal/delivery/http/middleware"
+	"go-films-api/internal/repository"
+)
+
+const testSecret = "test-secret"
+
+func setupProtectedRouter(store middleware.RevocationStore) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.Use(middleware.JWTMiddleware(config.Auth{JWTSecret: testSecret}, store))
+	r.GET("/me", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user": c.GetUint("userID"), "session": c.GetString("sessionID"), "role": c.Value("role")})
+	})
+	return r
+}
+
+func signToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+	return "Bearer " + token
+}
+
+func doRequest(r *gin.Engine, authHeader string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", authHeader)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestJWTMiddleware_ValidToken(t *testing.T) {
+	store := new(repository.MockTokenRepository)
+	store.On("IsTokenRevoked", mock.Anything, "abc").Return(false, nil)
+
+	w := doRequest(setupProtectedRouter(store), signToken(t, jwt.MapClaims{
+		"sub": 5, "jti": "abc", "sid": "family", "role": "editor", "exp": time.Now().Add(time.Hour).Unix(),
+	}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"user":5,"session":"family","role":"editor"}`, w.Body.String())
+}
+
+func TestJWTMiddleware_UnknownRoleIsUser(t *testing.T) {
+	store := new(repository.MockTokenRepository)
+	store.On("IsTokenRevoked", mock.Anything, "abc").Return(false, nil)
+
+	w := doRequest(setupProtectedRouter(store), signToken(t, jwt.MapClaims{
+		"sub": 5, "jti": "abc", "role": "root", "exp": time.Now().Add(time.Hour).Unix(),
+	}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"role":"user"`)
+}
+
+func TestJWTMiddleware_RevokedToken(t *testing.T) {
+	store := new(repository.MockTokenRepository)
+	store.On("IsTokenRevoked", mock.Anything, "abc").Return(true, nil)
+
+	w := doRequest(setupProtectedRouter(store), signToken(t, jwt.MapClaims{
+		"sub": 5, "jti": "abc", "sid": "family", "exp": time.Now().Add(time.Hour).Unix(),
+	}))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "token has been revoked")
+}
+
+func TestJWTMiddleware_MissingJTI(t *testing.T) {
+	store := new(repository.MockTokenRepository)
+
+	w := doRequest(setupProtectedRouter(store), signToken(t, jwt.MapClaims{
+		"sub": 5, "exp": time.Now().Add(time.Hour).Unix(),
+	}))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	store.AssertNotCalled(t, "IsTokenRevoked", mock.Anything, mock.Anything)
+}
+
+func TestJWTMiddleware_StoreError(t *testing.T) {
+	store := new(repository.MockTokenRepository)
+	store.On("IsTokenRevoked", mock.Anything, "abc").Return(false, errors.New("db down"))
+
+	w := doRequest(setupProtectedRouter(store), signToken(t, jwt.MapClaims{
+		"sub": 5, "jti": "abc", "exp": time.Now().Add(time.Hour).Unix(),
+	}))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestObserver records served requests, like metrics.Metrics.
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// unmatchedRoute stands for every path no route matches, so that scanners
+// probing random URLs do not create a series each.
+const unmatchedRoute = "unmatched"
+
+// otherMethod stands for every method outside the standard ones, for the
+// same reason.
+const otherMethod = "other"
+
+var standardMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true,
+	http.MethodPut: true, http.MethodPatch: true, http.MethodDelete: true,
+	http.MethodConnect: true, http.MethodOptions: true, http.MethodTrace: true,
+}
+
+// Metrics reports every request to observer under its route template. It
+// goes first, so that the status it sees is the one the error handler wrote.
+func Metrics(observer RequestObserver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		if !standardMethods[method] {
+			method = otherMethod
+		}
+		observer.ObserveRequest(method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,61 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-films-api/internal/delivery/http/middleware"
+	"go-films-api/internal/domain"
+)
+
+type observedRequest struct {
+	method, route string
+	status        int
+}
+
+type recordingObserver struct {
+	requests []observedRequest
+}
+
+func (o *recordingObserver) ObserveRequest(method, route string, status int, duration time.Duration) {
+	o.requests = append(o.requests, observedRequest{method, route, status})
+}
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	observer := &recordingObserver{}
+
+	r := gin.New()
+	r.Use(middleware.Metrics(observer))
+	r.Use(middleware.ErrorHandler())
+	r.GET("/films/:id", func(c *gin.Context) {
+		if c.Param("id") == "404" {
+			_ = c.Error(domain.NotFound("film_not_found", "film not found"))
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/films/1", "/films/404", "/wp-login.php"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	// Made-up methods are collapsed like unmatched paths
+	for _, method := range []string{"FOO", "BAR"} {
+		req, _ := http.NewRequest(method, "/films/1", nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []observedRequest{
+		{"GET", "/films/:id", http.StatusOK},
+		{"GET", "/films/:id", http.StatusNotFound},
+		{"GET", "unmatched", http.StatusNotFound},
+		{"other", "unmatched", http.StatusNotFound},
+		{"other", "unmatched", http.StatusNotFound},
+	}, observer.requests)
+}
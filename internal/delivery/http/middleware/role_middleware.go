@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"go-films-api/internal/domain"
+)
+
+var errInsufficientRole = domain.Forbidden("insufficient_role", "forbidden: insufficient role")
+
+// RequirePermission only lets requests through when the role set by
+// JWTMiddleware grants the permission. It must run after JWTMiddleware.
+func RequirePermission(p domain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		if r, ok := role.(domain.Role); !ok || !r.Can(p) {
+			abortWithError(c, errInsufficientRole)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-films-api/internal/delivery/http/middleware"
+	"go-films-api/internal/domain"
+)
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		role   interface{}
+		status int
+	}{
+		{domain.RoleAdmin, http.StatusOK},
+		{domain.RoleEditor, http.StatusForbidden},
+		{domain.RoleUser, http.StatusForbidden},
+		{nil, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		r := gin.New()
+		r.Use(middleware.ErrorHandler())
+		r.Use(func(c *gin.Context) {
+			if tc.role != nil {
+				c.Set("role", tc.role)
+			}
+			c.Next()
+		})
+		r.GET("/admin/users", middleware.RequirePermission(domain.PermManageUsers), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest("GET", "/admin/users", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "role %v", tc.role)
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"go-films-api/internal/domain"
+)
+
+// Timeout gives every request d to complete. The deadline travels with the
+// request context down to the database, which gives up on queries that
+// outlive it. A request that runs out of time without writing a response
+// fails with domain.ErrTimeout, whatever error the handler recorded. A d of
+// zero or less disables the timeout.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if d <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			_ = c.Error(domain.Timeout(ctx.Err()))
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-films-api/internal/delivery/http/middleware"
+)
+
+func serveWithTimeout(d time.Duration, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.Use(middleware.Timeout(d))
+	r.GET("/films", handler)
+
+	req, _ := http.NewRequest("GET", "/films", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestTimeout_Expired(t *testing.T) {
+	w := serveWithTimeout(10*time.Millisecond, func(c *gin.Context) {
+		// Like a query that gives up when the deadline passes
+		<-c.Request.Context().Done()
+		_ = c.Error(errors.New("could not list films: " + c.Request.Context().Err().Error()))
+	})
+
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	var problem middleware.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "request_timeout", problem.Code)
+	assert.NotContains(t, w.Body.String(), "could not list films")
+}
+
+func TestTimeout_InTime(t *testing.T) {
+	var deadline time.Time
+	w := serveWithTimeout(time.Minute, func(c *gin.Context) {
+		deadline, _ = c.Request.Context().Deadline()
+		c.Status(http.StatusOK)
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+func TestTimeout_Disabled(t *testing.T) {
+	var ctx context.Context
+	w := serveWithTimeout(0, func(c *gin.Context) {
+		ctx = c.Request.Context()
+		c.Status(http.StatusOK)
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+}
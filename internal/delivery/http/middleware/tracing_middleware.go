@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "go-films-api/internal/delivery/http"
+
+// Tracing starts a server span for every request, continuing the trace of
+// the caller when the request carries a W3C traceparent header. The span is
+// named after the route template and travels with the request context, so
+// service and query spans become its children. It goes before Metrics and
+// ErrorHandler, so that the span covers them and sees the final status.
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		ctx, span := otel.Tracer(tracerName).Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+			),
+		)
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if len(c.Errors) > 0 {
+			span.SetAttributes(attribute.String("error.message", strings.Join(c.Errors.Errors(), "; ")))
+		}
+		// Client errors are the client's problem, not the server's
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-films-api/internal/delivery/http/middleware"
+	"go-films-api/internal/domain"
+)
+
+func TestTracing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var handlerSpan trace.SpanContext
+	r := gin.New()
+	r.Use(middleware.Tracing())
+	r.Use(middleware.ErrorHandler())
+	r.GET("/films/:id", func(c *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(c.Request.Context())
+		switch c.Param("id") {
+		case "404":
+			_ = c.Error(domain.NotFound("film_not_found", "film not found"))
+		case "500":
+			c.Status(http.StatusInternalServerError)
+		default:
+			c.Status(http.StatusOK)
+		}
+	})
+
+	req, _ := http.NewRequest("GET", "/films/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		span := spans[0]
+		assert.Equal(t, "GET /films/:id", span.Name())
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String(), "the caller's trace goes on")
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Equal(t, span.SpanContext(), handlerSpan, "handlers see the span in the request context")
+		assert.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	}
+
+	for _, path := range []string{"/films/404", "/films/500"} {
+		req, _ = http.NewRequest("GET", path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	spans = recorder.Ended()
+	if assert.Len(t, spans, 3) {
+		assert.False(t, spans[1].Parent().IsValid(), "a request without traceparent starts a new trace")
+		assert.Equal(t, codes.Unset, spans[1].Status().Code, "client errors do not fail the span")
+		assert.Contains(t, spans[1].Attributes(), attribute.String("error.message", "film not found"))
+		assert.Equal(t, codes.Error, spans[2].Status().Code)
+	}
+}
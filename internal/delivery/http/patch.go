@@ -0,0 +1,376 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go-films-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Patch formats accepted by PATCH endpoints
+const (
+	mergePatchType = "application/merge-patch+json" // RFC 7396
+	jsonPatchType  = "application/json-patch+json"  // RFC 6902
+
+	maxPatchSize = 1 << 20
+)
+
+var errUnsupportedPatch = domain.UnsupportedMediaType("unsupported_patch_format",
+	"Content-Type must be "+mergePatchType+" or "+jsonPatchType)
+
+// patchFormat returns the patch media type of the request, advertising the
+// supported ones in Accept-Patch when it is missing or unknown.
+func patchFormat(c *gin.Context) (string, bool) {
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType != mergePatchType && mediaType != jsonPatchType {
+		c.Header("Accept-Patch", mergePatchType+", "+jsonPatchType)
+		c.Error(errUnsupportedPatch)
+		return "", false
+	}
+	return mediaType, true
+}
+
+func invalidPatch(format string, args ...interface{}) error {
+	message := "invalid patch: " + fmt.Sprintf(format, args...)
+	return &domain.Error{
+		Kind:    domain.ErrValidation,
+		Code:    "invalid_patch",
+		Message: message,
+		Fields:  []domain.FieldError{{Field: "body", Message: message}},
+	}
+}
+
+// patchConflict reports a well-formed patch that does not fit the current
+// document, such as a failed test or a path that does not exist.
+func patchConflict(format string, args ...interface{}) error {
+	return domain.Conflict("patch_conflict", "patch cannot be applied: "+fmt.Sprintf(format, args...))
+}
+
+// applyFilmPatch applies a patch in the given format to the representation
+// of a film and decodes the result, rejecting fields FilmRequest lacks.
+func applyFilmPatch(format string, current FilmRequest, patch []byte) (FilmRequest, error) {
+	raw, err := json.Marshal(current)
+	if err != nil {
+		return FilmRequest{}, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return FilmRequest{}, err
+	}
+
+	if format == mergePatchType {
+		doc, err = applyMergePatch(doc, patch)
+	} else {
+		doc, err = applyJSONPatch(doc, patch)
+	}
+	if err != nil {
+		return FilmRequest{}, err
+	}
+
+	raw, err = json.Marshal(doc)
+	if err != nil {
+		return FilmRequest{}, err
+	}
+	var result FilmRequest
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return FilmRequest{}, invalidPatch("%v", err)
+	}
+	return result, nil
+}
+
+// applyMergePatch implements RFC 7396: objects are merged recursively, null
+// removes a member and any other value replaces the target.
+func applyMergePatch(doc interface{}, patch []byte) (interface{}, error) {
+	var p interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, invalidPatch("body is not valid JSON")
+	}
+	return mergePatch(doc, p), nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	members, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	result, ok := target.(map[string]interface{})
+	if !ok {
+		result = map[string]interface{}{}
+	}
+	for key, value := range members {
+		if value == nil {
+			delete(result, key)
+		} else {
+			result[key] = mergePatch(result[key], value)
+		}
+	}
+	return result
+}
+
+// jsonPatchOperation is one operation of an RFC 6902 patch. Value is kept
+// raw so that an explicit null can be told apart from a missing value.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyJSONPatch implements RFC 6902. Operations are applied in order and
+// the whole patch fails if any of them does.
+func applyJSONPatch(doc interface{}, patch []byte) (interface{}, error) {
+	var ops []jsonPatchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, invalidPatch("body must be an array of operations")
+	}
+
+	for i, op := range ops {
+		if op.Path == nil {
+			return nil, invalidPatch("operation %d has no path", i)
+		}
+		path, err := parsePointer(*op.Path)
+		if err != nil {
+			return nil, invalidPatch("operation %d: %v", i, err)
+		}
+
+		var value interface{}
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, invalidPatch("operation %d (%s) has no value", i, op.Op)
+			}
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return nil, invalidPatch("operation %d has an invalid value", i)
+			}
+		case "move", "copy":
+			if op.From == nil {
+				return nil, invalidPatch("operation %d (%s) has no from", i, op.Op)
+			}
+			from, err := parsePointer(*op.From)
+			if err != nil {
+				return nil, invalidPatch("operation %d: %v", i, err)
+			}
+			if op.Op == "move" && isProperPrefix(from, path) {
+				return nil, invalidPatch("operation %d moves a value into itself", i)
+			}
+			value, err = getPointer(doc, from)
+			if err != nil {
+				return nil, patchConflict("operation %d: %v", i, err)
+			}
+			if op.Op == "move" {
+				if doc, err = removePointer(doc, from); err != nil {
+					return nil, patchConflict("operation %d: %v", i, err)
+				}
+			} else {
+				value = deepCopy(value)
+			}
+		case "remove":
+		default:
+			return nil, invalidPatch("operation %d has unknown op %q", i, op.Op)
+		}
+
+		switch op.Op {
+		case "add", "move", "copy":
+			doc, err = addPointer(doc, path, value)
+		case "remove":
+			doc, err = removePointer(doc, path)
+		case "replace":
+			if _, err = getPointer(doc, path); err == nil {
+				if doc, err = removePointer(doc, path); err == nil {
+					doc, err = addPointer(doc, path, value)
+				}
+			}
+		case "test":
+			var current interface{}
+			if current, err = getPointer(doc, path); err == nil && !reflect.DeepEqual(current, value) {
+				err = fmt.Errorf("test of %s failed", *op.Path)
+			}
+		}
+		if err != nil {
+			return nil, patchConflict("operation %d: %v", i, err)
+		}
+	}
+	return doc, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isProperPrefix(prefix, path []string) bool {
+	if len(prefix) >= len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex parses an array index token; "-" (past the end) is only
+// allowed when adding.
+func arrayIndex(token string, length int, adding bool) (int, error) {
+	if token == "-" && adding {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	limit := length - 1
+	if adding {
+		limit = length
+	}
+	if index > limit {
+		return 0, fmt.Errorf("array index %d out of range", index)
+	}
+	return index, nil
+}
+
+func getPointer(doc interface{}, path []string) (interface{}, error) {
+	current := doc
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("cannot look up %q in a scalar", token)
+		}
+	}
+	return current, nil
+}
+
+// addPointer returns doc with value added at path, inserting into arrays
+// and setting object members.
+func addPointer(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getPointer(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return doc, nil
+	case []interface{}:
+		index, err := arrayIndex(last, len(node), true)
+		if err != nil {
+			return nil, err
+		}
+		node = append(node, nil)
+		copy(node[index+1:], node[index:])
+		node[index] = value
+		return setPointer(doc, path[:len(path)-1], node)
+	default:
+		return nil, fmt.Errorf("cannot add %q to a scalar", last)
+	}
+}
+
+func removePointer(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	parent, err := getPointer(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		if _, ok := node[last]; !ok {
+			return nil, fmt.Errorf("member %q does not exist", last)
+		}
+		delete(node, last)
+		return doc, nil
+	case []interface{}:
+		index, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		node = append(node[:index:index], node[index+1:]...)
+		return setPointer(doc, path[:len(path)-1], node)
+	default:
+		return nil, fmt.Errorf("cannot remove %q from a scalar", last)
+	}
+}
+
+// setPointer replaces the existing value at path, which is needed after an
+// array changed length because slices are stored by value.
+func setPointer(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getPointer(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+	case []interface{}:
+		index, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = value
+	}
+	return doc, nil
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, member := range v {
+			result[key] = deepCopy(member)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = deepCopy(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
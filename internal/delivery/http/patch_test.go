@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-films-api/internal/domain"
+)
+
+func TestApplyPatch(t *testing.T) {
+	const doc = `{"title":"Heat","genres":["crime","drama"],"a/b":1,"m~n":2,"cast":{"lead":{"name":"Pacino","born":1940}}}`
+
+	cases := map[string]struct {
+		format string
+		patch  string
+		want   string
+		err    error
+	}{
+		"pointer unescaping": {jsonPatchType, `[
+			{"op":"replace","path":"/a~1b","value":10},
+			{"op":"remove","path":"/m~0n"}
+		]`, `{"title":"Heat","genres":["crime","drama"],"a/b":10,"cast":{"lead":{"name":"Pacino","born":1940}}}`, nil},
+		"add at index": {jsonPatchType, `[{"op":"add","path":"/genres/1","value":"thriller"}]`,
+			`{"title":"Heat","genres":["crime","thriller","drama"],"a/b":1,"m~n":2,"cast":{"lead":{"name":"Pacino","born":1940}}}`, nil},
+		"add at end": {jsonPatchType, `[{"op":"add","path":"/genres/-","value":"thriller"}]`,
+			`{"title":"Heat","genres":["crime","drama","thriller"],"a/b":1,"m~n":2,"cast":{"lead":{"name":"Pacino","born":1940}}}`, nil},
+		"add past end":        {jsonPatchType, `[{"op":"add","path":"/genres/3","value":"thriller"}]`, "", domain.ErrConflict},
+		"remove out of range": {jsonPatchType, `[{"op":"remove","path":"/genres/2"}]`, "", domain.ErrConflict},
+		"remove end":          {jsonPatchType, `[{"op":"remove","path":"/genres/-"}]`, "", domain.ErrConflict},
+		"leading zero index":  {jsonPatchType, `[{"op":"remove","path":"/genres/01"}]`, "", domain.ErrConflict},
+		"move into own child": {jsonPatchType, `[{"op":"move","from":"/cast","path":"/cast/lead/cast"}]`, "", domain.ErrValidation},
+		"move": {jsonPatchType, `[{"op":"move","from":"/cast/lead/name","path":"/title"}]`,
+			`{"title":"Pacino","genres":["crime","drama"],"a/b":1,"m~n":2,"cast":{"lead":{"born":1940}}}`, nil},
+		"copy": {jsonPatchType, `[
+			{"op":"copy","from":"/cast/lead","path":"/cast/villain"},
+			{"op":"replace","path":"/cast/villain/name","value":"De Niro"}
+		]`, `{"title":"Heat","genres":["crime","drama"],"a/b":1,"m~n":2,"cast":{"lead":{"name":"Pacino","born":1940},"villain":{"name":"De Niro","born":1940}}}`, nil},
+		"passing test": {jsonPatchType, `[
+			{"op":"test","path":"/cast/lead","value":{"born":1940,"name":"Pacino"}},
+			{"op":"replace","path":"/title","value":"Ronin"}
+		]`, `{"title":"Ronin","genres":["crime","drama"],"a/b":1,"m~n":2,"cast":{"lead":{"name":"Pacino","born":1940}}}`, nil},
+		"failing test aborts": {jsonPatchType, `[
+			{"op":"replace","path":"/title","value":"Ronin"},
+			{"op":"test","path":"/genres/0","value":"drama"},
+			{"op":"remove","path":"/genres"}
+		]`, "", domain.ErrConflict},
+		"merge nested null": {mergePatchType, `{"cast":{"lead":{"born":null},"villain":{"name":"De Niro","born":null}},"m~n":null}`,
+			`{"title":"Heat","genres":["crime","drama"],"a/b":1,"cast":{"lead":{"name":"Pacino"},"villain":{"name":"De Niro"}}}`, nil},
+		"merge replaces arrays": {mergePatchType, `{"genres":["thriller"]}`,
+			`{"title":"Heat","genres":["thriller"],"a/b":1,"m~n":2,"cast":{"lead":{"name":"Pacino","born":1940}}}`, nil},
+	}
+	for name, tc := range cases {
+		var target interface{}
+		if !assert.NoError(t, json.Unmarshal([]byte(doc), &target), name) {
+			continue
+		}
+
+		var result interface{}
+		var err error
+		if tc.format == mergePatchType {
+			result, err = applyMergePatch(target, []byte(tc.patch))
+		} else {
+			result, err = applyJSONPatch(target, []byte(tc.patch))
+		}
+
+		if tc.err != nil {
+			assert.ErrorIs(t, err, tc.err, name)
+			assert.Nil(t, result, name)
+			continue
+		}
+		if assert.NoError(t, err, name) {
+			raw, _ := json.Marshal(result)
+			assert.JSONEq(t, tc.want, string(raw), name)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PersonHandler struct {
+	personService usecase.PersonService
+	filmService   usecase.FilmService
+}
+
+type PersonRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type PersonListResponse struct {
+	Items    []domain.Person `json:"items"`
+	Total    int64           `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+	Next     string          `json:"next,omitempty"`
+	Prev     string          `json:"prev,omitempty"`
+}
+
+func NewPersonHandler(ps usecase.PersonService, fs usecase.FilmService) *PersonHandler {
+	return &PersonHandler{personService: ps, filmService: fs}
+}
+
+// GetPeople godoc
+// @Summary List people
+// @Description Retrieves a paginated list of people, ordered by name.
+// @Tags people
+// @Security BearerAuth
+// @Produce json
+// @Param name query string false "Person name (partial match)"
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} PersonListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /people [get]
+func (h *PersonHandler) GetPeople(c *gin.Context) {
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.personService.ListPeople(c.Request.Context(), c.Query("name"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp := PersonListResponse{
+		Items:    result.People,
+		Total:    result.Total,
+		Page:     result.Page,
+		PageSize: result.PageSize,
+	}
+	if resp.Items == nil {
+		resp.Items = []domain.Person{}
+	}
+	if int64(result.Page*result.PageSize) < result.Total {
+		resp.Next = listLink(c, "page", strconv.Itoa(result.Page+1))
+	}
+	if result.Page > 1 {
+		resp.Prev = listLink(c, "page", strconv.Itoa(result.Page-1))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetPerson godoc
+// @Summary Get a person
+// @Description Retrieves a person by ID.
+// @Tags people
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {object} domain.Person
+// @Failure 400 {object} middleware.Problem "Invalid person ID"
+// @Failure 404 {object} middleware.Problem "Person not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /people/{id} [get]
+func (h *PersonHandler) GetPerson(c *gin.Context) {
+	id, ok := parsePersonID(c)
+	if !ok {
+		return
+	}
+
+	person, err := h.personService.GetPerson(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
+
+// CreatePerson godoc
+// @Summary Create a person
+// @Description Adds a person who can then be credited on films. Editors and admins only.
+// @Tags people
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param person body PersonRequest true "Person name"
+// @Success 201 {object} domain.Person
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /people [post]
+func (h *PersonHandler) CreatePerson(c *gin.Context) {
+	var req PersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	person, err := h.personService.CreatePerson(c.Request.Context(), req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, person)
+}
+
+// UpdatePerson godoc
+// @Summary Rename a person
+// @Description Updates the name of a person. Editors and admins only.
+// @Tags people
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param person body PersonRequest true "Person name"
+// @Success 200 {object} domain.Person
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 404 {object} middleware.Problem "Person not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /people/{id} [put]
+func (h *PersonHandler) UpdatePerson(c *gin.Context) {
+	id, ok := parsePersonID(c)
+	if !ok {
+		return
+	}
+
+	var req PersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	person, err := h.personService.UpdatePerson(c.Request.Context(), id, req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, person)
+}
+
+// DeletePerson godoc
+// @Summary Delete a person
+// @Description Deletes a person and removes their credits from every film. Editors and admins only.
+// @Tags people
+// @Security BearerAuth
+// @Param id path int true "Person ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} middleware.Problem "Invalid person ID"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 404 {object} middleware.Problem "Person not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /people/{id} [delete]
+func (h *PersonHandler) DeletePerson(c *gin.Context) {
+	id, ok := parsePersonID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.personService.DeletePerson(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPersonFilms godoc
+// @Summary List a person's films
+// @Description Retrieves a paginated list of the films a person is credited on, optionally only in one role.
+// @Tags people
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param role query string false "Credit role" Enums(director, actor, writer, composer)
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Param sort query string false "Sort field: title, release_date, created_at, director, average_rating or review_count. Prefix with - for descending order"
+// @Param cursor query string false "Opaque cursor from a previous response's next_cursor; replaces page"
+// @Success 200 {object} FilmListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 404 {object} middleware.Problem "Person not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /people/{id}/films [get]
+func (h *PersonHandler) GetPersonFilms(c *gin.Context) {
+	id, ok := parsePersonID(c)
+	if !ok {
+		return
+	}
+
+	role := domain.CreditRole(c.Query("role"))
+	if role != "" && !role.IsValid() {
+		c.Error(domain.Invalid("role", "role must be director, actor, writer or composer"))
+		return
+	}
+
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	sort := c.Query("sort")
+	if sort != "" && !usecase.IsValidFilmSort(strings.TrimPrefix(sort, "-")) {
+		c.Error(errInvalidFilmSort)
+		return
+	}
+	cursor := c.Query("cursor")
+
+	if _, err := h.personService.GetPerson(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	result, err := h.filmService.ListFilms(c.Request.Context(), usecase.ListFilmsQuery{
+		PersonID:   id,
+		CreditRole: role,
+		Page:       page,
+		PageSize:   pageSize,
+		Sort:       sort,
+		Cursor:     cursor,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeFilmPage(c, result, cursor != "")
+}
+
+func parsePersonID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.Invalid("id", "invalid person ID"))
+		return 0, false
+	}
+	return uint(id), true
+}
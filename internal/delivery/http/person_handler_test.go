@@ -0,0 +1,236 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	personHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPersonService struct {
+	mock.Mock
+}
+
+func (m *MockPersonService) ListPeople(ctx context.Context, name string, page, pageSize int) (*usecase.PersonPage, error) {
+	args := m.Called(ctx, name, page, pageSize)
+	if result, ok := args.Get(0).(*usecase.PersonPage); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPersonService) GetPerson(ctx context.Context, id uint) (*domain.Person, error) {
+	args := m.Called(ctx, id)
+	if person, ok := args.Get(0).(*domain.Person); ok {
+		return person, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPersonService) CreatePerson(ctx context.Context, name string) (*domain.Person, error) {
+	args := m.Called(ctx, name)
+	if person, ok := args.Get(0).(*domain.Person); ok {
+		return person, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPersonService) UpdatePerson(ctx context.Context, id uint, name string) (*domain.Person, error) {
+	args := m.Called(ctx, id, name)
+	if person, ok := args.Get(0).(*domain.Person); ok {
+		return person, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPersonService) DeletePerson(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func setupPersonRouter(personService *MockPersonService, filmService *MockFilmService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	handler := personHttp.NewPersonHandler(personService, filmService)
+	r := newTestRouter()
+	r.GET("/people", handler.GetPeople)
+	r.GET("/people/:id", handler.GetPerson)
+	r.POST("/people", handler.CreatePerson)
+	r.PUT("/people/:id", handler.UpdatePerson)
+	r.DELETE("/people/:id", handler.DeletePerson)
+	r.GET("/people/:id/films", handler.GetPersonFilms)
+	return r
+}
+
+func TestGetPeople(t *testing.T) {
+	mockService := new(MockPersonService)
+	r := setupPersonRouter(mockService, new(MockFilmService))
+
+	mockService.On("ListPeople", mock.Anything, "pacino", 1, 1).Return(&usecase.PersonPage{
+		People:   []domain.Person{{ID: 8, Name: "Al Pacino"}},
+		Total:    2,
+		Page:     1,
+		PageSize: 1,
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/people?name=pacino&page_size=1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp personHttp.PersonListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, int64(2), resp.Total)
+	assert.Equal(t, "/people?name=pacino&page=2&page_size=1", resp.Next)
+	assert.Empty(t, resp.Prev)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetPerson_NotFound(t *testing.T) {
+	mockService := new(MockPersonService)
+	r := setupPersonRouter(mockService, new(MockFilmService))
+
+	mockService.On("GetPerson", mock.Anything, uint(9)).Return(nil, domain.NotFound("person_not_found", "person not found"))
+
+	req, _ := http.NewRequest("GET", "/people/9", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetPerson_InvalidID(t *testing.T) {
+	r := setupPersonRouter(new(MockPersonService), new(MockFilmService))
+
+	req, _ := http.NewRequest("GET", "/people/abc", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid person ID")
+}
+
+func TestCreatePerson(t *testing.T) {
+	mockService := new(MockPersonService)
+	r := setupPersonRouter(mockService, new(MockFilmService))
+
+	mockService.On("CreatePerson", mock.Anything, "Al Pacino").Return(&domain.Person{ID: 8, Name: "Al Pacino"}, nil)
+
+	req, _ := http.NewRequest("POST", "/people", bytes.NewBufferString(`{"name":"Al Pacino"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"Name":"Al Pacino"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreatePerson_InvalidName(t *testing.T) {
+	mockService := new(MockPersonService)
+	r := setupPersonRouter(mockService, new(MockFilmService))
+
+	mockService.On("CreatePerson", mock.Anything, "  ").Return(nil, domain.Invalid("name", "name is required"))
+
+	req, _ := http.NewRequest("POST", "/people", bytes.NewBufferString(`{"name":"  "}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "name is required")
+}
+
+func TestUpdatePerson_NotFound(t *testing.T) {
+	mockService := new(MockPersonService)
+	r := setupPersonRouter(mockService, new(MockFilmService))
+
+	mockService.On("UpdatePerson", mock.Anything, uint(9), "Someone").Return(nil, domain.NotFound("person_not_found", "person not found"))
+
+	req, _ := http.NewRequest("PUT", "/people/9", bytes.NewBufferString(`{"name":"Someone"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeletePerson(t *testing.T) {
+	mockService := new(MockPersonService)
+	r := setupPersonRouter(mockService, new(MockFilmService))
+
+	mockService.On("DeletePerson", mock.Anything, uint(8)).Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/people/8", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetPersonFilms(t *testing.T) {
+	mockPersonService := new(MockPersonService)
+	mockFilmService := new(MockFilmService)
+	r := setupPersonRouter(mockPersonService, mockFilmService)
+
+	mockPersonService.On("GetPerson", mock.Anything, uint(8)).Return(&domain.Person{ID: 8, Name: "Al Pacino"}, nil)
+	mockFilmService.On("ListFilms", mock.Anything, usecase.ListFilmsQuery{
+		PersonID:   8,
+		CreditRole: domain.CreditRoleActor,
+		Page:       1,
+		PageSize:   20,
+		Sort:       "-release_date",
+	}).Return(&usecase.FilmPage{Films: []domain.Film{{ID: 1, Title: "Heat"}}, Total: 1, Page: 1, PageSize: 20}, nil)
+
+	req, _ := http.NewRequest("GET", "/people/8/films?role=actor&sort=-release_date", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp personHttp.FilmListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, "Heat", resp.Items[0].Title)
+	mockPersonService.AssertExpectations(t)
+	mockFilmService.AssertExpectations(t)
+}
+
+func TestGetPersonFilms_InvalidRole(t *testing.T) {
+	mockFilmService := new(MockFilmService)
+	r := setupPersonRouter(new(MockPersonService), mockFilmService)
+
+	req, _ := http.NewRequest("GET", "/people/8/films?role=producer", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockFilmService.AssertNotCalled(t, "ListFilms", mock.Anything, mock.Anything)
+}
+
+func TestGetPersonFilms_PersonNotFound(t *testing.T) {
+	mockPersonService := new(MockPersonService)
+	mockFilmService := new(MockFilmService)
+	r := setupPersonRouter(mockPersonService, mockFilmService)
+
+	mockPersonService.On("GetPerson", mock.Anything, uint(9)).Return(nil, domain.NotFound("person_not_found", "person not found"))
+
+	req, _ := http.NewRequest("GET", "/people/9/films", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockFilmService.AssertNotCalled(t, "ListFilms", mock.Anything, mock.Anything)
+}
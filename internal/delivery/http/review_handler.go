@@ -0,0 +1,244 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviewService usecase.ReviewService
+}
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" binding:"required" minimum:"1" maximum:"10"`
+	Body   string `json:"body"`
+}
+
+type ReviewListResponse struct {
+	Items    []domain.Review `json:"items"`
+	Total    int64           `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+	Next     string          `json:"next,omitempty"`
+	Prev     string          `json:"prev,omitempty"`
+}
+
+func NewReviewHandler(rs usecase.ReviewService) *ReviewHandler {
+	return &ReviewHandler{reviewService: rs}
+}
+
+// GetReviews godoc
+// @Summary List a film's reviews
+// @Description Retrieves a paginated list of the reviews of a film, newest first.
+// @Tags reviews
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} ReviewListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/{id}/reviews [get]
+func (h *ReviewHandler) GetReviews(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.reviewService.ListReviews(c.Request.Context(), filmID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp := ReviewListResponse{
+		Items:    result.Reviews,
+		Total:    result.Total,
+		Page:     result.Page,
+		PageSize: result.PageSize,
+	}
+	if resp.Items == nil {
+		resp.Items = []domain.Review{}
+	}
+	if int64(result.Page*result.PageSize) < result.Total {
+		resp.Next = listLink(c, "page", strconv.Itoa(result.Page+1))
+	}
+	if result.Page > 1 {
+		resp.Prev = listLink(c, "page", strconv.Itoa(result.Page-1))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateReview godoc
+// @Summary Review a film
+// @Description Adds the authenticated user's review of a film. Each user can review a film once.
+// @Tags reviews
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param review body ReviewRequest true "Rating (1-10) and review text"
+// @Success 201 {object} domain.Review
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 404 {object} middleware.Problem "Film not found"
+// @Failure 409 {object} middleware.Problem "Film already reviewed"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/{id}/reviews [post]
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(c.Request.Context(), filmID, userID, usecase.ReviewData{
+		Rating: req.Rating,
+		Body:   req.Body,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
+}
+
+// UpdateReview godoc
+// @Summary Update a review
+// @Description Replaces the rating and text of a review, only allowed for its author or an admin.
+// @Tags reviews
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param reviewID path int true "Review ID"
+// @Param review body ReviewRequest true "Rating (1-10) and review text"
+// @Success 200 {object} domain.Review
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 403 {object} middleware.Problem "Forbidden: only creator can update this review"
+// @Failure 404 {object} middleware.Problem "Review not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/{id}/reviews/{reviewID} [put]
+func (h *ReviewHandler) UpdateReview(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	review, err := h.reviewService.UpdateReview(c.Request.Context(), filmID, reviewID, userID, currentRole(c), usecase.ReviewData{
+		Rating: req.Rating,
+		Body:   req.Body,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// DeleteReview godoc
+// @Summary Delete a review
+// @Description Deletes a review, only allowed for its author or an admin.
+// @Tags reviews
+// @Security BearerAuth
+// @Param id path int true "Film ID"
+// @Param reviewID path int true "Review ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} middleware.Problem "Invalid ID"
+// @Failure 403 {object} middleware.Problem "Forbidden: only creator can delete this review"
+// @Failure 404 {object} middleware.Problem "Review not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/{id}/reviews/{reviewID} [delete]
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(c.Request.Context(), filmID, reviewID, userID, currentRole(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseFilmID(c *gin.Context) (uint, bool) {
+	return parseFilmIDParam(c, "id")
+}
+
+func parseReviewID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("reviewID"), 10, 32)
+	if err != nil {
+		c.Error(domain.Invalid("reviewID", "invalid review ID"))
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+// currentUserID returns the user set by the JWT middleware, recording an unauthorized error when it is missing.
+func currentUserID(c *gin.Context) (uint, bool) {
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.Error(errUnauthorized)
+		return 0, false
+	}
+	userID, ok := userIDValue.(uint)
+	if !ok {
+		c.Error(errUnauthorized)
+		return 0, false
+	}
+	return userID, true
+}
+
+// currentRole returns the role set by the JWT middleware, defaulting to a plain user.
+func currentRole(c *gin.Context) domain.Role {
+	if role, ok := c.Value("role").(domain.Role); ok {
+		return role
+	}
+	return domain.RoleUser
+}
@@ -0,0 +1,202 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	reviewHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReviewService struct {
+	mock.Mock
+}
+
+func (m *MockReviewService) ListReviews(ctx context.Context, filmID uint, page, pageSize int) (*usecase.ReviewPage, error) {
+	args := m.Called(ctx, filmID, page, pageSize)
+	if result, ok := args.Get(0).(*usecase.ReviewPage); ok {
+		return result, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewService) CreateReview(ctx context.Context, filmID, userID uint, data usecase.ReviewData) (*domain.Review, error) {
+	args := m.Called(ctx, filmID, userID, data)
+	if review, ok := args.Get(0).(*domain.Review); ok {
+		return review, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewService) UpdateReview(ctx context.Context, filmID, reviewID, userID uint, role domain.Role, data usecase.ReviewData) (*domain.Review, error) {
+	args := m.Called(ctx, filmID, reviewID, userID, role, data)
+	if review, ok := args.Get(0).(*domain.Review); ok {
+		return review, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewService) DeleteReview(ctx context.Context, filmID, reviewID, userID uint, role domain.Role) error {
+	args := m.Called(ctx, filmID, reviewID, userID, role)
+	return args.Error(0)
+}
+
+func setupReviewRouter(service *MockReviewService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	handler := reviewHttp.NewReviewHandler(service)
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.GET("/films/:id/reviews", handler.GetReviews)
+	r.POST("/films/:id/reviews", handler.CreateReview)
+	r.PUT("/films/:id/reviews/:reviewID", handler.UpdateReview)
+	r.DELETE("/films/:id/reviews/:reviewID", handler.DeleteReview)
+	return r
+}
+
+func TestGetReviews(t *testing.T) {
+	mockService := new(MockReviewService)
+	r := setupReviewRouter(mockService)
+
+	mockService.On("ListReviews", mock.Anything, uint(1), 1, 20).Return(&usecase.ReviewPage{
+		Reviews:  []domain.Review{{ID: 3, FilmID: 1, UserID: 5, Rating: 8, User: domain.User{ID: 5, Username: "alex", Password: "hash"}}},
+		Total:    1,
+		Page:     1,
+		PageSize: 20,
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/films/1/reviews", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp reviewHttp.ReviewListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, "alex", resp.Items[0].User.Username)
+	assert.NotContains(t, w.Body.String(), "hash")
+	mockService.AssertExpectations(t)
+}
+
+func TestGetReviews_FilmNotFound(t *testing.T) {
+	mockService := new(MockReviewService)
+	r := setupReviewRouter(mockService)
+
+	mockService.On("ListReviews", mock.Anything, uint(9), 1, 20).Return(nil, domain.NotFound("film_not_found", "film not found"))
+
+	req, _ := http.NewRequest("GET", "/films/9/reviews", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreateReview(t *testing.T) {
+	mockService := new(MockReviewService)
+	r := setupReviewRouter(mockService)
+
+	data := usecase.ReviewData{Rating: 9, Body: "Loved it"}
+	mockService.On("CreateReview", mock.Anything, uint(1), uint(5), data).
+		Return(&domain.Review{ID: 3, FilmID: 1, UserID: 5, Rating: 9, Body: "Loved it"}, nil)
+
+	req, _ := http.NewRequest("POST", "/films/1/reviews", bytes.NewBufferString(`{"rating":9,"body":"Loved it"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"Rating":9`)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateReview_Errors(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		code int
+	}{
+		"invalid rating":   {domain.Invalid("rating", "rating must be between 1 and 10"), http.StatusBadRequest},
+		"already reviewed": {domain.Conflict("review_exists", "you have already reviewed this film"), http.StatusConflict},
+		"film not found":   {domain.NotFound("film_not_found", "film not found"), http.StatusNotFound},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(MockReviewService)
+			r := setupReviewRouter(mockService)
+
+			mockService.On("CreateReview", mock.Anything, uint(1), uint(5), mock.Anything).Return(nil, tc.err)
+
+			req, _ := http.NewRequest("POST", "/films/1/reviews", bytes.NewBufferString(`{"rating":12}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func TestUpdateReview_Forbidden(t *testing.T) {
+	mockService := new(MockReviewService)
+	r := setupReviewRouter(mockService)
+
+	mockService.On("UpdateReview", mock.Anything, uint(1), uint(3), uint(5), domain.RoleUser, usecase.ReviewData{Rating: 2}).
+		Return(nil, domain.Forbidden("not_review_author", "forbidden: only creator can update this review"))
+
+	req, _ := http.NewRequest("PUT", "/films/1/reviews/3", bytes.NewBufferString(`{"rating":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteReview(t *testing.T) {
+	mockService := new(MockReviewService)
+	r := setupReviewRouter(mockService)
+
+	mockService.On("DeleteReview", mock.Anything, uint(1), uint(3), uint(5), domain.RoleUser).Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/films/1/reviews/3", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteReview_NotFound(t *testing.T) {
+	mockService := new(MockReviewService)
+	r := setupReviewRouter(mockService)
+
+	mockService.On("DeleteReview", mock.Anything, uint(1), uint(3), uint(5), domain.RoleUser).Return(domain.NotFound("review_not_found", "review not found"))
+
+	req, _ := http.NewRequest("DELETE", "/films/1/reviews/3", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteReview_InvalidID(t *testing.T) {
+	r := setupReviewRouter(new(MockReviewService))
+
+	req, _ := http.NewRequest("DELETE", "/films/1/reviews/abc", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid review ID")
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTrash godoc
+// @Summary List my trashed films
+// @Description Retrieves a paginated list of the films the authenticated user created that are in the trash, most recently deleted first. Trashed films are purged once they are older than the retention period.
+// @Tags trash
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} FilmListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/trash [get]
+func (h *FilmHandler) GetTrash(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	h.listTrash(c, userID)
+}
+
+// GetAllTrash godoc
+// @Summary List all trashed films
+// @Description Retrieves a paginated list of every film in the trash, most recently deleted first. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (starting at 1)"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} FilmListResponse
+// @Failure 400 {object} middleware.Problem "Invalid query parameter"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /admin/trash [get]
+func (h *FilmHandler) GetAllTrash(c *gin.Context) {
+	h.listTrash(c, 0)
+}
+
+func (h *FilmHandler) listTrash(c *gin.Context, userID uint) {
+	page, pageSize, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.filmService.ListTrash(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeFilmPage(c, result, false)
+}
+
+// RestoreFilm godoc
+// @Summary Restore a film from the trash
+// @Description Takes a deleted film out of the trash together with its genres, credits and reviews. Allowed for whoever may delete the film.
+// @Tags trash
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Film ID"
+// @Success 200 {object} domain.Film
+// @Failure 400 {object} middleware.Problem "Invalid Film ID"
+// @Failure 403 {object} middleware.Problem "Forbidden: only creator can restore this film"
+// @Failure 404 {object} middleware.Problem "Film not found in trash"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /films/{id}/restore [post]
+func (h *FilmHandler) RestoreFilm(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	film, err := h.filmService.RestoreFilm(c.Request.Context(), filmID, userID, currentRole(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeFilm(c, http.StatusOK, film)
+}
+
+// PurgeFilm godoc
+// @Summary Purge a trashed film
+// @Description Permanently deletes a film from the trash, with everything that references it. Films must be deleted before they can be purged. Admins only.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path int true "Film ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} middleware.Problem "Invalid Film ID"
+// @Failure 403 {object} middleware.Problem "Forbidden: insufficient role"
+// @Failure 404 {object} middleware.Problem "Film not found in trash"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /admin/trash/{id} [delete]
+func (h *FilmHandler) PurgeFilm(c *gin.Context) {
+	filmID, ok := parseFilmID(c)
+	if !ok {
+		return
+	}
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.filmService.PurgeFilm(c.Request.Context(), filmID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,133 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	filmHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	usecase "go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupTrashRouter(mockService *MockFilmService, role domain.Role) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	filmHandler := filmHttp.NewFilmHandler(mockService)
+
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Set("role", role)
+		c.Next()
+	})
+	r.GET("/me/trash", filmHandler.GetTrash)
+	r.POST("/films/:id/restore", filmHandler.RestoreFilm)
+	r.GET("/admin/trash", filmHandler.GetAllTrash)
+	r.DELETE("/admin/trash/:id", filmHandler.PurgeFilm)
+	return r
+}
+
+func TestGetTrash(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupTrashRouter(mockService, domain.RoleUser)
+
+	mockService.On("ListTrash", mock.Anything, uint(5), 2, 10).Return(&usecase.FilmPage{
+		Films:    []domain.Film{{ID: 10, Title: "Heat"}},
+		Total:    11,
+		Page:     2,
+		PageSize: 10,
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/me/trash?page=2&page_size=10", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp filmHttp.FilmListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Heat", resp.Items[0].Title)
+	assert.Equal(t, int64(11), resp.Total)
+	assert.Equal(t, "/me/trash?page=1&page_size=10", resp.Prev)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAllTrash(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupTrashRouter(mockService, domain.RoleAdmin)
+
+	// A zero user ID lists the trash of every user
+	mockService.On("ListTrash", mock.Anything, uint(0), 1, 20).Return(&usecase.FilmPage{Page: 1, PageSize: 20}, nil)
+
+	req, _ := http.NewRequest("GET", "/admin/trash", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"items":[]`)
+	mockService.AssertExpectations(t)
+}
+
+func TestRestoreFilm(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupTrashRouter(mockService, domain.RoleUser)
+
+	mockService.On("RestoreFilm", mock.Anything, uint(10), uint(5), domain.RoleUser).
+		Return(&domain.Film{ID: 10, UserID: 5, Title: "Heat", Version: 3}, nil)
+	mockService.On("RestoreFilm", mock.Anything, uint(11), uint(5), domain.RoleUser).
+		Return(nil, domain.NotFound("film_not_in_trash", "film not found in trash"))
+	mockService.On("RestoreFilm", mock.Anything, uint(12), uint(5), domain.RoleUser).
+		Return(nil, domain.Forbidden("not_film_creator", "forbidden: only creator can restore this film"))
+
+	req, _ := http.NewRequest("POST", "/films/10/restore", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("ETag"), `"3-`)
+	var film domain.Film
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &film))
+	assert.Equal(t, "Heat", film.Title)
+
+	req, _ = http.NewRequest("POST", "/films/11/restore", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"film_not_in_trash"`)
+
+	req, _ = http.NewRequest("POST", "/films/12/restore", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req, _ = http.NewRequest("POST", "/films/abc/restore", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestPurgeFilm(t *testing.T) {
+	mockService := new(MockFilmService)
+	r := setupTrashRouter(mockService, domain.RoleAdmin)
+
+	mockService.On("PurgeFilm", mock.Anything, uint(10), uint(5)).Return(nil)
+	mockService.On("PurgeFilm", mock.Anything, uint(11), uint(5)).Return(domain.NotFound("film_not_in_trash", "film not found in trash"))
+
+	req, _ := http.NewRequest("DELETE", "/admin/trash/10", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	req, _ = http.NewRequest("DELETE", "/admin/trash/11", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,369 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WatchlistHandler struct {
+	watchlistService usecase.WatchlistService
+}
+
+type WatchlistRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+}
+
+type AddWatchlistItemRequest struct {
+	FilmID uint `json:"film_id" binding:"required"`
+	// Position is zero-based; the film is appended when it is omitted
+	Position *int `json:"position"`
+}
+
+type MoveWatchlistItemRequest struct {
+	Position *int `json:"position" binding:"required"`
+}
+
+func NewWatchlistHandler(ws usecase.WatchlistService) *WatchlistHandler {
+	return &WatchlistHandler{watchlistService: ws}
+}
+
+// GetWatchlists godoc
+// @Summary List my watchlists
+// @Description Retrieves the authenticated user's watchlists, without their items.
+// @Tags watchlists
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} domain.Watchlist
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists [get]
+func (h *WatchlistHandler) GetWatchlists(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	watchlists, err := h.watchlistService.ListWatchlists(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if watchlists == nil {
+		watchlists = []domain.Watchlist{}
+	}
+
+	c.JSON(http.StatusOK, watchlists)
+}
+
+// GetWatchlist godoc
+// @Summary Get a watchlist
+// @Description Retrieves a watchlist with its films in order. Other users' watchlists are only visible when public.
+// @Tags watchlists
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Watchlist ID"
+// @Success 200 {object} domain.Watchlist
+// @Failure 400 {object} middleware.Problem "Invalid watchlist ID"
+// @Failure 404 {object} middleware.Problem "Watchlist not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists/{id} [get]
+// @Router /lists/{id} [get]
+func (h *WatchlistHandler) GetWatchlist(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseWatchlistID(c)
+	if !ok {
+		return
+	}
+
+	watchlist, err := h.watchlistService.GetWatchlist(c.Request.Context(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, watchlist)
+}
+
+// CreateWatchlist godoc
+// @Summary Create a watchlist
+// @Description Creates an empty watchlist for the authenticated user. Watchlists are private unless public is set.
+// @Tags watchlists
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param watchlist body WatchlistRequest true "Watchlist details"
+// @Success 201 {object} domain.Watchlist
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists [post]
+func (h *WatchlistHandler) CreateWatchlist(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	watchlist, err := h.watchlistService.CreateWatchlist(c.Request.Context(), userID, usecase.WatchlistData{
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, watchlist)
+}
+
+// UpdateWatchlist godoc
+// @Summary Update a watchlist
+// @Description Replaces the name, description and visibility of one of the authenticated user's watchlists.
+// @Tags watchlists
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Watchlist ID"
+// @Param watchlist body WatchlistRequest true "Watchlist details"
+// @Success 200 {object} domain.Watchlist
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 404 {object} middleware.Problem "Watchlist not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists/{id} [put]
+func (h *WatchlistHandler) UpdateWatchlist(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseWatchlistID(c)
+	if !ok {
+		return
+	}
+
+	var req WatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	watchlist, err := h.watchlistService.UpdateWatchlist(c.Request.Context(), id, userID, usecase.WatchlistData{
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, watchlist)
+}
+
+// DeleteWatchlist godoc
+// @Summary Delete a watchlist
+// @Description Deletes one of the authenticated user's watchlists.
+// @Tags watchlists
+// @Security BearerAuth
+// @Param id path int true "Watchlist ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} middleware.Problem "Invalid watchlist ID"
+// @Failure 404 {object} middleware.Problem "Watchlist not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists/{id} [delete]
+func (h *WatchlistHandler) DeleteWatchlist(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseWatchlistID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.watchlistService.DeleteWatchlist(c.Request.Context(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWatchlistItems godoc
+// @Summary List the films of a watchlist
+// @Description Retrieves the items of a watchlist in list order. Other users' watchlists are only visible when public.
+// @Tags watchlists
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Watchlist ID"
+// @Success 200 {array} domain.WatchlistItem
+// @Failure 400 {object} middleware.Problem "Invalid watchlist ID"
+// @Failure 404 {object} middleware.Problem "Watchlist not found"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists/{id}/items [get]
+func (h *WatchlistHandler) GetWatchlistItems(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseWatchlistID(c)
+	if !ok {
+		return
+	}
+
+	watchlist, err := h.watchlistService.GetWatchlist(c.Request.Context(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeWatchlistItems(c, http.StatusOK, watchlist)
+}
+
+// AddWatchlistItem godoc
+// @Summary Add a film to a watchlist
+// @Description Inserts a film at the given zero-based position, or appends it when position is omitted.
+// @Tags watchlists
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Watchlist ID"
+// @Param item body AddWatchlistItemRequest true "Film and optional position"
+// @Success 201 {array} domain.WatchlistItem
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 404 {object} middleware.Problem "Watchlist or film not found"
+// @Failure 409 {object} middleware.Problem "Film already in the watchlist"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists/{id}/items [post]
+func (h *WatchlistHandler) AddWatchlistItem(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseWatchlistID(c)
+	if !ok {
+		return
+	}
+
+	var req AddWatchlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	watchlist, err := h.watchlistService.AddWatchlistItem(c.Request.Context(), id, userID, req.FilmID, req.Position)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeWatchlistItems(c, http.StatusCreated, watchlist)
+}
+
+// MoveWatchlistItem godoc
+// @Summary Move a film within a watchlist
+// @Description Moves a film to a new zero-based position, shifting the films in between.
+// @Tags watchlists
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Watchlist ID"
+// @Param filmID path int true "Film ID"
+// @Param item body MoveWatchlistItemRequest true "New position"
+// @Success 200 {array} domain.WatchlistItem
+// @Failure 400 {object} middleware.Problem "Invalid input"
+// @Failure 404 {object} middleware.Problem "Watchlist not found or film not in it"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists/{id}/items/{filmID} [put]
+func (h *WatchlistHandler) MoveWatchlistItem(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseWatchlistID(c)
+	if !ok {
+		return
+	}
+	filmID, ok := parseFilmIDParam(c, "filmID")
+	if !ok {
+		return
+	}
+
+	var req MoveWatchlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(errInvalidBody)
+		return
+	}
+
+	watchlist, err := h.watchlistService.MoveWatchlistItem(c.Request.Context(), id, userID, filmID, *req.Position)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeWatchlistItems(c, http.StatusOK, watchlist)
+}
+
+// RemoveWatchlistItem godoc
+// @Summary Remove a film from a watchlist
+// @Description Removes a film from one of the authenticated user's watchlists.
+// @Tags watchlists
+// @Security BearerAuth
+// @Param id path int true "Watchlist ID"
+// @Param filmID path int true "Film ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} middleware.Problem "Invalid ID"
+// @Failure 404 {object} middleware.Problem "Watchlist not found or film not in it"
+// @Failure 500 {object} middleware.Problem "Internal Server Error"
+// @Router /me/lists/{id}/items/{filmID} [delete]
+func (h *WatchlistHandler) RemoveWatchlistItem(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseWatchlistID(c)
+	if !ok {
+		return
+	}
+	filmID, ok := parseFilmIDParam(c, "filmID")
+	if !ok {
+		return
+	}
+
+	if _, err := h.watchlistService.RemoveWatchlistItem(c.Request.Context(), id, userID, filmID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseWatchlistID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(domain.Invalid("id", "invalid watchlist ID"))
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+func writeWatchlistItems(c *gin.Context, status int, watchlist *domain.Watchlist) {
+	items := watchlist.Items
+	if items == nil {
+		items = []domain.WatchlistItem{}
+	}
+	c.JSON(status, items)
+}
@@ -0,0 +1,253 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	watchlistHttp "go-films-api/internal/delivery/http"
+	"go-films-api/internal/domain"
+	"go-films-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWatchlistService struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistService) ListWatchlists(ctx context.Context, userID uint) ([]domain.Watchlist, error) {
+	args := m.Called(ctx, userID)
+	if watchlists, ok := args.Get(0).([]domain.Watchlist); ok {
+		return watchlists, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWatchlistService) GetWatchlist(ctx context.Context, id, userID uint) (*domain.Watchlist, error) {
+	args := m.Called(ctx, id, userID)
+	if watchlist, ok := args.Get(0).(*domain.Watchlist); ok {
+		return watchlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWatchlistService) CreateWatchlist(ctx context.Context, userID uint, data usecase.WatchlistData) (*domain.Watchlist, error) {
+	args := m.Called(ctx, userID, data)
+	if watchlist, ok := args.Get(0).(*domain.Watchlist); ok {
+		return watchlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWatchlistService) UpdateWatchlist(ctx context.Context, id, userID uint, data usecase.WatchlistData) (*domain.Watchlist, error) {
+	args := m.Called(ctx, id, userID, data)
+	if watchlist, ok := args.Get(0).(*domain.Watchlist); ok {
+		return watchlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWatchlistService) DeleteWatchlist(ctx context.Context, id, userID uint) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistService) AddWatchlistItem(ctx context.Context, id, userID, filmID uint, position *int) (*domain.Watchlist, error) {
+	args := m.Called(ctx, id, userID, filmID, position)
+	if watchlist, ok := args.Get(0).(*domain.Watchlist); ok {
+		return watchlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWatchlistService) MoveWatchlistItem(ctx context.Context, id, userID, filmID uint, position int) (*domain.Watchlist, error) {
+	args := m.Called(ctx, id, userID, filmID, position)
+	if watchlist, ok := args.Get(0).(*domain.Watchlist); ok {
+		return watchlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWatchlistService) RemoveWatchlistItem(ctx context.Context, id, userID, filmID uint) (*domain.Watchlist, error) {
+	args := m.Called(ctx, id, userID, filmID)
+	if watchlist, ok := args.Get(0).(*domain.Watchlist); ok {
+		return watchlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupWatchlistRouter(service *MockWatchlistService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	handler := watchlistHttp.NewWatchlistHandler(service)
+	r := newTestRouter()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(5))
+		c.Next()
+	})
+	r.GET("/me/lists", handler.GetWatchlists)
+	r.POST("/me/lists", handler.CreateWatchlist)
+	r.GET("/me/lists/:id", handler.GetWatchlist)
+	r.PUT("/me/lists/:id", handler.UpdateWatchlist)
+	r.DELETE("/me/lists/:id", handler.DeleteWatchlist)
+	r.GET("/me/lists/:id/items", handler.GetWatchlistItems)
+	r.POST("/me/lists/:id/items", handler.AddWatchlistItem)
+	r.PUT("/me/lists/:id/items/:filmID", handler.MoveWatchlistItem)
+	r.DELETE("/me/lists/:id/items/:filmID", handler.RemoveWatchlistItem)
+	return r
+}
+
+func TestGetWatchlists_Empty(t *testing.T) {
+	mockService := new(MockWatchlistService)
+	r := setupWatchlistRouter(mockService)
+
+	mockService.On("ListWatchlists", mock.Anything, uint(5)).Return(nil, nil)
+
+	req, _ := http.NewRequest("GET", "/me/lists", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, "[]", w.Body.String())
+}
+
+func TestCreateWatchlist(t *testing.T) {
+	mockService := new(MockWatchlistService)
+	r := setupWatchlistRouter(mockService)
+
+	data := usecase.WatchlistData{Name: "Weekend", Public: true}
+	mockService.On("CreateWatchlist", mock.Anything, uint(5), data).
+		Return(&domain.Watchlist{ID: 1, UserID: 5, Name: "Weekend", Public: true}, nil)
+
+	req, _ := http.NewRequest("POST", "/me/lists", bytes.NewBufferString(`{"name":"Weekend","public":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"Public":true`)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetWatchlist_NotFound(t *testing.T) {
+	mockService := new(MockWatchlistService)
+	r := setupWatchlistRouter(mockService)
+
+	mockService.On("GetWatchlist", mock.Anything, uint(1), uint(5)).Return(nil, domain.NotFound("watchlist_not_found", "watchlist not found"))
+
+	req, _ := http.NewRequest("GET", "/me/lists/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAddWatchlistItem(t *testing.T) {
+	mockService := new(MockWatchlistService)
+	r := setupWatchlistRouter(mockService)
+
+	position := 0
+	mockService.On("AddWatchlistItem", mock.Anything, uint(1), uint(5), uint(3), &position).Return(&domain.Watchlist{
+		ID:    1,
+		Items: []domain.WatchlistItem{{WatchlistID: 1, FilmID: 3}, {WatchlistID: 1, FilmID: 7, Position: 1}},
+	}, nil)
+
+	req, _ := http.NewRequest("POST", "/me/lists/1/items", bytes.NewBufferString(`{"film_id":3,"position":0}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var items []domain.WatchlistItem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+	assert.Len(t, items, 2)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddWatchlistItem_Errors(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		code int
+	}{
+		"duplicate":    {domain.Conflict("watchlist_item_exists", "film is already in this watchlist"), http.StatusConflict},
+		"bad position": {domain.Invalid("position", "position must be between 0 and 2"), http.StatusBadRequest},
+		"no film":      {domain.NotFound("film_not_found", "film not found"), http.StatusNotFound},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockService := new(MockWatchlistService)
+			r := setupWatchlistRouter(mockService)
+
+			mockService.On("AddWatchlistItem", mock.Anything, uint(1), uint(5), uint(3), mock.Anything).Return(nil, tc.err)
+
+			req, _ := http.NewRequest("POST", "/me/lists/1/items", bytes.NewBufferString(`{"film_id":3}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func TestMoveWatchlistItem(t *testing.T) {
+	mockService := new(MockWatchlistService)
+	r := setupWatchlistRouter(mockService)
+
+	mockService.On("MoveWatchlistItem", mock.Anything, uint(1), uint(5), uint(3), 0).
+		Return(&domain.Watchlist{ID: 1, Items: []domain.WatchlistItem{{FilmID: 3}}}, nil)
+
+	req, _ := http.NewRequest("PUT", "/me/lists/1/items/3", bytes.NewBufferString(`{"position":0}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestMoveWatchlistItem_MissingPosition(t *testing.T) {
+	mockService := new(MockWatchlistService)
+	r := setupWatchlistRouter(mockService)
+
+	req, _ := http.NewRequest("PUT", "/me/lists/1/items/3", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "MoveWatchlistItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveWatchlistItem(t *testing.T) {
+	mockService := new(MockWatchlistService)
+	r := setupWatchlistRouter(mockService)
+
+	mockService.On("RemoveWatchlistItem", mock.Anything, uint(1), uint(5), uint(3)).Return(&domain.Watchlist{ID: 1}, nil)
+
+	req, _ := http.NewRequest("DELETE", "/me/lists/1/items/3", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteWatchlist_NotFound(t *testing.T) {
+	mockService := new(MockWatchlistService)
+	r := setupWatchlistRouter(mockService)
+
+	mockService.On("DeleteWatchlist", mock.Anything, uint(1), uint(5)).Return(domain.NotFound("watchlist_not_found", "watchlist not found"))
+
+	req, _ := http.NewRequest("DELETE", "/me/lists/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// AuditAction is the kind of change an audit event records.
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionPurge   AuditAction = "purge"
+)
+
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionCreate, AuditActionUpdate, AuditActionDelete, AuditActionRestore, AuditActionPurge:
+		return true
+	}
+	return false
+}
+
+// AuditEvent records one change to a film. It is written in the same
+// transaction as the change and outlives the film, so purged films keep
+// their history.
+type AuditEvent struct {
+	ID     uint        `gorm:"primaryKey"`
+	FilmID uint        `gorm:"not null;index"`
+	Action AuditAction `gorm:"type:varchar(20);not null"`
+
+	// The user who made the change; nil for changes made by the server
+	// itself, such as the trash retention job
+	ActorID *uint
+	Actor   *User `gorm:"foreignKey:ActorID"`
+
+	// Field-level diff; empty for actions that do not change the fields
+	Changes   []FieldChange `gorm:"serializer:json"`
+	CreatedAt time.Time
+}
+
+// FieldChange is the value of one film field before and after a change.
+// Before is nil for created films.
+type FieldChange struct {
+	Field  string
+	Before interface{}
+	After  interface{}
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Error kinds. Every error the services return for a condition the client
+// can act on matches exactly one of them with errors.Is; anything else is an
+// internal error.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrValidation   = errors.New("validation failed")
+	ErrConflict     = errors.New("conflict")
+	ErrUnavailable  = errors.New("service unavailable")
+	ErrTimeout      = errors.New("timeout")
+
+	// Conditional requests: the client's version is stale, or it sent none
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
+
+	// The request body is in a format the endpoint does not accept
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// Error is a typed error. Kind is one of the sentinels above, Code a stable
+// machine-readable identifier such as "film_not_found" and Message the
+// human-readable text. Validation errors list the offending fields.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// FieldError describes why a single input field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is makes errors.Is(err, ErrNotFound) and friends match on the kind.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return &Error{Kind: ErrPreconditionFailed, Code: code, Message: message}
+}
+
+func PreconditionRequired(code, message string) *Error {
+	return &Error{Kind: ErrPreconditionRequired, Code: code, Message: message}
+}
+
+func UnsupportedMediaType(code, message string) *Error {
+	return &Error{Kind: ErrUnsupportedMediaType, Code: code, Message: message}
+}
+
+// Unavailable marks err as a temporary failure of a dependency, e.g. a lost
+// database connection. The cause is kept for logging but not shown to clients.
+func Unavailable(err error) *Error {
+	return &Error{Kind: ErrUnavailable, Code: "service_unavailable", Message: "service temporarily unavailable", Err: err}
+}
+
+// Timeout marks err as the request running out of time before it could be
+// served. Like Unavailable, the cause is kept for logging only.
+func Timeout(err error) *Error {
+	return &Error{Kind: ErrTimeout, Code: "request_timeout", Message: "request timed out", Err: err}
+}
+
+// Invalid reports a single rejected field.
+func Invalid(field, message string) *Error {
+	return ValidationFailed([]FieldError{{Field: field, Message: message}})
+}
+
+// ValidationFailed reports every rejected field at once. The message joins
+// the field messages so a single failure reads the same as with Invalid.
+func ValidationFailed(fields []FieldError) *Error {
+	messages := make([]string, len(fields))
+	for i, f := range fields {
+		messages[i] = f.Message
+	}
+	return &Error{Kind: ErrValidation, Code: "validation_failed", Message: strings.Join(messages, "; "), Fields: fields}
+}
+
+// ErrorCode returns the stable code of err, or "internal_error" when err is not typed.
+func ErrorCode(err error) string {
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed.Code
+	}
+	return "internal_error"
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+type Favorite struct {
+	UserID    uint `gorm:"primaryKey"`
+	FilmID    uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	Film Film `gorm:"foreignKey:FilmID"`
+}
@@ -1,18 +1,56 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrFilmModified is returned when a film was changed after the version an
+// update was based on.
+var ErrFilmModified = PreconditionFailed("film_modified", "film has been modified since it was fetched")
 
 type Film struct {
-	ID          uint   `gorm:"primaryKey"`
-	UserID      uint   `gorm:"not null"`
-	Title       string `gorm:"type:varchar(255);uniqueIndex;not null"`
-	Director    string
-	ReleaseDate time.Time
-	Cast        string
-	Genre       string
+	ID          uint       `gorm:"primaryKey"`
+	UserID      uint       `gorm:"not null"`
+	Title       string     `gorm:"type:varchar(255);uniqueIndex;not null"`
+	ReleaseDate *time.Time // nil when unknown
 	Synopsis    string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 
-	User User `gorm:"foreignKey:UserID"`
+	// Incremented by every update and served as the film's ETag
+	Version uint `gorm:"not null;default:1"`
+
+	// Set while the film is in the trash; trashed films are left out of
+	// every query that does not ask for them explicitly
+	DeletedAt gorm.DeletedAt `gorm:"index" swaggertype:"string" format:"date-time"`
+
+	// Review aggregates, maintained by the review repository
+	AverageRating float64 `gorm:"type:decimal(4,2);->"`
+	ReviewCount   int     `gorm:"->"`
+
+	User    User         `gorm:"foreignKey:UserID"`
+	Genres  []Genre      `gorm:"many2many:film_genres"`
+	Credits []FilmCredit `gorm:"foreignKey:FilmID"`
+	Images  []FilmImage  `gorm:"foreignKey:FilmID"`
+
+	// Search metadata, only set when films are listed with a full-text query
+	Score      float64           `gorm:"->;-:migration" json:",omitempty"`
+	Highlights map[string]string `gorm:"-" json:",omitempty"`
+
+	// Value of a computed sort expression, only set when films are listed
+	// in an order that is not a column of their own; cursors resume from it
+	SortKey string `gorm:"->;-:migration" json:"-"`
+}
+
+// CreditNames returns the names of the people credited in role, in billing order.
+func (f Film) CreditNames(role CreditRole) []string {
+	var names []string
+	for _, credit := range f.Credits {
+		if credit.Role == role {
+			names = append(names, credit.Person.Name)
+		}
+	}
+	return names
 }
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+type Genre struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"type:varchar(50);uniqueIndex;not null"`
+	Slug      string `gorm:"type:varchar(50);uniqueIndex;not null"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// ImageKind says what an image of a film shows.
+type ImageKind string
+
+const (
+	ImageKindPoster   ImageKind = "poster"
+	ImageKindBackdrop ImageKind = "backdrop"
+	ImageKindStill    ImageKind = "still"
+)
+
+func (k ImageKind) IsValid() bool {
+	switch k {
+	case ImageKindPoster, ImageKindBackdrop, ImageKindStill:
+		return true
+	}
+	return false
+}
+
+// FilmImage is an uploaded image of a film. The original and its thumbnails
+// are kept in a blob store under their Key; URL is where clients fetch them.
+type FilmImage struct {
+	ID          uint      `gorm:"primaryKey"`
+	FilmID      uint      `gorm:"not null;index"`
+	Kind        ImageKind `gorm:"type:varchar(20);not null"`
+	ContentType string    `gorm:"type:varchar(50);not null"`
+	Width       int       `gorm:"not null"`
+	Height      int       `gorm:"not null"`
+	Size        int64     `gorm:"not null"`
+	Key         string    `gorm:"type:varchar(255);not null"`
+	URL         string    `gorm:"type:varchar(1024);not null"`
+	CreatedAt   time.Time
+
+	Thumbnails []ImageThumbnail `gorm:"serializer:json"`
+}
+
+// ImageThumbnail is a scaled-down copy of a FilmImage. Name is one of the
+// thumbnail sizes, such as "small".
+type ImageThumbnail struct {
+	Name   string
+	Key    string
+	URL    string
+	Width  int
+	Height int
+}
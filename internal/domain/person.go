@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+type Person struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"type:varchar(255);index;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CreditRole string
+
+const (
+	CreditRoleDirector CreditRole = "director"
+	CreditRoleActor    CreditRole = "actor"
+	CreditRoleWriter   CreditRole = "writer"
+	CreditRoleComposer CreditRole = "composer"
+)
+
+func (r CreditRole) IsValid() bool {
+	switch r {
+	case CreditRoleDirector, CreditRoleActor, CreditRoleWriter, CreditRoleComposer:
+		return true
+	}
+	return false
+}
+
+// FilmCredit links a person to a film in a given role. Character is only set
+// for actors; BillingOrder sorts the credits of a film.
+type FilmCredit struct {
+	ID           uint       `gorm:"primaryKey"`
+	FilmID       uint       `gorm:"not null;index"`
+	PersonID     uint       `gorm:"not null;index"`
+	Role         CreditRole `gorm:"type:varchar(20);not null"`
+	Character    string     `gorm:"column:character_name;type:varchar(255)"`
+	BillingOrder int        `gorm:"not null;default:0"`
+
+	Person Person `gorm:"foreignKey:PersonID"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+type Review struct {
+	ID        uint `gorm:"primaryKey"`
+	FilmID    uint `gorm:"not null;uniqueIndex:uq_reviews_film_user"`
+	UserID    uint `gorm:"not null;uniqueIndex:uq_reviews_film_user"`
+	Rating    int  `gorm:"type:tinyint;not null"`
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
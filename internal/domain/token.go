@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// RefreshToken is one link in a session's rotation chain. Only the SHA-256
+// hash of the token is stored. Every refresh marks the presented token as
+// used and issues a new one in the same family, so a used token showing up
+// again means it was stolen and the whole family is revoked.
+type RefreshToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null"`
+	FamilyID  string `gorm:"type:char(32);not null;index"`
+	TokenHash string `gorm:"type:char(64);uniqueIndex;not null"`
+
+	// The access token issued together with this refresh token, so revoking
+	// the family can also revoke access tokens that have not expired yet
+	AccessTokenID        string    `gorm:"type:char(32);not null"`
+	AccessTokenExpiresAt time.Time `gorm:"not null"`
+
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// RevokedToken records the jti of an access token that must no longer be
+// accepted. Rows can be dropped once ExpiresAt has passed.
+type RevokedToken struct {
+	JTI       string    `gorm:"column:jti;type:char(32);primaryKey"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
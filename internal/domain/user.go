@@ -5,6 +5,61 @@ import "time"
 type User struct {
 	ID        uint   `gorm:"primaryKey"`
 	Username  string `gorm:"type:varchar(50);uniqueIndex;not null"`
-	Password  string `gorm:"type:varchar(255);not null"`
+	Password  string `gorm:"type:varchar(255);not null" json:"-"`
+	Role      Role   `gorm:"type:varchar(20);not null;default:user"`
 	CreatedAt time.Time
 }
+
+type Role string
+
+const (
+	RoleUser   Role = "user"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleUser, RoleEditor, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Permission is an action that goes beyond what every user may do with
+// the records they own.
+type Permission string
+
+const (
+	// Edit films created by other users
+	PermEditAnyFilm Permission = "films:edit_any"
+	// Delete films created by other users
+	PermDeleteAnyFilm Permission = "films:delete_any"
+	// Edit or delete reviews written by other users
+	PermModerateReviews Permission = "reviews:moderate"
+	// Create, rename and delete genres and people
+	PermManageCatalog Permission = "catalog:manage"
+	// List users and change their roles
+	PermManageUsers Permission = "users:manage"
+	// Permanently delete trashed films
+	PermPurgeFilms Permission = "films:purge"
+	// Read the audit log of every film
+	PermViewAudit Permission = "audit:view"
+)
+
+// rolePermissions is the access policy. Plain users only get the implicit
+// right to change what they created.
+var rolePermissions = map[Role][]Permission{
+	RoleEditor: {PermEditAnyFilm, PermManageCatalog},
+	RoleAdmin:  {PermEditAnyFilm, PermDeleteAnyFilm, PermModerateReviews, PermManageCatalog, PermManageUsers, PermPurgeFilms, PermViewAudit},
+}
+
+// Can reports whether the role grants the permission.
+func (r Role) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
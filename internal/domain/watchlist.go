@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Watchlist is a named, ordered list of films owned by a user. Public
+// watchlists can be read by every user.
+type Watchlist struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;index"`
+	Name        string `gorm:"type:varchar(100);not null"`
+	Description string
+	Public      bool `gorm:"not null;default:false"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Items []WatchlistItem `gorm:"foreignKey:WatchlistID"`
+}
+
+type WatchlistItem struct {
+	WatchlistID uint `gorm:"primaryKey"`
+	FilmID      uint `gorm:"primaryKey"`
+	Position    int  `gorm:"not null"`
+	CreatedAt   time.Time
+
+	Film Film `gorm:"foreignKey:FilmID"`
+}
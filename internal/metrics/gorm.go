@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+const startKey = "metrics:start"
+
+// gormPlugin times every statement GORM runs, from the first callback of an
+// operation to its last.
+type gormPlugin struct {
+	queryDuration *prometheus.HistogramVec
+}
+
+func (p *gormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	processors := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("*").Register, cb.Create().After("*").Register},
+		{"query", cb.Query().Before("*").Register, cb.Query().After("*").Register},
+		{"update", cb.Update().Before("*").Register, cb.Update().After("*").Register},
+		{"delete", cb.Delete().Before("*").Register, cb.Delete().After("*").Register},
+		{"row", cb.Row().Before("*").Register, cb.Row().After("*").Register},
+		{"raw", cb.Raw().Before("*").Register, cb.Raw().After("*").Register},
+	}
+	for _, proc := range processors {
+		if err := proc.before("metrics:before_"+proc.operation, p.start); err != nil {
+			return err
+		}
+		if err := proc.after("metrics:after_"+proc.operation, p.observe(proc.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *gormPlugin) start(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func (p *gormPlugin) observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+
+		// Not finding a record is an answer, not a failure
+		status := "ok"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			status = "error"
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		p.queryDuration.WithLabelValues(operation, table, status).Observe(time.Since(start).Seconds())
+	}
+}
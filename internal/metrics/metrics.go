@@ -0,0 +1,113 @@
+// Package metrics collects the Prometheus metrics of the API: HTTP requests,
+// logins and registrations, and the database connection pool and queries.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+const namespace = "films"
+
+// Outcomes of a login or a registration
+const (
+	ResultSuccess            = "success"
+	ResultInvalidCredentials = "invalid_credentials"
+	ResultInvalidRequest     = "invalid_request"
+	ResultConflict           = "conflict"
+	ResultError              = "error"
+)
+
+// Metrics holds the collectors of the API in a registry of its own, so that
+// tests can create as many as they need.
+type Metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	logins          *prometheus.CounterVec
+	registrations   *prometheus.CounterVec
+	queryDuration   *prometheus.HistogramVec
+}
+
+// New registers the collectors of the API, along with the Go runtime and
+// process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to serve HTTP requests, by method, route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_logins_total",
+			Help:      "Login attempts, by result.",
+		}, []string{"result"}),
+		registrations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_registrations_total",
+			Help:      "Registration attempts, by result.",
+		}, []string{"result"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Time taken by database queries, by operation, table and status.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"operation", "table", "status"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests, m.requestDuration, m.logins, m.registrations, m.queryDuration,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a served request. route is the route template,
+// such as "/films/:id", so that every film counts towards the same series.
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(method, route, code).Inc()
+	m.requestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// LoginAttempt counts a login with one of the Result outcomes.
+func (m *Metrics) LoginAttempt(result string) {
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// Registration counts a registration with one of the Result outcomes.
+func (m *Metrics) Registration(result string) {
+	m.registrations.WithLabelValues(result).Inc()
+}
+
+// InstrumentDB collects the connection pool stats of db and the duration
+// of every query it runs.
+func (m *Metrics) InstrumentDB(db *gorm.DB, name string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := m.registry.Register(collectors.NewDBStatsCollector(sqlDB, name)); err != nil {
+		return err
+	}
+	return db.Use(&gormPlugin{queryDuration: m.queryDuration})
+}
@@ -0,0 +1,63 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-films-api/internal/database"
+	"go-films-api/internal/metrics"
+)
+
+func scrape(m *metrics.Metrics) string {
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, req)
+	return w.Body.String()
+}
+
+func TestObserveRequest(t *testing.T) {
+	m := metrics.New()
+	m.ObserveRequest("GET", "/films/:id", http.StatusOK, 20*time.Millisecond)
+	m.ObserveRequest("GET", "/films/:id", http.StatusOK, 30*time.Millisecond)
+	m.ObserveRequest("GET", "/films/:id", http.StatusNotFound, time.Millisecond)
+
+	body := scrape(m)
+	assert.Contains(t, body, `films_http_requests_total{method="GET",route="/films/:id",status="200"} 2`)
+	assert.Contains(t, body, `films_http_requests_total{method="GET",route="/films/:id",status="404"} 1`)
+	assert.Contains(t, body, `films_http_request_duration_seconds_count{method="GET",route="/films/:id",status="200"} 2`)
+	assert.Contains(t, body, `films_http_request_duration_seconds_bucket{method="GET",route="/films/:id",status="200",le="0.025"} 1`)
+}
+
+func TestInstrumentDB(t *testing.T) {
+	cfg := database.Config{Driver: database.SQLite, Name: filepath.Join(t.TempDir(), "films.db")}
+	db, err := database.Open(cfg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	m := metrics.New()
+	assert.NoError(t, m.InstrumentDB(db, "films"))
+
+	type item struct {
+		ID   uint
+		Name string
+	}
+	assert.NoError(t, db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)").Error)
+	assert.NoError(t, db.Table("items").Create(&item{Name: "a"}).Error)
+	var found item
+	assert.Error(t, db.Table("items").First(&found, 42).Error)
+	assert.Error(t, db.Table("missing").First(&found).Error)
+
+	body := scrape(m)
+	assert.Contains(t, body, `films_db_query_duration_seconds_count{operation="create",status="ok",table="items"} 1`)
+	assert.Contains(t, body, `films_db_query_duration_seconds_count{operation="query",status="ok",table="items"} 1`)
+	assert.Contains(t, body, `films_db_query_duration_seconds_count{operation="query",status="error",table="missing"} 1`)
+	assert.Contains(t, body, `go_sql_open_connections{db_name="films"}`)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-films-api/internal/domain"
+)
+
+// AuditRepository reads the audit log. Events are written by the film
+// repository, inside the transaction of the change they record.
+type AuditRepository interface {
+	FindAuditEvents(ctx context.Context, filters AuditFilters) ([]domain.AuditEvent, int64, error)
+}
+
+type AuditFilters struct {
+	FilmID  uint
+	ActorID uint
+	Action  domain.AuditAction
+
+	// Range of CreatedAt, inclusive; zero values are ignored
+	From time.Time
+	To   time.Time
+
+	// A zero Limit returns every matching row
+	Limit  int
+	Offset int
+}
+
+type auditRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewAuditRepositoryGorm(db *gorm.DB) AuditRepository {
+	return &auditRepositoryGorm{db: db}
+}
+
+// FindAuditEvents returns the matching events, newest first, with their actor.
+func (r *auditRepositoryGorm) FindAuditEvents(ctx context.Context, filters AuditFilters) ([]domain.AuditEvent, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.AuditEvent{})
+	if filters.FilmID != 0 {
+		query = query.Where("film_id = ?", filters.FilmID)
+	}
+	if filters.ActorID != 0 {
+		query = query.Where("actor_id = ?", filters.ActorID)
+	}
+	if filters.Action != "" {
+		query = query.Where("action = ?", filters.Action)
+	}
+	if !filters.From.IsZero() {
+		query = query.Where("created_at >= ?", filters.From)
+	}
+	if !filters.To.IsZero() {
+		query = query.Where("created_at <= ?", filters.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError("could not count audit events", err)
+	}
+
+	query = query.Order("created_at DESC").Order("id DESC")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit).Offset(filters.Offset)
+	}
+
+	var events []domain.AuditEvent
+	if err := query.Preload("Actor").Find(&events).Error; err != nil {
+		return nil, 0, wrapDBError("could not list audit events", err)
+	}
+	return events, total, nil
+}
+
+// recordAuditEvent stores event as a change of filmID within tx. A nil event
+// records nothing.
+func recordAuditEvent(tx *gorm.DB, filmID uint, event *domain.AuditEvent) error {
+	if event == nil {
+		return nil
+	}
+	event.FilmID = filmID
+	return tx.Omit("Actor").Create(event).Error
+}
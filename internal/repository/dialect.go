@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// Dialector names, as reported by gorm.Dialector.Name
+const (
+	dialectPostgres = "postgres"
+	dialectSQLite   = "sqlite"
+)
+
+// filmSearch holds the SQL that matches films against a search query in one
+// dialect. args turns the query into the arguments of both expressions.
+type filmSearch struct {
+	condition string
+	score     string
+	args      func(query string) []interface{}
+}
+
+// filmSearches maps dialects to their film search; MySQL is the default.
+var filmSearches = map[string]filmSearch{
+	// Full-text expressions backed by the ft_films_search and ft_people_name
+	// indexes. Both take the query twice: once for films, once for people.
+	"": {
+		condition: "(MATCH(title, synopsis) AGAINST (? IN NATURAL LANGUAGE MODE) OR id IN (" +
+			"SELECT fc.film_id FROM film_credits fc JOIN people p ON p.id = fc.person_id " +
+			"WHERE MATCH(p.name) AGAINST (? IN NATURAL LANGUAGE MODE)))",
+		score: "MATCH(title, synopsis) AGAINST (? IN NATURAL LANGUAGE MODE) + COALESCE((" +
+			"SELECT MAX(MATCH(p.name) AGAINST (? IN NATURAL LANGUAGE MODE)) FROM film_credits fc " +
+			"JOIN people p ON p.id = fc.person_id WHERE fc.film_id = films.id), 0)",
+		args: func(query string) []interface{} {
+			return []interface{}{query, query}
+		},
+	},
+	// The same expressions over the GIN indexes of the PostgreSQL schema.
+	// Like MySQL's natural language mode, any word of the query matches.
+	dialectPostgres: {
+		condition: "(to_tsvector('simple', title || ' ' || COALESCE(synopsis, '')) @@ to_tsquery('simple', ?) " +
+			"OR id IN (SELECT fc.film_id FROM film_credits fc JOIN people p ON p.id = fc.person_id " +
+			"WHERE to_tsvector('simple', p.name) @@ to_tsquery('simple', ?)))",
+		score: "ts_rank(to_tsvector('simple', title || ' ' || COALESCE(synopsis, '')), to_tsquery('simple', ?)) + " +
+			"COALESCE((SELECT MAX(ts_rank(to_tsvector('simple', p.name), to_tsquery('simple', ?))) " +
+			"FROM film_credits fc JOIN people p ON p.id = fc.person_id WHERE fc.film_id = films.id), 0)",
+		args: func(query string) []interface{} {
+			tsquery := strings.Join(strings.FieldsFunc(query, func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			}), " | ")
+			return []interface{}{tsquery, tsquery}
+		},
+	},
+	// SQLite has no full-text index here, so the whole query is matched as a
+	// substring. Title matches rank above synopsis and people matches.
+	dialectSQLite: {
+		condition: "(title LIKE ? OR synopsis LIKE ? OR id IN (" +
+			"SELECT fc.film_id FROM film_credits fc JOIN people p ON p.id = fc.person_id WHERE p.name LIKE ?))",
+		score: "(title LIKE ?) * 2 + (COALESCE(synopsis, '') LIKE ?) + EXISTS (" +
+			"SELECT 1 FROM film_credits fc JOIN people p ON p.id = fc.person_id " +
+			"WHERE fc.film_id = films.id AND p.name LIKE ?)",
+		args: func(query string) []interface{} {
+			pattern := "%" + query + "%"
+			return []interface{}{pattern, pattern, pattern}
+		},
+	},
+}
+
+// searchFilms returns the film search of the dialect db speaks.
+func searchFilms(db *gorm.DB) filmSearch {
+	if search, ok := filmSearches[db.Dialector.Name()]; ok {
+		return search
+	}
+	return filmSearches[""]
+}
+
+// like returns the operator that matches a pattern regardless of case.
+// LIKE already does in MySQL and SQLite, but not in PostgreSQL.
+func like(db *gorm.DB) string {
+	if db.Dialector.Name() == dialectPostgres {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+
+	"go-films-api/internal/domain"
+)
+
+// MySQL server errors; all but the first mean the database cannot serve the
+// request right now
+const (
+	mysqlErrDuplicateKey       = 1062
+	mysqlErrTooManyConnections = 1040
+	mysqlErrServerShutdown     = 1053
+)
+
+// PostgreSQL error codes (SQLSTATE); all but the first mean the database
+// cannot serve the request right now
+const (
+	pgErrUniqueViolation    = "23505"
+	pgErrTooManyConnections = "53300"
+	pgErrAdminShutdown      = "57P01"
+	pgErrCannotConnectNow   = "57P03"
+)
+
+// SQLite extended result codes
+const (
+	sqliteErrUniqueViolation = 2067 // SQLITE_CONSTRAINT_UNIQUE
+	sqliteErrPrimaryKey      = 1555 // SQLITE_CONSTRAINT_PRIMARYKEY
+)
+
+// isDuplicateKeyError reports whether err is a unique constraint violation,
+// in whichever dialect the database speaks.
+func isDuplicateKeyError(err error) bool {
+	var mysqlError *mysql.MySQLError
+	if errors.As(err, &mysqlError) {
+		return mysqlError.Number == mysqlErrDuplicateKey
+	}
+	var pgError *pgconn.PgError
+	if errors.As(err, &pgError) {
+		return pgError.Code == pgErrUniqueViolation
+	}
+	var sqliteError *sqlite.Error
+	if errors.As(err, &sqliteError) {
+		return sqliteError.Code() == sqliteErrUniqueViolation || sqliteError.Code() == sqliteErrPrimaryKey
+	}
+	return false
+}
+
+// isConnectionError reports whether err comes from the database being
+// unreachable rather than from the query itself.
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+	var netErr *net.OpError
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	var pgError *pgconn.PgError
+	if errors.As(err, &pgError) {
+		return pgError.Code == pgErrTooManyConnections || pgError.Code == pgErrAdminShutdown ||
+			pgError.Code == pgErrCannotConnectNow
+	}
+	var mysqlError *mysql.MySQLError
+	return errors.As(err, &mysqlError) &&
+		(mysqlError.Number == mysqlErrTooManyConnections || mysqlError.Number == mysqlErrServerShutdown)
+}
+
+// wrapDBError adds context to a database error, marking it as
+// domain.ErrTimeout when the request ran out of time and as
+// domain.ErrUnavailable when it was canceled or the database could not be
+// reached.
+func wrapDBError(message string, err error) error {
+	wrapped := fmt.Errorf("%s: %w", message, err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return domain.Timeout(wrapped)
+	}
+	if errors.Is(err, context.Canceled) || isConnectionError(err) {
+		return domain.Unavailable(wrapped)
+	}
+	return wrapped
+}
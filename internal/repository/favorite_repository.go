@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-films-api/internal/domain"
+)
+
+type FavoriteRepository interface {
+	FindFavoriteFilms(ctx context.Context, filters FavoriteFilters) ([]domain.Film, int64, error)
+	GetFavorite(ctx context.Context, userID, filmID uint) (*domain.Favorite, error)
+	AddFavorite(ctx context.Context, favorite *domain.Favorite) error
+	RemoveFavorite(ctx context.Context, userID, filmID uint) error
+}
+
+type FavoriteFilters struct {
+	UserID uint
+
+	// A zero Limit returns every matching row
+	Limit  int
+	Offset int
+}
+
+type favoriteRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewFavoriteRepositoryGorm(db *gorm.DB) FavoriteRepository {
+	return &favoriteRepositoryGorm{db: db}
+}
+
+// FindFavoriteFilms returns the user's favorite films, most recently added first.
+func (r *favoriteRepositoryGorm) FindFavoriteFilms(ctx context.Context, filters FavoriteFilters) ([]domain.Film, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Film{}).
+		Joins("JOIN favorites fav ON fav.film_id = films.id AND fav.user_id = ?", filters.UserID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError("could not count favorites", err)
+	}
+
+	query = query.Order("fav.created_at DESC").Order("films.id DESC")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit).Offset(filters.Offset)
+	}
+
+	var films []domain.Film
+	if err := preloadFilmRelations(query).Find(&films).Error; err != nil {
+		return nil, 0, wrapDBError("could not list favorites", err)
+	}
+	return films, total, nil
+}
+
+func (r *favoriteRepositoryGorm) GetFavorite(ctx context.Context, userID, filmID uint) (*domain.Favorite, error) {
+	var favorite domain.Favorite
+	err := r.db.WithContext(ctx).Where("user_id = ? AND film_id = ?", userID, filmID).First(&favorite).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("could not get favorite", err)
+	}
+	return &favorite, nil
+}
+
+// AddFavorite is idempotent: adding a film that is already a favorite is a no-op.
+func (r *favoriteRepositoryGorm) AddFavorite(ctx context.Context, favorite *domain.Favorite) error {
+	err := r.db.WithContext(ctx).Omit("Film").Clauses(clause.OnConflict{DoNothing: true}).Create(favorite).Error
+	if err != nil {
+		return wrapDBError("could not add favorite", err)
+	}
+	return nil
+}
+
+func (r *favoriteRepositoryGorm) RemoveFavorite(ctx context.Context, userID, filmID uint) error {
+	err := r.db.WithContext(ctx).Where("user_id = ? AND film_id = ?", userID, filmID).Delete(&domain.Favorite{}).Error
+	if err != nil {
+		return wrapDBError("could not remove favorite", err)
+	}
+	return nil
+}
@@ -1,22 +1,51 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"go-films-api/internal/domain"
 
-	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 )
 
 type FilmRepository interface {
-	FindFilms(filters FilmFilters) ([]domain.Film, error)
-	GetFilmByID(id uint) (*domain.Film, error)
-	CreateFilm(film *domain.Film) error
-	UpdateFilm(film *domain.Film) error
-	DeleteFilmByID(id uint) error
+	FindFilms(ctx context.Context, filters FilmFilters) ([]domain.Film, int64, error)
+	StreamFilms(ctx context.Context, filters FilmFilters, batchSize int, fn func(films []domain.Film) error) error
+	GetFilmByID(ctx context.Context, id uint) (*domain.Film, error)
+
+	// Changes are written together with the audit event describing them,
+	// in one transaction
+	CreateFilm(ctx context.Context, film *domain.Film, event *domain.AuditEvent) error
+	UpdateFilm(ctx context.Context, film *domain.Film, event *domain.AuditEvent) error
+	DeleteFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) error
+
+	FindTrashedFilms(ctx context.Context, filters TrashFilters) ([]domain.Film, int64, error)
+	GetTrashedFilmByID(ctx context.Context, id uint) (*domain.Film, error)
+	RestoreFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) error
+
+	// Purges return the images of the purged films, whose blobs the caller
+	// still has to delete
+	PurgeFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) ([]domain.FilmImage, error)
+	PurgeFilmsDeletedBefore(ctx context.Context, cutoff time.Time) (int64, []domain.FilmImage, error)
+
+	// Transaction runs fn with a repository bound to a single transaction,
+	// committed if fn returns nil and rolled back otherwise. Writes inside it
+	// use savepoints, so a failed write does not abort the transaction.
+	Transaction(ctx context.Context, fn func(repo FilmRepository) error) error
+}
+
+type TrashFilters struct {
+	// Only films created by this user; zero lists the whole trash
+	UserID uint
+
+	// A zero Limit returns every matching row
+	Limit  int
+	Offset int
 }
 
 type filmRepositoryGorm struct {
@@ -28,69 +57,522 @@ func NewFilmRepositoryGorm(db *gorm.DB) FilmRepository {
 }
 
 type FilmFilters struct {
+	// Query is a full-text search over title, synopsis and the names of
+	// credited people. Results carry a relevance Score and are ranked by it
+	// unless SortBy is set.
+	Query       string
 	Title       string
-	Genre       string
+	Director    string
+	Genres      []string // genre slugs, a film matches if it has any of them
 	ReleaseDate time.Time
+
+	// Films crediting a person, optionally only in the given role
+	PersonID   uint
+	CreditRole domain.CreditRole
+
+	// Ranges are inclusive; zero values are ignored
+	ReleaseDateFrom time.Time
+	ReleaseDateTo   time.Time
+	Year            int
+	CreatedBy       uint
+	CreatedAfter    time.Time
+
+	// Pagination and ordering. A zero Limit returns every matching row.
+	// When After is set the page starts right after that row (keyset
+	// pagination) and Offset is ignored.
+	Limit    int
+	Offset   int
+	SortBy   string
+	SortDesc bool
+	After    *FilmKeyset
+}
+
+// FilmKeyset identifies the last row of a previous page by its sort value and ID.
+// SortValue holds an RFC 3339 timestamp for date columns and a decimal
+// number for the review aggregates; it is empty when the value is NULL.
+type FilmKeyset struct {
+	SortValue string
+	ID        uint
+}
+
+// filmSortColumns maps the sort keys accepted by FindFilms to their SQL expressions.
+var filmSortColumns = map[string]string{
+	"title":          "title",
+	"release_date":   "release_date",
+	"created_at":     "created_at",
+	"average_rating": "average_rating",
+	"review_count":   "review_count",
+	"director": "COALESCE((SELECT MIN(p.name) FROM film_credits fc JOIN people p ON p.id = fc.person_id " +
+		"WHERE fc.film_id = films.id AND fc.role = 'director'), '')",
+}
+
+// filmComputedColumns are the sort keys whose columns are not stored on
+// films. They are selected as sort_key, so that cursors hold the value the
+// database sorted by, collation included.
+var filmComputedColumns = map[string]bool{
+	"director": true,
+}
+
+// filmTimeColumns are the sort keys whose keyset values are timestamps.
+var filmTimeColumns = map[string]bool{
+	"release_date": true,
+	"created_at":   true,
+}
+
+// filmNullableColumns are the sort keys whose columns may be NULL. On every
+// driver NULLs sort after all values in ascending order and before them in
+// descending order.
+var filmNullableColumns = map[string]bool{
+	"release_date": true,
+}
+
+// filmNumericColumns are the sort keys whose keyset values are numbers.
+var filmNumericColumns = map[string]bool{
+	"average_rating": true,
+	"review_count":   true,
+}
+
+// IsValidFilmSort reports whether key can be used as FilmFilters.SortBy.
+func IsValidFilmSort(key string) bool {
+	_, ok := filmSortColumns[key]
+	return ok
+}
+
+func (r *filmRepositoryGorm) FindFilms(ctx context.Context, filters FilmFilters) ([]domain.Film, int64, error) {
+	query := r.filterFilms(ctx, filters)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError("could not count films", err)
+	}
+
+	query, err := orderFilms(query, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var films []domain.Film
+	if err := preloadFilmRelations(query).Find(&films).Error; err != nil {
+		return nil, 0, wrapDBError("could not list films", err)
+	}
+	return films, total, nil
 }
 
-func (r *filmRepositoryGorm) FindFilms(filters FilmFilters) ([]domain.Film, error) {
-	query := r.db.Model(&domain.Film{})
+// StreamFilms passes every film matching filters to fn, in batches of up to
+// batchSize films with their relations loaded. Rows are read through a
+// database cursor, so the whole result is never held in memory. An error
+// from fn stops the stream and is returned as is.
+func (r *filmRepositoryGorm) StreamFilms(ctx context.Context, filters FilmFilters, batchSize int, fn func(films []domain.Film) error) error {
+	query, err := orderFilms(r.filterFilms(ctx, filters), filters)
+	if err != nil {
+		return err
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return wrapDBError("could not stream films", err)
+	}
+	defer rows.Close()
 
+	batch := make([]domain.Film, 0, batchSize)
+	flush := func() error {
+		if err := r.loadFilmRelations(ctx, batch); err != nil {
+			return err
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		var film domain.Film
+		if err := r.db.ScanRows(rows, &film); err != nil {
+			return wrapDBError("could not stream films", err)
+		}
+		batch = append(batch, film)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return wrapDBError("could not stream films", err)
+	}
+	if len(batch) > 0 {
+		return flush()
+	}
+	return nil
+}
+
+// loadFilmRelations loads the relations preloadFilmRelations would for
+// films that were scanned from a cursor.
+func (r *filmRepositoryGorm) loadFilmRelations(ctx context.Context, films []domain.Film) error {
+	if len(films) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(films))
+	for i, film := range films {
+		ids[i] = film.ID
+	}
+
+	var loaded []domain.Film
+	if err := preloadFilmRelations(r.db.WithContext(ctx).Select("id")).Where("id IN ?", ids).Find(&loaded).Error; err != nil {
+		return wrapDBError("could not load film relations", err)
+	}
+	byID := make(map[uint]domain.Film, len(loaded))
+	for _, film := range loaded {
+		byID[film.ID] = film
+	}
+	for i := range films {
+		films[i].Genres = byID[films[i].ID].Genres
+		films[i].Credits = byID[films[i].ID].Credits
+		films[i].Images = byID[films[i].ID].Images
+	}
+	return nil
+}
+
+// filterFilms builds the query selecting the films that match filters.
+func (r *filmRepositoryGorm) filterFilms(ctx context.Context, filters FilmFilters) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&domain.Film{})
+
+	if filters.Query != "" {
+		search := searchFilms(r.db)
+		query = query.Where(search.condition, search.args(filters.Query)...)
+	}
 	if filters.Title != "" {
-		query = query.Where("title LIKE ?", "%"+filters.Title+"%")
+		query = query.Where("title "+like(r.db)+" ?", "%"+filters.Title+"%")
+	}
+	if filters.Director != "" {
+		query = query.Where(
+			"id IN (SELECT fc.film_id FROM film_credits fc JOIN people p ON p.id = fc.person_id "+
+				"WHERE fc.role = ? AND p.name "+like(r.db)+" ?)",
+			domain.CreditRoleDirector, "%"+filters.Director+"%",
+		)
+	}
+	if filters.PersonID != 0 {
+		if filters.CreditRole != "" {
+			query = query.Where("id IN (SELECT film_id FROM film_credits WHERE person_id = ? AND role = ?)",
+				filters.PersonID, filters.CreditRole)
+		} else {
+			query = query.Where("id IN (SELECT film_id FROM film_credits WHERE person_id = ?)", filters.PersonID)
+		}
 	}
-	if filters.Genre != "" {
-		query = query.Where("genre = ?", filters.Genre)
+	if len(filters.Genres) > 0 {
+		query = query.Where(
+			"id IN (SELECT fg.film_id FROM film_genres fg JOIN genres g ON g.id = fg.genre_id WHERE g.slug IN ?)",
+			filters.Genres,
+		)
 	}
 
 	if !filters.ReleaseDate.IsZero() {
 		query = query.Where("release_date = ?", filters.ReleaseDate)
 	}
+	if !filters.ReleaseDateFrom.IsZero() {
+		query = query.Where("release_date >= ?", filters.ReleaseDateFrom)
+	}
+	if !filters.ReleaseDateTo.IsZero() {
+		query = query.Where("release_date <= ?", filters.ReleaseDateTo)
+	}
+	if filters.Year != 0 {
+		// A range instead of YEAR(release_date) keeps the condition sargable
+		start := time.Date(filters.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		query = query.Where("release_date >= ? AND release_date < ?", start, start.AddDate(1, 0, 0))
+	}
+	if filters.CreatedBy != 0 {
+		query = query.Where("user_id = ?", filters.CreatedBy)
+	}
+	if !filters.CreatedAfter.IsZero() {
+		query = query.Where("created_at > ?", filters.CreatedAfter)
+	}
+	return query
+}
 
-	var films []domain.Film
-	if err := query.Find(&films).Error; err != nil {
-		return nil, err
+// orderFilms applies the sort order, keyset and limit of filters to query.
+func orderFilms(query *gorm.DB, filters FilmFilters) (*gorm.DB, error) {
+	direction, cmp := "ASC", ">"
+	if filters.SortDesc {
+		direction, cmp = "DESC", "<"
+	}
+	column, sorted := filmSortColumns[filters.SortBy]
+
+	if filters.After != nil {
+		nullable := filmNullableColumns[filters.SortBy]
+		if sorted && nullable && filters.After.SortValue == "" {
+			// The page ended on a NULL: only NULLs follow in ascending order,
+			// and every value follows the remaining NULLs in descending order
+			if filters.SortDesc {
+				query = query.Where("("+column+" IS NOT NULL OR id < ?)", filters.After.ID)
+			} else {
+				query = query.Where("("+column+" IS NULL AND id > ?)", filters.After.ID)
+			}
+		} else if sorted {
+			var value interface{} = filters.After.SortValue
+			if filmTimeColumns[filters.SortBy] {
+				t, err := time.Parse(time.RFC3339Nano, filters.After.SortValue)
+				if err != nil {
+					return nil, fmt.Errorf("invalid keyset value: %w", err)
+				}
+				value = t
+			} else if filmNumericColumns[filters.SortBy] {
+				n, err := strconv.ParseFloat(filters.After.SortValue, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid keyset value: %w", err)
+				}
+				value = n
+			}
+			condition := "(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))"
+			if nullable && !filters.SortDesc {
+				condition = "(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?) OR %[1]s IS NULL)"
+			}
+			query = query.Where(fmt.Sprintf(condition, column, cmp), value, value, filters.After.ID)
+		} else {
+			query = query.Where("id "+cmp+" ?", filters.After.ID)
+		}
+	}
+
+	selects, args := []string{"films.*"}, []interface{}(nil)
+	if filters.Query != "" {
+		search := searchFilms(query)
+		selects = append(selects, search.score+" AS score")
+		args = search.args(filters.Query)
+	}
+	if filmComputedColumns[filters.SortBy] {
+		selects = append(selects, column+" AS sort_key")
 	}
-	return films, nil
+	if len(selects) > 1 {
+		query = query.Select(strings.Join(selects, ", "), args...)
+	}
+
+	if sorted {
+		if filmNullableColumns[filters.SortBy] {
+			query = query.Order("CASE WHEN " + column + " IS NULL THEN 1 ELSE 0 END " + direction)
+		}
+		query = query.Order(column + " " + direction)
+	} else if filters.Query != "" {
+		query = query.Order("score DESC")
+	}
+	// Always order by id last so pages are stable between requests
+	query = query.Order("id " + direction)
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+		if filters.After == nil {
+			query = query.Offset(filters.Offset)
+		}
+	}
+	return query, nil
 }
 
-func (r *filmRepositoryGorm) GetFilmByID(id uint) (*domain.Film, error) {
+func (r *filmRepositoryGorm) GetFilmByID(ctx context.Context, id uint) (*domain.Film, error) {
 	var film domain.Film
-	err := r.db.Preload("User").First(&film, id).Error
+	err := preloadFilmRelations(r.db.WithContext(ctx).Preload("User")).First(&film, id).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	} else if err != nil {
-		return nil, fmt.Errorf("could not get film: %w", err)
+		return nil, wrapDBError("could not get film", err)
 	}
 	return &film, nil
 }
 
-func isDuplicateKeyError(err error) bool {
-	var mysqlError *mysql.MySQLError
-	return errors.As(err, &mysqlError) && mysqlError.Number == 1062
+// preloadFilmRelations loads the genres and the credits, in billing order, of the queried films.
+func preloadFilmRelations(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Genres").
+		Preload("Credits", func(db *gorm.DB) *gorm.DB {
+			return db.Order("billing_order, id")
+		}).
+		Preload("Credits.Person").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		})
 }
 
-func (r *filmRepositoryGorm) CreateFilm(film *domain.Film) error {
-	if err := r.db.Create(film).Error; err != nil {
+func (r *filmRepositoryGorm) CreateFilm(ctx context.Context, film *domain.Film, event *domain.AuditEvent) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(film).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(tx, film.ID, event)
+	})
+	if err != nil {
 		// Check if it's a duplicate key error on Title
 		if isDuplicateKeyError(err) {
-			return fmt.Errorf("film with title '%s' already exists", film.Title)
+			return domain.Conflict("film_title_taken", fmt.Sprintf("film with title '%s' already exists", film.Title))
 		}
+		return wrapDBError("could not create film", err)
+	}
+	return nil
+}
+
+// UpdateFilm saves the film only if its stored version still equals
+// film.Version, then increments the version. A film changed in the meantime
+// fails with domain.ErrFilmModified.
+func (r *filmRepositoryGorm) UpdateFilm(ctx context.Context, film *domain.Film, event *domain.AuditEvent) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(film).Where("version = ?", film.Version).Updates(map[string]interface{}{
+			"title":        film.Title,
+			"release_date": film.ReleaseDate,
+			"synopsis":     film.Synopsis,
+			"version":      gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrFilmModified
+		}
+
+		if err := tx.Model(film).Association("Genres").Replace(film.Genres); err != nil {
+			return err
+		}
+
+		// Credits belong to the film, so replacing them means deleting the old rows
+		if err := tx.Where("film_id = ?", film.ID).Delete(&domain.FilmCredit{}).Error; err != nil {
+			return err
+		}
+		for i := range film.Credits {
+			film.Credits[i].ID = 0
+			film.Credits[i].FilmID = film.ID
+		}
+		if len(film.Credits) > 0 {
+			if err := tx.Create(&film.Credits).Error; err != nil {
+				return err
+			}
+		}
+		return recordAuditEvent(tx, film.ID, event)
+	})
+	if errors.Is(err, domain.ErrFilmModified) {
 		return err
 	}
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.Conflict("film_title_taken", fmt.Sprintf("film with title '%s' already exists", film.Title))
+		}
+		return wrapDBError("could not update film", err)
+	}
+	film.Version++
 	return nil
 }
 
-func (r *filmRepositoryGorm) UpdateFilm(film *domain.Film) error {
-	if err := r.db.Save(film).Error; err != nil {
-		return fmt.Errorf("could not update film: %w", err)
+// DeleteFilmByID moves a film to the trash. Its genres, credits, reviews
+// and list entries are kept so that restoring it brings them back.
+func (r *filmRepositoryGorm) DeleteFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.Film{}, id).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(tx, id, event)
+	})
+	if err != nil {
+		return wrapDBError("could not delete film", err)
 	}
 	return nil
 }
 
-func (r *filmRepositoryGorm) DeleteFilmByID(id uint) error {
-	if err := r.db.Delete(&domain.Film{}, id).Error; err != nil {
-		return fmt.Errorf("could not delete film: %w", err)
+func (r *filmRepositoryGorm) Transaction(ctx context.Context, fn func(repo FilmRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&filmRepositoryGorm{db: tx})
+	})
+}
+
+// trashed scopes a query on db to films in the trash.
+func trashed(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Model(&domain.Film{}).Where("films.deleted_at IS NOT NULL")
+}
+
+// FindTrashedFilms returns trashed films, most recently deleted first.
+func (r *filmRepositoryGorm) FindTrashedFilms(ctx context.Context, filters TrashFilters) ([]domain.Film, int64, error) {
+	query := trashed(r.db.WithContext(ctx))
+	if filters.UserID != 0 {
+		query = query.Where("user_id = ?", filters.UserID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError("could not count trashed films", err)
+	}
+
+	query = query.Order("deleted_at DESC").Order("id DESC")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit).Offset(filters.Offset)
+	}
+
+	var films []domain.Film
+	if err := preloadFilmRelations(query).Find(&films).Error; err != nil {
+		return nil, 0, wrapDBError("could not list trashed films", err)
+	}
+	return films, total, nil
+}
+
+func (r *filmRepositoryGorm) GetTrashedFilmByID(ctx context.Context, id uint) (*domain.Film, error) {
+	var film domain.Film
+	err := preloadFilmRelations(trashed(r.db.WithContext(ctx)).Preload("User")).First(&film, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("could not get trashed film", err)
+	}
+	return &film, nil
+}
+
+func (r *filmRepositoryGorm) RestoreFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := trashed(tx).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(tx, id, event)
+	})
+	if err != nil {
+		return wrapDBError("could not restore film", err)
 	}
 	return nil
 }
+
+// PurgeFilmByID permanently deletes a trashed film. The foreign keys cascade
+// the delete to everything that references it, except its audit events.
+func (r *filmRepositoryGorm) PurgeFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) ([]domain.FilmImage, error) {
+	var images []domain.FilmImage
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("film_id = ?", id).Find(&images).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").Delete(&domain.Film{}, id).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(tx, id, event)
+	})
+	if err != nil {
+		return nil, wrapDBError("could not purge film", err)
+	}
+	return images, nil
+}
+
+// PurgeFilmsDeletedBefore permanently deletes the films trashed before
+// cutoff and returns how many there were. Each purge is audited without an
+// actor.
+func (r *filmRepositoryGorm) PurgeFilmsDeletedBefore(ctx context.Context, cutoff time.Time) (int64, []domain.FilmImage, error) {
+	var purged int64
+	var images []domain.FilmImage
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("film_id IN (?)", tx.Unscoped().Model(&domain.Film{}).Select("id").Where("deleted_at < ?", cutoff)).
+			Find(&images).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec("INSERT INTO audit_events (film_id, action, created_at) "+
+			"SELECT id, ?, ? FROM films WHERE deleted_at < ?",
+			domain.AuditActionPurge, tx.NowFunc(), cutoff).Error
+		if err != nil {
+			return err
+		}
+		result := tx.Unscoped().Where("deleted_at < ?", cutoff).Delete(&domain.Film{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, nil, wrapDBError("could not purge trashed films", err)
+	}
+	return purged, images, nil
+}
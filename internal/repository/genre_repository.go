@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"go-films-api/internal/domain"
+)
+
+type GenreRepository interface {
+	FindGenres(ctx context.Context) ([]domain.Genre, error)
+	FindGenresBySlugs(ctx context.Context, slugs []string) ([]domain.Genre, error)
+	GetGenreBySlug(ctx context.Context, slug string) (*domain.Genre, error)
+	CreateGenre(ctx context.Context, genre *domain.Genre) error
+	UpdateGenre(ctx context.Context, genre *domain.Genre) error
+	DeleteGenreByID(ctx context.Context, id uint) error
+}
+
+type genreRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewGenreRepositoryGorm(db *gorm.DB) GenreRepository {
+	return &genreRepositoryGorm{db: db}
+}
+
+func (r *genreRepositoryGorm) FindGenres(ctx context.Context) ([]domain.Genre, error) {
+	var genres []domain.Genre
+	if err := r.db.WithContext(ctx).Order("name").Find(&genres).Error; err != nil {
+		return nil, wrapDBError("could not list genres", err)
+	}
+	return genres, nil
+}
+
+func (r *genreRepositoryGorm) FindGenresBySlugs(ctx context.Context, slugs []string) ([]domain.Genre, error) {
+	var genres []domain.Genre
+	if len(slugs) == 0 {
+		return genres, nil
+	}
+	if err := r.db.WithContext(ctx).Where("slug IN ?", slugs).Find(&genres).Error; err != nil {
+		return nil, wrapDBError("could not find genres", err)
+	}
+	return genres, nil
+}
+
+func (r *genreRepositoryGorm) GetGenreBySlug(ctx context.Context, slug string) (*domain.Genre, error) {
+	var genre domain.Genre
+	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&genre).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("could not get genre", err)
+	}
+	return &genre, nil
+}
+
+func (r *genreRepositoryGorm) CreateGenre(ctx context.Context, genre *domain.Genre) error {
+	if err := r.db.WithContext(ctx).Create(genre).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.Conflict("genre_exists", fmt.Sprintf("genre '%s' already exists", genre.Name))
+		}
+		return wrapDBError("could not create genre", err)
+	}
+	return nil
+}
+
+func (r *genreRepositoryGorm) UpdateGenre(ctx context.Context, genre *domain.Genre) error {
+	if err := r.db.WithContext(ctx).Save(genre).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.Conflict("genre_exists", fmt.Sprintf("genre '%s' already exists", genre.Name))
+		}
+		return wrapDBError("could not update genre", err)
+	}
+	return nil
+}
+
+func (r *genreRepositoryGorm) DeleteGenreByID(ctx context.Context, id uint) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.Genre{}, id).Error; err != nil {
+		return wrapDBError("could not delete genre", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"go-films-api/internal/domain"
+)
+
+type ImageRepository interface {
+	GetFilmImage(ctx context.Context, filmID, imageID uint) (*domain.FilmImage, error)
+	CreateFilmImage(ctx context.Context, image *domain.FilmImage) error
+	DeleteFilmImageByID(ctx context.Context, id uint) error
+}
+
+type imageRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewImageRepositoryGorm(db *gorm.DB) ImageRepository {
+	return &imageRepositoryGorm{db: db}
+}
+
+// GetFilmImage returns nil when the film has no image with the given ID.
+func (r *imageRepositoryGorm) GetFilmImage(ctx context.Context, filmID, imageID uint) (*domain.FilmImage, error) {
+	var image domain.FilmImage
+	err := r.db.WithContext(ctx).Where("film_id = ?", filmID).First(&image, imageID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, wrapDBError("could not get film image", err)
+	}
+	return &image, nil
+}
+
+func (r *imageRepositoryGorm) CreateFilmImage(ctx context.Context, image *domain.FilmImage) error {
+	if err := r.db.WithContext(ctx).Create(image).Error; err != nil {
+		return wrapDBError("could not create film image", err)
+	}
+	return nil
+}
+
+func (r *imageRepositoryGorm) DeleteFilmImageByID(ctx context.Context, id uint) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.FilmImage{}, id).Error; err != nil {
+		return wrapDBError("could not delete film image", err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) FindAuditEvents(ctx context.Context, filters AuditFilters) ([]domain.AuditEvent, int64, error) {
+	args := m.Called(ctx, filters)
+	if events, ok := args.Get(0).([]domain.AuditEvent); ok {
+		return events, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+)
+
+type MockFavoriteRepository struct {
+	mock.Mock
+}
+
+func (m *MockFavoriteRepository) FindFavoriteFilms(ctx context.Context, filters FavoriteFilters) ([]domain.Film, int64, error) {
+	args := m.Called(ctx, filters)
+	if films, ok := args.Get(0).([]domain.Film); ok {
+		return films, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockFavoriteRepository) GetFavorite(ctx context.Context, userID, filmID uint) (*domain.Favorite, error) {
+	args := m.Called(ctx, userID, filmID)
+	if favorite, ok := args.Get(0).(*domain.Favorite); ok {
+		return favorite, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFavoriteRepository) AddFavorite(ctx context.Context, favorite *domain.Favorite) error {
+	args := m.Called(ctx, favorite)
+	return args.Error(0)
+}
+
+func (m *MockFavoriteRepository) RemoveFavorite(ctx context.Context, userID, filmID uint) error {
+	args := m.Called(ctx, userID, filmID)
+	return args.Error(0)
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"go-films-api/internal/domain"
@@ -10,33 +13,89 @@ type MockFilmRepository struct {
 	mock.Mock
 }
 
-func (m *MockFilmRepository) FindFilms(filters FilmFilters) ([]domain.Film, error) {
-	args := m.Called(filters)
+func (m *MockFilmRepository) FindFilms(ctx context.Context, filters FilmFilters) ([]domain.Film, int64, error) {
+	args := m.Called(ctx, filters)
 	if films, ok := args.Get(0).([]domain.Film); ok {
-		return films, args.Error(1)
+		return films, args.Get(1).(int64), args.Error(2)
 	}
-	return nil, args.Error(1)
+	return nil, 0, args.Error(2)
 }
 
-func (m *MockFilmRepository) GetFilmByID(id uint) (*domain.Film, error) {
-	args := m.Called(id)
+// StreamFilms passes the films the expectation returns to fn in batches.
+func (m *MockFilmRepository) StreamFilms(ctx context.Context, filters FilmFilters, batchSize int, fn func(films []domain.Film) error) error {
+	args := m.Called(ctx, filters, batchSize)
+	films, _ := args.Get(0).([]domain.Film)
+	for len(films) > 0 {
+		n := min(batchSize, len(films))
+		if err := fn(films[:n]); err != nil {
+			return err
+		}
+		films = films[n:]
+	}
+	return args.Error(1)
+}
+
+func (m *MockFilmRepository) GetFilmByID(ctx context.Context, id uint) (*domain.Film, error) {
+	args := m.Called(ctx, id)
 	if film, ok := args.Get(0).(*domain.Film); ok {
 		return film, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockFilmRepository) CreateFilm(film *domain.Film) error {
-	args := m.Called(film)
+func (m *MockFilmRepository) CreateFilm(ctx context.Context, film *domain.Film, event *domain.AuditEvent) error {
+	args := m.Called(ctx, film, event)
 	return args.Error(0)
 }
 
-func (m *MockFilmRepository) UpdateFilm(film *domain.Film) error {
-	args := m.Called(film)
+func (m *MockFilmRepository) UpdateFilm(ctx context.Context, film *domain.Film, event *domain.AuditEvent) error {
+	args := m.Called(ctx, film, event)
 	return args.Error(0)
 }
 
-func (m *MockFilmRepository) DeleteFilmByID(id uint) error {
-	args := m.Called(id)
+func (m *MockFilmRepository) DeleteFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) error {
+	args := m.Called(ctx, id, event)
 	return args.Error(0)
 }
+
+func (m *MockFilmRepository) FindTrashedFilms(ctx context.Context, filters TrashFilters) ([]domain.Film, int64, error) {
+	args := m.Called(ctx, filters)
+	if films, ok := args.Get(0).([]domain.Film); ok {
+		return films, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockFilmRepository) GetTrashedFilmByID(ctx context.Context, id uint) (*domain.Film, error) {
+	args := m.Called(ctx, id)
+	if film, ok := args.Get(0).(*domain.Film); ok {
+		return film, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockFilmRepository) RestoreFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) error {
+	args := m.Called(ctx, id, event)
+	return args.Error(0)
+}
+
+func (m *MockFilmRepository) PurgeFilmByID(ctx context.Context, id uint, event *domain.AuditEvent) ([]domain.FilmImage, error) {
+	args := m.Called(ctx, id, event)
+	images, _ := args.Get(0).([]domain.FilmImage)
+	return images, args.Error(1)
+}
+
+func (m *MockFilmRepository) PurgeFilmsDeletedBefore(ctx context.Context, cutoff time.Time) (int64, []domain.FilmImage, error) {
+	args := m.Called(ctx, cutoff)
+	images, _ := args.Get(1).([]domain.FilmImage)
+	return args.Get(0).(int64), images, args.Error(2)
+}
+
+// Transaction runs fn against the mock itself, so expectations set on the
+// mock also cover the writes made inside the transaction.
+func (m *MockFilmRepository) Transaction(ctx context.Context, fn func(repo FilmRepository) error) error {
+	if err := m.Called(ctx).Error(0); err != nil {
+		return err
+	}
+	return fn(m)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+)
+
+type MockGenreRepository struct {
+	mock.Mock
+}
+
+func (m *MockGenreRepository) FindGenres(ctx context.Context) ([]domain.Genre, error) {
+	args := m.Called(ctx)
+	if genres, ok := args.Get(0).([]domain.Genre); ok {
+		return genres, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockGenreRepository) FindGenresBySlugs(ctx context.Context, slugs []string) ([]domain.Genre, error) {
+	args := m.Called(ctx, slugs)
+	if genres, ok := args.Get(0).([]domain.Genre); ok {
+		return genres, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockGenreRepository) GetGenreBySlug(ctx context.Context, slug string) (*domain.Genre, error) {
+	args := m.Called(ctx, slug)
+	if genre, ok := args.Get(0).(*domain.Genre); ok {
+		return genre, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockGenreRepository) CreateGenre(ctx context.Context, genre *domain.Genre) error {
+	args := m.Called(ctx, genre)
+	return args.Error(0)
+}
+
+func (m *MockGenreRepository) UpdateGenre(ctx context.Context, genre *domain.Genre) error {
+	args := m.Called(ctx, genre)
+	return args.Error(0)
+}
+
+func (m *MockGenreRepository) DeleteGenreByID(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+)
+
+type MockImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockImageRepository) GetFilmImage(ctx context.Context, filmID, imageID uint) (*domain.FilmImage, error) {
+	args := m.Called(ctx, filmID, imageID)
+	if image, ok := args.Get(0).(*domain.FilmImage); ok {
+		return image, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockImageRepository) CreateFilmImage(ctx context.Context, image *domain.FilmImage) error {
+	args := m.Called(ctx, image)
+	return args.Error(0)
+}
+
+func (m *MockImageRepository) DeleteFilmImageByID(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+)
+
+type MockPersonRepository struct {
+	mock.Mock
+}
+
+func (m *MockPersonRepository) FindPeople(ctx context.Context, filters PersonFilters) ([]domain.Person, int64, error) {
+	args := m.Called(ctx, filters)
+	if people, ok := args.Get(0).([]domain.Person); ok {
+		return people, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockPersonRepository) FindPeopleByIDs(ctx context.Context, ids []uint) ([]domain.Person, error) {
+	args := m.Called(ctx, ids)
+	if people, ok := args.Get(0).([]domain.Person); ok {
+		return people, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPersonRepository) GetPersonByID(ctx context.Context, id uint) (*domain.Person, error) {
+	args := m.Called(ctx, id)
+	if person, ok := args.Get(0).(*domain.Person); ok {
+		return person, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPersonRepository) CreatePerson(ctx context.Context, person *domain.Person) error {
+	args := m.Called(ctx, person)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) UpdatePerson(ctx context.Context, person *domain.Person) error {
+	args := m.Called(ctx, person)
+	return args.Error(0)
+}
+
+func (m *MockPersonRepository) DeletePersonByID(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
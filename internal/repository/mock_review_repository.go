@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+)
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewRepository) FindReviews(ctx context.Context, filters ReviewFilters) ([]domain.Review, int64, error) {
+	args := m.Called(ctx, filters)
+	if reviews, ok := args.Get(0).([]domain.Review); ok {
+		return reviews, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockReviewRepository) GetReviewByID(ctx context.Context, id uint) (*domain.Review, error) {
+	args := m.Called(ctx, id)
+	if review, ok := args.Get(0).(*domain.Review); ok {
+		return review, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockReviewRepository) CreateReview(ctx context.Context, review *domain.Review) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockReviewRepository) UpdateReview(ctx context.Context, review *domain.Review) error {
+	args := m.Called(ctx, review)
+	return args.Error(0)
+}
+
+func (m *MockReviewRepository) DeleteReviewByID(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
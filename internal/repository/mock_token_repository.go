@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+)
+
+type MockTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRepository) CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	args := m.Called(ctx, hash)
+	if token, ok := args.Get(0).(*domain.RefreshToken); ok {
+		return token, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTokenRepository) RotateRefreshToken(ctx context.Context, current *domain.RefreshToken, next *domain.RefreshToken) (bool, error) {
+	args := m.Called(ctx, current, next)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) RevokeUserTokens(ctx context.Context, userID uint) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	args := m.Called(ctx, jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) DeleteExpiredTokens(ctx context.Context, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 
 	"go-films-api/internal/domain"
@@ -10,15 +11,36 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) CreateUser(user *domain.User) error {
-	args := m.Called(user)
+func (m *MockUserRepository) CreateUser(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetUserByUsername(username string) (*domain.User, error) {
-	args := m.Called(username)
+func (m *MockUserRepository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	args := m.Called(ctx, username)
 	if user, ok := args.Get(0).(*domain.User); ok {
 		return user, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
+
+func (m *MockUserRepository) FindUsers(ctx context.Context, filters UserFilters) ([]domain.User, int64, error) {
+	args := m.Called(ctx, filters)
+	if users, ok := args.Get(0).([]domain.User); ok {
+		return users, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id uint) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if user, ok := args.Get(0).(*domain.User); ok {
+		return user, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserRole(ctx context.Context, id uint, role domain.Role) error {
+	args := m.Called(ctx, id, role)
+	return args.Error(0)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+)
+
+type MockWatchlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchlistRepository) FindWatchlistsByUser(ctx context.Context, userID uint) ([]domain.Watchlist, error) {
+	args := m.Called(ctx, userID)
+	if watchlists, ok := args.Get(0).([]domain.Watchlist); ok {
+		return watchlists, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWatchlistRepository) GetWatchlistByID(ctx context.Context, id uint) (*domain.Watchlist, error) {
+	args := m.Called(ctx, id)
+	if watchlist, ok := args.Get(0).(*domain.Watchlist); ok {
+		return watchlist, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWatchlistRepository) CreateWatchlist(ctx context.Context, watchlist *domain.Watchlist) error {
+	args := m.Called(ctx, watchlist)
+	return args.Error(0)
+}
+
+func (m *MockWatchlistRepository) UpdateWatchlist(ctx context.Context, watchlist *domain.Watchlist) error {
+	args := m.Called(ctx, watchlist)
+	return args.Error(0)
+}
+
+// UpdateWatchlistItems runs update on the watchlist the expectation returns.
+func (m *MockWatchlistRepository) UpdateWatchlistItems(ctx context.Context, id uint, update func(watchlist *domain.Watchlist) error) (*domain.Watchlist, error) {
+	args := m.Called(ctx, id)
+	watchlist, ok := args.Get(0).(*domain.Watchlist)
+	if !ok || args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	if err := update(watchlist); err != nil {
+		return nil, err
+	}
+	return watchlist, nil
+}
+
+func (m *MockWatchlistRepository) DeleteWatchlistByID(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"go-films-api/internal/domain"
+)
+
+type PersonRepository interface {
+	FindPeople(ctx context.Context, filters PersonFilters) ([]domain.Person, int64, error)
+	FindPeopleByIDs(ctx context.Context, ids []uint) ([]domain.Person, error)
+	GetPersonByID(ctx context.Context, id uint) (*domain.Person, error)
+	CreatePerson(ctx context.Context, person *domain.Person) error
+	UpdatePerson(ctx context.Context, person *domain.Person) error
+	DeletePersonByID(ctx context.Context, id uint) error
+}
+
+type personRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewPersonRepositoryGorm(db *gorm.DB) PersonRepository {
+	return &personRepositoryGorm{db: db}
+}
+
+type PersonFilters struct {
+	Name   string
+	Limit  int
+	Offset int
+}
+
+func (r *personRepositoryGorm) FindPeople(ctx context.Context, filters PersonFilters) ([]domain.Person, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Person{})
+	if filters.Name != "" {
+		query = query.Where("name "+like(r.db)+" ?", "%"+filters.Name+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError("could not count people", err)
+	}
+
+	query = query.Order("name, id")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit).Offset(filters.Offset)
+	}
+
+	var people []domain.Person
+	if err := query.Find(&people).Error; err != nil {
+		return nil, 0, wrapDBError("could not list people", err)
+	}
+	return people, total, nil
+}
+
+func (r *personRepositoryGorm) FindPeopleByIDs(ctx context.Context, ids []uint) ([]domain.Person, error) {
+	var people []domain.Person
+	if len(ids) == 0 {
+		return people, nil
+	}
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&people).Error; err != nil {
+		return nil, wrapDBError("could not find people", err)
+	}
+	return people, nil
+}
+
+func (r *personRepositoryGorm) GetPersonByID(ctx context.Context, id uint) (*domain.Person, error) {
+	var person domain.Person
+	err := r.db.WithContext(ctx).First(&person, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("could not get person", err)
+	}
+	return &person, nil
+}
+
+func (r *personRepositoryGorm) CreatePerson(ctx context.Context, person *domain.Person) error {
+	if err := r.db.WithContext(ctx).Create(person).Error; err != nil {
+		return wrapDBError("could not create person", err)
+	}
+	return nil
+}
+
+func (r *personRepositoryGorm) UpdatePerson(ctx context.Context, person *domain.Person) error {
+	if err := r.db.WithContext(ctx).Save(person).Error; err != nil {
+		return wrapDBError("could not update person", err)
+	}
+	return nil
+}
+
+func (r *personRepositoryGorm) DeletePersonByID(ctx context.Context, id uint) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.Person{}, id).Error; err != nil {
+		return wrapDBError("could not delete person", err)
+	}
+	return nil
+}
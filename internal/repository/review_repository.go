@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"go-films-api/internal/domain"
+)
+
+type ReviewRepository interface {
+	FindReviews(ctx context.Context, filters ReviewFilters) ([]domain.Review, int64, error)
+	GetReviewByID(ctx context.Context, id uint) (*domain.Review, error)
+	CreateReview(ctx context.Context, review *domain.Review) error
+	UpdateReview(ctx context.Context, review *domain.Review) error
+	DeleteReviewByID(ctx context.Context, id uint) error
+}
+
+type ReviewFilters struct {
+	FilmID uint
+
+	// A zero Limit returns every matching row
+	Limit  int
+	Offset int
+}
+
+type reviewRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewReviewRepositoryGorm(db *gorm.DB) ReviewRepository {
+	return &reviewRepositoryGorm{db: db}
+}
+
+// refreshFilmRatingSQL recomputes the review aggregates stored on a film.
+// Reviews do not change the film itself, so updated_at is set explicitly to
+// keep MySQL's ON UPDATE CURRENT_TIMESTAMP from moving it.
+const refreshFilmRatingSQL = `UPDATE films SET
+	average_rating = COALESCE((SELECT AVG(rating) FROM reviews WHERE film_id = ?), 0),
+	review_count = (SELECT COUNT(*) FROM reviews WHERE film_id = ?),
+	updated_at = updated_at
+	WHERE id = ?`
+
+func refreshFilmRating(tx *gorm.DB, filmID uint) error {
+	return tx.Exec(refreshFilmRatingSQL, filmID, filmID, filmID).Error
+}
+
+func (r *reviewRepositoryGorm) FindReviews(ctx context.Context, filters ReviewFilters) ([]domain.Review, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Review{}).Where("film_id = ?", filters.FilmID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError("could not count reviews", err)
+	}
+
+	// Newest first, id breaks ties between reviews written in the same second
+	query = query.Preload("User").Order("created_at DESC").Order("id DESC")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit).Offset(filters.Offset)
+	}
+
+	var reviews []domain.Review
+	if err := query.Find(&reviews).Error; err != nil {
+		return nil, 0, wrapDBError("could not list reviews", err)
+	}
+	return reviews, total, nil
+}
+
+func (r *reviewRepositoryGorm) GetReviewByID(ctx context.Context, id uint) (*domain.Review, error) {
+	var review domain.Review
+	err := r.db.WithContext(ctx).Preload("User").First(&review, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("could not get review", err)
+	}
+	return &review, nil
+}
+
+func (r *reviewRepositoryGorm) CreateReview(ctx context.Context, review *domain.Review) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User").Create(review).Error; err != nil {
+			return err
+		}
+		return refreshFilmRating(tx, review.FilmID)
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.Conflict("review_exists", "you have already reviewed this film")
+		}
+		return wrapDBError("could not create review", err)
+	}
+	return nil
+}
+
+func (r *reviewRepositoryGorm) UpdateReview(ctx context.Context, review *domain.Review) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User").Save(review).Error; err != nil {
+			return err
+		}
+		return refreshFilmRating(tx, review.FilmID)
+	})
+	if err != nil {
+		return wrapDBError("could not update review", err)
+	}
+	return nil
+}
+
+func (r *reviewRepositoryGorm) DeleteReviewByID(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var review domain.Review
+		if err := tx.Select("id", "film_id").First(&review, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Review{}, id).Error; err != nil {
+			return err
+		}
+		return refreshFilmRating(tx, review.FilmID)
+	})
+	if err != nil {
+		return wrapDBError("could not delete review", err)
+	}
+	return nil
+}
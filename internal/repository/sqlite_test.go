@@ -0,0 +1,569 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go-films-api/internal/database"
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// openSQLite migrates a fresh SQLite database, seeded like every other
+// driver with two users and three films, and connects to it.
+func openSQLite(t *testing.T) *gorm.DB {
+	cfg := database.Config{Driver: database.SQLite, Name: filepath.Join(t.TempDir(), "films.db")}
+	if err := database.Migrate(cfg, "../../migrations"); err != nil {
+		t.Fatalf("could not migrate: %v", err)
+	}
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("could not open: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestSQLite_Seed(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewFilmRepositoryGorm(openSQLite(t))
+
+	films, total, err := repo.FindFilms(ctx, repository.FilmFilters{SortBy: "release_date"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, "First Admin Film", films[0].Title)
+	if assert.NotNil(t, films[0].ReleaseDate) {
+		assert.Equal(t, time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), films[0].ReleaseDate.UTC())
+	}
+	assert.Equal(t, "action", films[0].Genres[0].Slug)
+	assert.Equal(t, []string{"Admin Director"}, films[0].CreditNames(domain.CreditRoleDirector))
+	assert.Equal(t, []string{"Sample Cast A"}, films[0].CreditNames(domain.CreditRoleActor))
+
+	films, _, err = repo.FindFilms(ctx, repository.FilmFilters{Year: 2023, ReleaseDateFrom: *films[1].ReleaseDate})
+	assert.NoError(t, err)
+	assert.Len(t, films, 2)
+}
+
+func TestSQLite_CreateFilm(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewFilmRepositoryGorm(openSQLite(t))
+
+	releaseDate := time.Date(1995, time.December, 15, 0, 0, 0, 0, time.UTC)
+	film := &domain.Film{
+		UserID:      1,
+		Title:       "Heat",
+		ReleaseDate: &releaseDate,
+		Synopsis:    "A group of professional bank robbers.",
+		Genres:      []domain.Genre{{ID: 1}},
+		Credits:     []domain.FilmCredit{{PersonID: 1, Role: domain.CreditRoleDirector}},
+	}
+	err := repo.CreateFilm(ctx, film, &domain.AuditEvent{Action: domain.AuditActionCreate})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	stored, err := repo.GetFilmByID(ctx, film.ID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Heat", stored.Title)
+	assert.Equal(t, uint(1), stored.Version)
+	assert.Equal(t, releaseDate, stored.ReleaseDate.UTC())
+	assert.Len(t, stored.Genres, 1)
+	assert.Len(t, stored.Credits, 1)
+
+	// Titles are unique, whichever database enforces it
+	err = repo.CreateFilm(ctx, &domain.Film{UserID: 1, Title: "Heat"}, &domain.AuditEvent{Action: domain.AuditActionCreate})
+	assert.True(t, errors.Is(err, domain.ErrConflict))
+	assert.Equal(t, "film_title_taken", domain.ErrorCode(err))
+
+	missing, err := repo.GetFilmByID(ctx, 999)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSQLite_UpdateFilm(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewFilmRepositoryGorm(openSQLite(t))
+
+	film, err := repo.GetFilmByID(ctx, 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+	film.Synopsis = "Updated synopsis."
+	assert.NoError(t, repo.UpdateFilm(ctx, film, &domain.AuditEvent{Action: domain.AuditActionUpdate}))
+	assert.Equal(t, uint(2), film.Version)
+
+	// The stored version moved on, so a second update from version 1 fails
+	film.Version = 1
+	assert.ErrorIs(t, repo.UpdateFilm(ctx, film, &domain.AuditEvent{Action: domain.AuditActionUpdate}), domain.ErrFilmModified)
+
+	film.Version = 2
+	film.Title = "Testuser Film"
+	err = repo.UpdateFilm(ctx, film, &domain.AuditEvent{Action: domain.AuditActionUpdate})
+	assert.Equal(t, "film_title_taken", domain.ErrorCode(err))
+}
+
+func TestSQLite_ClearReleaseDate(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	repo := repository.NewFilmRepositoryGorm(db)
+
+	film, err := repo.GetFilmByID(ctx, 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+	film.ReleaseDate = nil
+	assert.NoError(t, repo.UpdateFilm(ctx, film, &domain.AuditEvent{Action: domain.AuditActionUpdate}))
+
+	var nulls int64
+	db.Model(&domain.Film{}).Where("id = ? AND release_date IS NULL", 1).Count(&nulls)
+	assert.Equal(t, int64(1), nulls, "the column is NULL, not a zero date")
+
+	stored, err := repo.GetFilmByID(ctx, 1)
+	if assert.NoError(t, err) {
+		assert.Nil(t, stored.ReleaseDate)
+	}
+}
+
+func TestSQLite_SearchFilms(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	repo := repository.NewFilmRepositoryGorm(db)
+
+	films, total, err := repo.FindFilms(ctx, repository.FilmFilters{Query: "dramatic"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "Testuser Film", films[0].Title)
+	assert.Greater(t, films[0].Score, 0.0)
+
+	// People match too, and title matches rank first
+	films, _, err = repo.FindFilms(ctx, repository.FilmFilters{Query: "admin"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, films, 1)
+	assert.Equal(t, "First Admin Film", films[0].Title)
+
+	films, _, err = repo.FindFilms(ctx, repository.FilmFilters{Query: "sample cast c"})
+	assert.NoError(t, err)
+	assert.Len(t, films, 1)
+
+	// LIKE filters ignore case
+	films, _, err = repo.FindFilms(ctx, repository.FilmFilters{Title: "TESTUSER", Director: "test director 2"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, films, 1)
+	assert.Equal(t, "Another Testuser Film", films[0].Title)
+
+	people, total, err := repository.NewPersonRepositoryGorm(db).FindPeople(ctx, repository.PersonFilters{Name: "SAMPLE"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, people, 3)
+}
+
+func TestSQLite_KeysetPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewFilmRepositoryGorm(openSQLite(t))
+
+	first, _, err := repo.FindFilms(ctx, repository.FilmFilters{SortBy: "release_date", SortDesc: true, Limit: 2})
+	if !assert.NoError(t, err) || !assert.Len(t, first, 2) {
+		return
+	}
+	next, _, err := repo.FindFilms(ctx, repository.FilmFilters{
+		SortBy:   "release_date",
+		SortDesc: true,
+		Limit:    2,
+		After: &repository.FilmKeyset{
+			SortValue: first[1].ReleaseDate.Format(time.RFC3339Nano),
+			ID:        first[1].ID,
+		},
+	})
+	if !assert.NoError(t, err) || !assert.Len(t, next, 1) {
+		return
+	}
+	assert.Equal(t, "First Admin Film", next[0].Title)
+}
+
+// Paging one film at a time through every sort key must return the films in
+// the same order as a single page, which needs each key's keyset value to be
+// decoded and compared the right way.
+func TestSQLite_KeysetPagination_AllSorts(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	repo := repository.NewFilmRepositoryGorm(db)
+	assert.NoError(t, db.Exec("UPDATE films SET average_rating = 10 - id * 2.5, review_count = id % 2").Error)
+	// Written by the driver, like the timestamps of films created through the
+	// API, with a tie that id has to break
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, db.Model(&domain.Film{}).Where("id IN ?", []uint{1, 3}).UpdateColumn("created_at", created).Error)
+	assert.NoError(t, db.Model(&domain.Film{}).Where("id = ?", 2).UpdateColumn("created_at", created.Add(-time.Hour)).Error)
+
+	sortValue := func(sortBy string, film domain.Film) string {
+		switch sortBy {
+		case "title":
+			return film.Title
+		case "director":
+			return film.SortKey
+		case "release_date":
+			return film.ReleaseDate.Format(time.RFC3339Nano)
+		case "created_at":
+			return film.CreatedAt.Format(time.RFC3339Nano)
+		case "average_rating":
+			return strconv.FormatFloat(film.AverageRating, 'f', -1, 64)
+		default:
+			return strconv.Itoa(film.ReviewCount)
+		}
+	}
+
+	for _, sortBy := range []string{"title", "director", "release_date", "created_at", "average_rating", "review_count"} {
+		for _, desc := range []bool{false, true} {
+			name := fmt.Sprintf("%s desc=%v", sortBy, desc)
+			all, _, err := repo.FindFilms(ctx, repository.FilmFilters{SortBy: sortBy, SortDesc: desc})
+			if !assert.NoError(t, err, name) || !assert.Len(t, all, 3, name) {
+				continue
+			}
+
+			var paged []uint
+			filters := repository.FilmFilters{SortBy: sortBy, SortDesc: desc, Limit: 1}
+			for i := 0; i < 4; i++ {
+				page, _, err := repo.FindFilms(ctx, filters)
+				if !assert.NoError(t, err, name) || len(page) == 0 {
+					break
+				}
+				paged = append(paged, page[0].ID)
+				filters.After = &repository.FilmKeyset{SortValue: sortValue(sortBy, page[0]), ID: page[0].ID}
+			}
+			assert.Equal(t, []uint{all[0].ID, all[1].ID, all[2].ID}, paged, name)
+		}
+	}
+}
+
+func TestSQLite_KeysetPagination_NullReleaseDates(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	repo := repository.NewFilmRepositoryGorm(db)
+	assert.NoError(t, db.Model(&domain.Film{}).Where("id IN ?", []uint{1, 3}).Update("release_date", nil).Error)
+
+	// Pages of one film each, resuming from the keyset of the previous page
+	collect := func(desc bool) []uint {
+		var ids []uint
+		var after *repository.FilmKeyset
+		for len(ids) <= 3 {
+			films, _, err := repo.FindFilms(ctx, repository.FilmFilters{SortBy: "release_date", SortDesc: desc, Limit: 1, After: after})
+			if !assert.NoError(t, err) || len(films) == 0 {
+				break
+			}
+			ids = append(ids, films[0].ID)
+			after = &repository.FilmKeyset{ID: films[0].ID}
+			if films[0].ReleaseDate != nil {
+				after.SortValue = films[0].ReleaseDate.Format(time.RFC3339Nano)
+			}
+		}
+		return ids
+	}
+	assert.Equal(t, []uint{2, 1, 3}, collect(false), "films without a date come last")
+	assert.Equal(t, []uint{3, 1, 2}, collect(true))
+}
+
+func TestSQLite_KeysetPagination_Director(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewFilmRepositoryGorm(openSQLite(t))
+
+	first, _, err := repo.FindFilms(ctx, repository.FilmFilters{SortBy: "director", Limit: 2})
+	if !assert.NoError(t, err) || !assert.Len(t, first, 2) {
+		return
+	}
+	assert.Equal(t, first[1].CreditNames(domain.CreditRoleDirector)[0], first[1].SortKey)
+
+	next, _, err := repo.FindFilms(ctx, repository.FilmFilters{
+		SortBy: "director",
+		Limit:  2,
+		After:  &repository.FilmKeyset{SortValue: first[1].SortKey, ID: first[1].ID},
+	})
+	if !assert.NoError(t, err) || !assert.Len(t, next, 1) {
+		return
+	}
+	assert.NotContains(t, []uint{first[0].ID, first[1].ID}, next[0].ID)
+}
+
+func TestSQLite_ReviewAggregates(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	films := repository.NewFilmRepositoryGorm(db)
+	reviews := repository.NewReviewRepositoryGorm(db)
+
+	before, err := films.GetFilmByID(ctx, 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, reviews.CreateReview(ctx, &domain.Review{FilmID: 1, UserID: 2, Rating: 7}))
+	assert.NoError(t, reviews.CreateReview(ctx, &domain.Review{FilmID: 1, UserID: 1, Rating: 8}))
+
+	after, err := films.GetFilmByID(ctx, 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 7.5, after.AverageRating)
+	assert.Equal(t, 2, after.ReviewCount)
+	// A review is not a change to the film: its ETag and update time stay
+	assert.Equal(t, before.Version, after.Version)
+	assert.True(t, before.UpdatedAt.Equal(after.UpdatedAt))
+}
+
+func TestSQLite_StreamFilms(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewFilmRepositoryGorm(openSQLite(t))
+
+	var batches [][]string
+	err := repo.StreamFilms(ctx, repository.FilmFilters{SortBy: "title"}, 2, func(films []domain.Film) error {
+		var titles []string
+		for _, film := range films {
+			assert.NotEmpty(t, film.Genres)
+			titles = append(titles, film.Title)
+		}
+		batches = append(batches, titles)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Another Testuser Film", "First Admin Film"}, {"Testuser Film"}}, batches)
+}
+
+func TestSQLite_ConcurrentWatchlistUpdates(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewWatchlistRepositoryGorm(openSQLite(t))
+
+	watchlist := &domain.Watchlist{UserID: 1, Name: "Weekend"}
+	if !assert.NoError(t, repo.CreateWatchlist(ctx, watchlist)) {
+		return
+	}
+
+	// Each edit sees the items added before it, so none is lost
+	var wg sync.WaitGroup
+	for filmID := uint(1); filmID <= 3; filmID++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.UpdateWatchlistItems(ctx, watchlist.ID, func(watchlist *domain.Watchlist) error {
+				watchlist.Items = append(watchlist.Items, domain.WatchlistItem{FilmID: filmID})
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	loaded, err := repo.GetWatchlistByID(ctx, watchlist.ID)
+	if !assert.NoError(t, err) || !assert.Len(t, loaded.Items, 3) {
+		return
+	}
+	for i, item := range loaded.Items {
+		assert.Equal(t, i, item.Position)
+	}
+
+	updated, err := repo.UpdateWatchlistItems(ctx, 99, func(*domain.Watchlist) error {
+		t.Error("update called for a missing watchlist")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, updated)
+
+	failure := errors.New("not allowed")
+	_, err = repo.UpdateWatchlistItems(ctx, watchlist.ID, func(watchlist *domain.Watchlist) error {
+		watchlist.Items = nil
+		return failure
+	})
+	assert.Equal(t, failure, err, "errors of update are returned as is")
+	loaded, _ = repo.GetWatchlistByID(ctx, watchlist.ID)
+	assert.Len(t, loaded.Items, 3, "and roll back")
+}
+
+func TestSQLite_WatchlistItemsOfTrashedFilms(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	films := repository.NewFilmRepositoryGorm(db)
+	repo := repository.NewWatchlistRepositoryGorm(db)
+
+	watchlist := &domain.Watchlist{UserID: 1, Name: "Weekend"}
+	if !assert.NoError(t, repo.CreateWatchlist(ctx, watchlist)) {
+		return
+	}
+	setItems := func(filmIDs ...uint) {
+		_, err := repo.UpdateWatchlistItems(ctx, watchlist.ID, func(watchlist *domain.Watchlist) error {
+			watchlist.Items = nil
+			for _, id := range filmIDs {
+				watchlist.Items = append(watchlist.Items, domain.WatchlistItem{FilmID: id})
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+	setItems(1, 2, 3)
+	assert.NoError(t, films.DeleteFilmByID(ctx, 1, &domain.AuditEvent{Action: domain.AuditActionDelete}))
+
+	// Film 1 keeps its entry, moved behind the live items instead of sharing
+	// position 0 with film 3
+	setItems(3, 2)
+	var positions []uint
+	db.Model(&domain.WatchlistItem{}).Where("watchlist_id = ?", watchlist.ID).Order("position").Pluck("film_id", &positions)
+	assert.Equal(t, []uint{3, 2, 1}, positions)
+
+	assert.NoError(t, films.RestoreFilmByID(ctx, 1, &domain.AuditEvent{Action: domain.AuditActionRestore}))
+	loaded, err := repo.GetWatchlistByID(ctx, watchlist.ID)
+	if !assert.NoError(t, err) || !assert.Len(t, loaded.Items, 3) {
+		return
+	}
+	for i, item := range loaded.Items {
+		assert.Equal(t, []uint{3, 2, 1}[i], item.FilmID)
+		assert.Equal(t, i, item.Position)
+	}
+}
+
+func TestSQLite_TrashAndPurge(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	repo := repository.NewFilmRepositoryGorm(db)
+
+	assert.NoError(t, repo.DeleteFilmByID(ctx, 2, &domain.AuditEvent{Action: domain.AuditActionDelete}))
+	film, err := repo.GetFilmByID(ctx, 2)
+	assert.NoError(t, err)
+	assert.Nil(t, film)
+
+	trashed, total, err := repo.FindTrashedFilms(ctx, repository.TrashFilters{UserID: 2})
+	if !assert.NoError(t, err) || !assert.Len(t, trashed, 1) {
+		return
+	}
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "Testuser Film", trashed[0].Title)
+
+	assert.NoError(t, repo.RestoreFilmByID(ctx, 2, &domain.AuditEvent{Action: domain.AuditActionRestore}))
+	film, err = repo.GetFilmByID(ctx, 2)
+	if !assert.NoError(t, err) || !assert.NotNil(t, film) {
+		return
+	}
+	assert.Len(t, film.Credits, 2)
+
+	// Only films trashed before the cutoff are purged, with everything
+	// that references them. Their images are returned, so that the caller
+	// can delete the blobs
+	imageRepo := repository.NewImageRepositoryGorm(db)
+	for _, image := range []*domain.FilmImage{
+		{FilmID: 1, Kind: domain.ImageKindPoster, ContentType: "image/png", Key: "films/1/a/original.png"},
+		{FilmID: 2, Kind: domain.ImageKindPoster, ContentType: "image/png", Key: "films/2/b/original.png"},
+		{FilmID: 3, Kind: domain.ImageKindStill, ContentType: "image/png", Key: "films/3/c/original.png"},
+	} {
+		assert.NoError(t, imageRepo.CreateFilmImage(ctx, image))
+	}
+
+	assert.NoError(t, repo.DeleteFilmByID(ctx, 2, &domain.AuditEvent{Action: domain.AuditActionDelete}))
+	purged, images, err := repo.PurgeFilmsDeletedBefore(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+	assert.Empty(t, images)
+
+	purged, images, err = repo.PurgeFilmsDeletedBefore(ctx, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	if assert.Len(t, images, 1) {
+		assert.Equal(t, "films/2/b/original.png", images[0].Key)
+	}
+
+	assert.NoError(t, repo.DeleteFilmByID(ctx, 3, &domain.AuditEvent{Action: domain.AuditActionDelete}))
+	images, err = repo.PurgeFilmByID(ctx, 3, &domain.AuditEvent{Action: domain.AuditActionPurge})
+	assert.NoError(t, err)
+	if assert.Len(t, images, 1) {
+		assert.Equal(t, "films/3/c/original.png", images[0].Key)
+	}
+	var remaining int64
+	assert.NoError(t, db.Model(&domain.FilmImage{}).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+
+	var credits int64
+	assert.NoError(t, db.Model(&domain.FilmCredit{}).Where("film_id = ?", 2).Count(&credits).Error)
+	assert.Equal(t, int64(0), credits)
+
+	events, total, err := repository.NewAuditRepositoryGorm(db).FindAuditEvents(ctx, repository.AuditFilters{FilmID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, domain.AuditActionPurge, events[0].Action)
+}
+
+func TestSQLite_DeleteExpiredTokens(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+	repo := repository.NewTokenRepositoryGorm(db)
+
+	now := time.Now()
+	for i, expiresAt := range []time.Time{now.Add(-time.Hour), now.Add(time.Hour)} {
+		assert.NoError(t, repo.CreateRefreshToken(ctx, &domain.RefreshToken{
+			UserID:               1,
+			FamilyID:             fmt.Sprintf("%032d", i),
+			TokenHash:            fmt.Sprintf("%064d", i),
+			AccessTokenID:        fmt.Sprintf("%032d", i),
+			AccessTokenExpiresAt: expiresAt.Add(-time.Minute),
+			ExpiresAt:            expiresAt,
+		}))
+	}
+	assert.NoError(t, db.Create(&[]domain.RevokedToken{
+		{JTI: "expired", ExpiresAt: now.Add(-time.Minute)},
+		{JTI: "live", ExpiresAt: now.Add(time.Minute)},
+	}).Error)
+
+	deleted, err := repo.DeleteExpiredTokens(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	token, err := repo.GetRefreshTokenByHash(ctx, fmt.Sprintf("%064d", 0))
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+	token, err = repo.GetRefreshTokenByHash(ctx, fmt.Sprintf("%064d", 1))
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+
+	revoked, err := repo.IsTokenRevoked(ctx, "expired")
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+	revoked, err = repo.IsTokenRevoked(ctx, "live")
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestSQLite_ContextDeadline(t *testing.T) {
+	db := openSQLite(t)
+	repo := repository.NewFilmRepositoryGorm(db)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, _, err := repo.FindFilms(ctx, repository.FilmFilters{})
+	assert.ErrorIs(t, err, domain.ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = repo.GetFilmByID(ctx, 1)
+	assert.ErrorIs(t, err, domain.ErrUnavailable)
+	_, _, err = repo.FindTrashedFilms(ctx, repository.TrashFilters{})
+	assert.ErrorIs(t, err, domain.ErrUnavailable)
+	_, err = repo.GetTrashedFilmByID(ctx, 1)
+	assert.ErrorIs(t, err, domain.ErrUnavailable)
+	err = repository.NewPersonRepositoryGorm(db).CreatePerson(ctx, &domain.Person{Name: "Michael Mann"})
+	assert.ErrorIs(t, err, domain.ErrUnavailable)
+}
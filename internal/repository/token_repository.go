@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-films-api/internal/domain"
+)
+
+// TokenRepository stores refresh tokens and doubles as the revocation store
+// consulted by the JWT middleware.
+type TokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*domain.RefreshToken, error)
+	// RotateRefreshToken marks current as used and stores next in its place.
+	// It returns false without storing next when current was already used,
+	// which happens when two requests race with the same token.
+	RotateRefreshToken(ctx context.Context, current *domain.RefreshToken, next *domain.RefreshToken) (bool, error)
+	// RevokeTokenFamily revokes every refresh token of a family together with
+	// the access tokens issued alongside them that have not expired yet.
+	RevokeTokenFamily(ctx context.Context, familyID string) error
+	// RevokeUserTokens revokes every session of a user.
+	RevokeUserTokens(ctx context.Context, userID uint) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// DeleteExpiredTokens deletes the refresh tokens and revocations that
+	// expired before cutoff, which can no longer be presented, and returns
+	// how many rows there were.
+	DeleteExpiredTokens(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type tokenRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewTokenRepositoryGorm(db *gorm.DB) TokenRepository {
+	return &tokenRepositoryGorm{db: db}
+}
+
+func (r *tokenRepositoryGorm) CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
+	if err := r.db.WithContext(ctx).Create(token).Error; err != nil {
+		return wrapDBError("could not create refresh token", err)
+	}
+	return nil
+}
+
+func (r *tokenRepositoryGorm) GetRefreshTokenByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", hash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("could not get refresh token", err)
+	}
+	return &token, nil
+}
+
+// errTokenAlreadyUsed rolls back a rotation that lost the race for the current token.
+var errTokenAlreadyUsed = errors.New("refresh token already used")
+
+func (r *tokenRepositoryGorm) RotateRefreshToken(ctx context.Context, current *domain.RefreshToken, next *domain.RefreshToken) (bool, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		// The used_at condition makes the check and the update a single atomic step
+		result := tx.Model(&domain.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", current.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTokenAlreadyUsed
+		}
+		current.UsedAt = &now
+		return tx.Create(next).Error
+	})
+	if errors.Is(err, errTokenAlreadyUsed) {
+		return false, nil
+	} else if err != nil {
+		return false, wrapDBError("could not rotate refresh token", err)
+	}
+	return true, nil
+}
+
+func (r *tokenRepositoryGorm) RevokeTokenFamily(ctx context.Context, familyID string) error {
+	if err := r.revokeTokens(ctx, "family_id = ?", familyID); err != nil {
+		return wrapDBError("could not revoke token family", err)
+	}
+	return nil
+}
+
+func (r *tokenRepositoryGorm) RevokeUserTokens(ctx context.Context, userID uint) error {
+	if err := r.revokeTokens(ctx, "user_id = ?", userID); err != nil {
+		return wrapDBError("could not revoke user tokens", err)
+	}
+	return nil
+}
+
+// revokeTokens revokes the refresh tokens matching the condition and records
+// the jti of their access tokens that have not expired yet.
+func (r *tokenRepositoryGorm) revokeTokens(ctx context.Context, condition string, value interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var tokens []domain.RefreshToken
+		if err := tx.Select("access_token_id", "access_token_expires_at").
+			Where(condition, value).
+			Where("access_token_expires_at > ?", now).
+			Find(&tokens).Error; err != nil {
+			return err
+		}
+		if len(tokens) > 0 {
+			revoked := make([]domain.RevokedToken, len(tokens))
+			for i, token := range tokens {
+				revoked[i] = domain.RevokedToken{JTI: token.AccessTokenID, ExpiresAt: token.AccessTokenExpiresAt}
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&revoked).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&domain.RefreshToken{}).
+			Where(condition, value).
+			Where("revoked_at IS NULL").
+			Update("revoked_at", now).Error
+	})
+}
+
+func (r *tokenRepositoryGorm) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&domain.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, wrapDBError("could not check token revocation", err)
+	}
+	return count > 0, nil
+}
+
+func (r *tokenRepositoryGorm) DeleteExpiredTokens(ctx context.Context, cutoff time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("expires_at < ?", cutoff).Delete(&domain.RevokedToken{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		result = tx.Where("expires_at < ?", cutoff).Delete(&domain.RefreshToken{})
+		deleted += result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, wrapDBError("could not delete expired tokens", err)
+	}
+	return deleted, nil
+}
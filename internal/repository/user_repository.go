@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 
 	"gorm.io/gorm"
@@ -9,8 +10,19 @@ import (
 )
 
 type UserRepository interface {
-	CreateUser(user *domain.User) error
-	GetUserByUsername(username string) (*domain.User, error)
+	FindUsers(ctx context.Context, filters UserFilters) ([]domain.User, int64, error)
+	GetUserByID(ctx context.Context, id uint) (*domain.User, error)
+	CreateUser(ctx context.Context, user *domain.User) error
+	GetUserByUsername(ctx context.Context, username string) (*domain.User, error)
+	UpdateUserRole(ctx context.Context, id uint, role domain.Role) error
+}
+
+type UserFilters struct {
+	Role domain.Role
+
+	// A zero Limit returns every matching row
+	Limit  int
+	Offset int
 }
 
 type userRepositoryGorm struct {
@@ -21,20 +33,64 @@ func NewUserRepositoryGorm(db *gorm.DB) UserRepository {
 	return &userRepositoryGorm{db: db}
 }
 
-func (r *userRepositoryGorm) CreateUser(user *domain.User) error {
-	if err := r.db.Create(user).Error; err != nil {
-		return err
+func (r *userRepositoryGorm) FindUsers(ctx context.Context, filters UserFilters) ([]domain.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.User{})
+	if filters.Role != "" {
+		query = query.Where("role = ?", filters.Role)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, wrapDBError("could not count users", err)
+	}
+
+	query = query.Order("username").Order("id")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit).Offset(filters.Offset)
+	}
+
+	var users []domain.User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, 0, wrapDBError("could not list users", err)
+	}
+	return users, total, nil
+}
+
+func (r *userRepositoryGorm) GetUserByID(ctx context.Context, id uint) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, wrapDBError("could not get user", err)
+	}
+	return &user, nil
+}
+
+func (r *userRepositoryGorm) CreateUser(ctx context.Context, user *domain.User) error {
+	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.Conflict("username_taken", "username already taken")
+		}
+		return wrapDBError("could not create user", err)
 	}
 	return nil
 }
 
-func (r *userRepositoryGorm) GetUserByUsername(username string) (*domain.User, error) {
+func (r *userRepositoryGorm) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
 	var user domain.User
-	err := r.db.Where("username = ?", username).First(&user).Error
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	} else if err != nil {
-		return nil, err
+		return nil, wrapDBError("could not get user", err)
 	}
 	return &user, nil
 }
+
+func (r *userRepositoryGorm) UpdateUserRole(ctx context.Context, id uint, role domain.Role) error {
+	if err := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", id).Update("role", role).Error; err != nil {
+		return wrapDBError("could not update user role", err)
+	}
+	return nil
+}
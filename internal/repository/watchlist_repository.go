@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"go-films-api/internal/domain"
+)
+
+type WatchlistRepository interface {
+	FindWatchlistsByUser(ctx context.Context, userID uint) ([]domain.Watchlist, error)
+	GetWatchlistByID(ctx context.Context, id uint) (*domain.Watchlist, error)
+	CreateWatchlist(ctx context.Context, watchlist *domain.Watchlist) error
+	UpdateWatchlist(ctx context.Context, watchlist *domain.Watchlist) error
+	UpdateWatchlistItems(ctx context.Context, id uint, update func(watchlist *domain.Watchlist) error) (*domain.Watchlist, error)
+	DeleteWatchlistByID(ctx context.Context, id uint) error
+}
+
+type watchlistRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewWatchlistRepositoryGorm(db *gorm.DB) WatchlistRepository {
+	return &watchlistRepositoryGorm{db: db}
+}
+
+// FindWatchlistsByUser returns the user's watchlists without their items.
+func (r *watchlistRepositoryGorm) FindWatchlistsByUser(ctx context.Context, userID uint) ([]domain.Watchlist, error) {
+	var watchlists []domain.Watchlist
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name").Order("id").Find(&watchlists).Error; err != nil {
+		return nil, wrapDBError("could not list watchlists", err)
+	}
+	return watchlists, nil
+}
+
+// liveItemCondition leaves out the items of trashed films. They stay in the
+// table, after the live items, and reappear when the film is restored.
+const liveItemCondition = "film_id IN (SELECT id FROM films WHERE deleted_at IS NULL)"
+
+// GetWatchlistByID loads a watchlist with its items in list order.
+func (r *watchlistRepositoryGorm) GetWatchlistByID(ctx context.Context, id uint) (*domain.Watchlist, error) {
+	watchlist, err := findWatchlist(r.db.WithContext(ctx), id)
+	if err != nil {
+		return nil, wrapDBError("could not get watchlist", err)
+	}
+	return watchlist, nil
+}
+
+// findWatchlist loads a watchlist with its live items in list order, or nil
+// if there is none with that id.
+func findWatchlist(db *gorm.DB, id uint) (*domain.Watchlist, error) {
+	var watchlist domain.Watchlist
+	err := db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Where(liveItemCondition).Order("position")
+		}).
+		Preload("Items.Film").
+		First(&watchlist, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &watchlist, nil
+}
+
+func (r *watchlistRepositoryGorm) CreateWatchlist(ctx context.Context, watchlist *domain.Watchlist) error {
+	if err := r.db.WithContext(ctx).Omit("Items").Create(watchlist).Error; err != nil {
+		return wrapDBError("could not create watchlist", err)
+	}
+	return nil
+}
+
+func (r *watchlistRepositoryGorm) UpdateWatchlist(ctx context.Context, watchlist *domain.Watchlist) error {
+	if err := r.db.WithContext(ctx).Omit("Items").Save(watchlist).Error; err != nil {
+		return wrapDBError("could not update watchlist", err)
+	}
+	return nil
+}
+
+// UpdateWatchlistItems loads a watchlist, lets update change its items and
+// stores watchlist.Items as the complete, ordered content of the list, all in
+// one transaction. The watchlist stays locked until then, so concurrent
+// changes to one list run one after the other instead of dropping each
+// other's items. It returns nil if the watchlist does not exist; an error
+// from update rolls the transaction back and is returned as is.
+func (r *watchlistRepositoryGorm) UpdateWatchlistItems(ctx context.Context, id uint, update func(watchlist *domain.Watchlist) error) (*domain.Watchlist, error) {
+	var watchlist *domain.Watchlist
+	var updateErr error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Touching the list first locks its row before the items are read, and
+		// makes UpdatedAt reflect changes to its content
+		err := tx.Model(&domain.Watchlist{}).Where("id = ?", id).Update("updated_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		watchlist, err = findWatchlist(tx, id)
+		if err != nil || watchlist == nil {
+			return err
+		}
+		if updateErr = update(watchlist); updateErr != nil {
+			return updateErr
+		}
+		return replaceWatchlistItems(tx, watchlist)
+	})
+	if updateErr != nil {
+		return nil, updateErr
+	}
+	if err != nil {
+		return nil, wrapDBError("could not update watchlist items", err)
+	}
+	return watchlist, nil
+}
+
+// replaceWatchlistItems numbers watchlist.Items from 0 and stores them in
+// place of the live items. The items of trashed films are kept, renumbered
+// to follow the live ones in their previous order.
+func replaceWatchlistItems(tx *gorm.DB, watchlist *domain.Watchlist) error {
+	err := tx.Where("watchlist_id = ?", watchlist.ID).Where(liveItemCondition).Delete(&domain.WatchlistItem{}).Error
+	if err != nil {
+		return err
+	}
+	for i := range watchlist.Items {
+		watchlist.Items[i].WatchlistID = watchlist.ID
+		watchlist.Items[i].Position = i
+	}
+	if len(watchlist.Items) > 0 {
+		if err := tx.Omit("Film").Create(&watchlist.Items).Error; err != nil {
+			return err
+		}
+	}
+
+	var trashedItems []domain.WatchlistItem
+	err = tx.Where("watchlist_id = ?", watchlist.ID).Where("NOT " + liveItemCondition).
+		Order("position").Order("film_id").Find(&trashedItems).Error
+	if err != nil {
+		return err
+	}
+	for i, item := range trashedItems {
+		if err := tx.Model(&item).Update("position", len(watchlist.Items)+i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *watchlistRepositoryGorm) DeleteWatchlistByID(ctx context.Context, id uint) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.Watchlist{}, id).Error; err != nil {
+		return wrapDBError("could not delete watchlist", err)
+	}
+	return nil
+}
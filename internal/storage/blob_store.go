@@ -0,0 +1,36 @@
+// Package storage keeps uploaded files, such as film images, outside the
+// database.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BlobStore stores opaque blobs under slash-separated keys like
+// "films/12/3f9c/original.jpg".
+type BlobStore interface {
+	// Put stores size bytes read from r under key, replacing any blob
+	// already stored there.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Delete removes the blob stored under key. Deleting a missing blob is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the address clients fetch the blob from.
+	URL(key string) string
+}
+
+// validateKey rejects keys that could escape the store, such as "../x".
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStore keeps blobs as files below a directory, which the server
+// publishes under baseURL.
+type LocalBlobStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalBlobStore(dir, baseURL string) *LocalBlobStore {
+	return &LocalBlobStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Put writes the blob to a temporary file first, so that a failed or
+// canceled upload never leaves a truncated file behind.
+func (s *LocalBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("could not create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("could not create blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("could not write blob: %w", err)
+	}
+	if written != size {
+		return fmt.Errorf("could not write blob: got %d bytes, expected %d", written, size)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("could not write blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("could not write blob: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not delete blob: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
@@ -0,0 +1,49 @@
+package storage_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-films-api/internal/storage"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := storage.NewLocalBlobStore(dir, "/media/")
+
+	err := store.Put(ctx, "films/1/abc/original.png", strings.NewReader("png"), 3, "image/png")
+	assert.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "films", "1", "abc", "original.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", string(data))
+	assert.Equal(t, "/media/films/1/abc/original.png", store.URL("films/1/abc/original.png"))
+
+	assert.NoError(t, store.Delete(ctx, "films/1/abc/original.png"))
+	_, err = os.Stat(filepath.Join(dir, "films", "1", "abc", "original.png"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Deleting twice is fine
+	assert.NoError(t, store.Delete(ctx, "films/1/abc/original.png"))
+}
+
+func TestLocalBlobStore_RejectsBadBlobs(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := storage.NewLocalBlobStore(filepath.Join(dir, "media"), "/media")
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "films//x", "films/./x"} {
+		assert.Error(t, store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"), key)
+	}
+
+	// A short read leaves nothing behind
+	assert.Error(t, store.Put(ctx, "films/1/x.png", strings.NewReader("x"), 2, "image/png"))
+	entries, err := os.ReadDir(filepath.Join(dir, "media", "films", "1"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
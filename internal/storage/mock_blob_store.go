@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBlobStore struct {
+	mock.Mock
+}
+
+// Put reads the blob, so expectations can match on its content.
+func (m *MockBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	args := m.Called(ctx, key, data, contentType)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) URL(key string) string {
+	return "https://cdn.example.com/" + key
+}
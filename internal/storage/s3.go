@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config describes a bucket of Amazon S3 or of a compatible server such
+// as MinIO.
+type S3Config struct {
+	// Endpoint is the server's base URL, e.g. https://s3.eu-west-1.amazonaws.com
+	// or http://localhost:9000
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	// PublicURL is where clients fetch blobs from, such as a CDN in front of
+	// the bucket. It defaults to the bucket's address on Endpoint, which
+	// needs a bucket policy allowing anonymous reads.
+	PublicURL string
+}
+
+// S3BlobStore keeps blobs as objects in an S3 bucket, addressed path-style
+// (endpoint/bucket/key) so that it also works with servers that have no
+// per-bucket host names. Requests are signed with AWS Signature Version 4.
+type S3BlobStore struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// emptyPayloadHash is the SHA-256 of an empty request body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func NewS3BlobStore(cfg S3Config) (*S3BlobStore, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("missing S3 bucket")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("missing S3 credentials")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.PublicURL == "" {
+		cfg.PublicURL = endpoint.String() + "/" + cfg.Bucket
+	}
+	cfg.PublicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+
+	return &S3BlobStore{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: time.Minute},
+		now:      time.Now,
+	}, nil
+}
+
+// Put uploads the blob without hashing it first, which S3 allows by signing
+// the body as UNSIGNED-PAYLOAD.
+func (s *S3BlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), r)
+	if err != nil {
+		return fmt.Errorf("could not put blob: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, "UNSIGNED-PAYLOAD", s.now())
+
+	return s.do(req, "put", http.StatusOK)
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return fmt.Errorf("could not delete blob: %w", err)
+	}
+	s.sign(req, emptyPayloadHash, s.now())
+
+	// S3 answers 204 whether or not the object existed, but compatible
+	// servers may report a missing one
+	return s.do(req, "delete", http.StatusOK, http.StatusNoContent, http.StatusNotFound)
+}
+
+func (s *S3BlobStore) URL(key string) string {
+	return s.cfg.PublicURL + "/" + s3Escape(key, false)
+}
+
+func (s *S3BlobStore) objectURL(key string) string {
+	return s.endpoint.String() + "/" + s3Escape(s.cfg.Bucket, true) + "/" + s3Escape(key, false)
+}
+
+// do sends req and turns an unexpected status into an error carrying the
+// S3 error code.
+func (s *S3BlobStore) do(req *http.Request, action string, expected ...int) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not %s blob: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			return nil
+		}
+	}
+
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(body, &s3Err) == nil && s3Err.Code != "" {
+		return fmt.Errorf("could not %s blob: %s: %s: %s", action, resp.Status, s3Err.Code, s3Err.Message)
+	}
+	return fmt.Errorf("could not %s blob: %s", action, resp.Status)
+}
+
+// sign adds the Signature Version 4 headers to req. Every header already set
+// on req is signed, together with the host.
+func (s *S3BlobStore) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "authorization" || name == "user-agent" {
+			continue
+		}
+		headers[name] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, s3Escape(name, true)+"="+s3Escape(value, true))
+		}
+	}
+	sort.Strings(params)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		strings.Join(params, "&"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// s3Escape percent-encodes every byte but the unreserved characters, as S3
+// expects in signed paths and query strings. Slashes are kept unless
+// escapeSlash is set.
+func s3Escape(value string, escapeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !escapeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
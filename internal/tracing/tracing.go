@@ -0,0 +1,70 @@
+// Package tracing sets up OpenTelemetry tracing: the tracer provider and its
+// exporter, W3C trace context propagation and the spans of database queries.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/uptrace/opentelemetry-go-extra/otelgorm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"gorm.io/gorm"
+
+	"go-films-api/internal/config"
+)
+
+// Setup installs the global tracer provider and propagator described by cfg.
+// The returned function flushes the spans still buffered and must be called
+// on shutdown. With the "none" exporter spans are not recorded at all, but
+// incoming trace context is still passed on.
+func Setup(ctx context.Context, cfg config.Tracing) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case config.ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case config.ExporterStdout:
+		exporter = newStdoutExporter(os.Stdout)
+	case config.ExporterOTLP:
+		otlp, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return nil, fmt.Errorf("could not create OTLP exporter: %w", err)
+		}
+		exporter = otlp
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func newStdoutExporter(w io.Writer) sdktrace.SpanExporter {
+	// Only fails on invalid options
+	exporter, _ := stdouttrace.New(stdouttrace.WithWriter(w), stdouttrace.WithPrettyPrint())
+	return exporter
+}
+
+// InstrumentDB makes every query db runs a span, child of the span in the
+// context the query runs with. Query arguments are left out of the spans,
+// since they hold password hashes and tokens.
+func InstrumentDB(db *gorm.DB, name string) error {
+	return db.Use(otelgorm.NewPlugin(
+		otelgorm.WithDBName(name),
+		otelgorm.WithoutQueryVariables(),
+		otelgorm.WithoutMetrics(),
+	))
+}
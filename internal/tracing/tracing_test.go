@@ -0,0 +1,62 @@
+package tracing_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"go-films-api/internal/config"
+	"go-films-api/internal/database"
+	"go-films-api/internal/tracing"
+)
+
+func TestSetup(t *testing.T) {
+	cfg := config.Default().Tracing
+	shutdown, err := tracing.Setup(context.Background(), cfg)
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	cfg.Exporter = config.ExporterStdout
+	shutdown, err = tracing.Setup(context.Background(), cfg)
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	cfg.Exporter = "jaeger"
+	_, err = tracing.Setup(context.Background(), cfg)
+	assert.EqualError(t, err, `unsupported trace exporter "jaeger"`)
+}
+
+func TestInstrumentDB(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	cfg := database.Config{Driver: database.SQLite, Name: filepath.Join(t.TempDir(), "films.db")}
+	db, err := database.Open(cfg)
+	if !assert.NoError(t, err) {
+		return
+	}
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+	assert.NoError(t, tracing.InstrumentDB(db, "films"))
+
+	assert.NoError(t, db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, password TEXT)").Error)
+	recorder.Reset()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	assert.NoError(t, db.WithContext(ctx).Exec("INSERT INTO users (password) VALUES (?)", "hunter2").Error)
+	parent.End()
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		query := spans[0]
+		assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID(), "queries are children of the span in their context")
+		for _, attr := range query.Attributes() {
+			assert.NotContains(t, attr.Value.Emit(), "hunter2", "query arguments stay out of spans")
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+)
+
+type AuditService interface {
+	ListAuditEvents(ctx context.Context, query AuditQuery) (*AuditPage, error)
+	FilmHistory(ctx context.Context, filmID uint, page, pageSize int) (*AuditPage, error)
+}
+
+// AuditQuery holds the filters and page for ListAuditEvents. From and To
+// bound the time of the events, inclusively.
+type AuditQuery struct {
+	FilmID   uint
+	ActorID  uint
+	Action   domain.AuditAction
+	From     time.Time
+	To       time.Time
+	Page     int
+	PageSize int
+}
+
+// AuditPage is a single page of audit events together with the total number of matches.
+type AuditPage struct {
+	Events   []domain.AuditEvent
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+type auditService struct {
+	auditRepo repository.AuditRepository
+	filmRepo  repository.FilmRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository, filmRepo repository.FilmRepository) AuditService {
+	return &auditService{auditRepo: auditRepo, filmRepo: filmRepo}
+}
+
+func (s *auditService) ListAuditEvents(ctx context.Context, query AuditQuery) (*AuditPage, error) {
+	if query.Action != "" && !query.Action.IsValid() {
+		return nil, domain.Invalid("action", "action must be one of create, update, delete, restore, purge")
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.From.After(query.To) {
+		return nil, domain.Invalid("from", "from must not be after to")
+	}
+
+	page, pageSize := query.Page, query.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	events, total, err := s.auditRepo.FindAuditEvents(ctx, repository.AuditFilters{
+		FilmID:  query.FilmID,
+		ActorID: query.ActorID,
+		Action:  query.Action,
+		From:    query.From,
+		To:      query.To,
+		Limit:   pageSize,
+		Offset:  (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditPage{
+		Events:   events,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// FilmHistory returns the changes made to a film, newest first. Only films
+// that are not in the trash have a public history.
+func (s *auditService) FilmHistory(ctx context.Context, filmID uint, page, pageSize int) (*AuditPage, error) {
+	film, err := s.filmRepo.GetFilmByID(ctx, filmID)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if film == nil {
+		return nil, domain.NotFound("film_not_found", "film not found")
+	}
+
+	return s.ListAuditEvents(ctx, AuditQuery{FilmID: filmID, Page: page, PageSize: pageSize})
+}
+
+// auditField is the value of one audited film field.
+type auditField struct {
+	name  string
+	value interface{}
+}
+
+// auditCredit is how a credit appears in the audit log.
+type auditCredit struct {
+	PersonID  uint
+	Role      domain.CreditRole
+	Character string `json:",omitempty"`
+}
+
+// filmAuditFields captures the audited fields of a film in a stable order.
+// Genres are recorded by slug and credits without their generated IDs.
+func filmAuditFields(film *domain.Film) []auditField {
+	var releaseDate interface{}
+	if film.ReleaseDate != nil {
+		releaseDate = film.ReleaseDate.Format("2006-01-02")
+	}
+	genres := make([]string, len(film.Genres))
+	for i, genre := range film.Genres {
+		genres[i] = genre.Slug
+	}
+	credits := make([]auditCredit, len(film.Credits))
+	for i, credit := range film.Credits {
+		credits[i] = auditCredit{PersonID: credit.PersonID, Role: credit.Role, Character: credit.Character}
+	}
+
+	return []auditField{
+		{"title", film.Title},
+		{"release_date", releaseDate},
+		{"synopsis", film.Synopsis},
+		{"genres", genres},
+		{"credits", credits},
+	}
+}
+
+// diffAuditFields lists the fields whose value differs between before and
+// after. A nil before describes a new film, whose empty fields are skipped.
+func diffAuditFields(before, after []auditField) []domain.FieldChange {
+	var changes []domain.FieldChange
+	for i, field := range after {
+		if before == nil {
+			if !isEmptyAuditValue(field.value) {
+				changes = append(changes, domain.FieldChange{Field: field.name, After: field.value})
+			}
+			continue
+		}
+		if !reflect.DeepEqual(before[i].value, field.value) {
+			changes = append(changes, domain.FieldChange{Field: field.name, Before: before[i].value, After: field.value})
+		}
+	}
+	return changes
+}
+
+func isEmptyAuditValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// newAuditEvent builds the event recording action by actorID.
+func newAuditEvent(action domain.AuditAction, actorID uint, changes []domain.FieldChange) *domain.AuditEvent {
+	return &domain.AuditEvent{Action: action, ActorID: &actorID, Changes: changes}
+}
@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
+	"go-films-api/internal/usecase"
+)
+
+func captureAuditEvent(call *mock.Call, event **domain.AuditEvent) *mock.Call {
+	return call.Run(func(args mock.Arguments) {
+		*event = args.Get(2).(*domain.AuditEvent)
+	})
+}
+
+func TestCreateFilm_RecordsAuditEvent(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	mockGenreRepo := new(repository.MockGenreRepository)
+	service := usecase.NewFilmService(mockRepo, mockGenreRepo, new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockGenreRepo.On("FindGenresBySlugs", mock.Anything, []string{"crime"}).
+		Return([]domain.Genre{{ID: 3, Name: "Crime", Slug: "crime"}}, nil)
+	var event *domain.AuditEvent
+	captureAuditEvent(mockRepo.On("CreateFilm", mock.Anything, mock.Anything, mock.Anything).Return(nil), &event)
+
+	_, err := service.CreateFilm(ctx, usecase.CreateFilmData{
+		Title:       "Heat",
+		ReleaseDate: time.Date(1995, time.December, 15, 0, 0, 0, 0, time.UTC),
+		Genres:      []string{"crime"},
+	}, 5)
+	assert.NoError(t, err)
+
+	assert.Equal(t, domain.AuditActionCreate, event.Action)
+	assert.Equal(t, uint(5), *event.ActorID)
+	// Empty fields of a new film are left out
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "title", After: "Heat"},
+		{Field: "release_date", After: "1995-12-15"},
+		{Field: "genres", After: []string{"crime"}},
+	}, event.Changes)
+}
+
+func TestUpdateFilm_RecordsChangedFields(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	mockGenreRepo := new(repository.MockGenreRepository)
+	service := usecase.NewFilmService(mockRepo, mockGenreRepo, new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(&domain.Film{
+		ID:       10,
+		UserID:   5,
+		Title:    "Heat",
+		Synopsis: "A heist.",
+		Genres:   []domain.Genre{{ID: 3, Name: "Crime", Slug: "crime"}},
+	}, nil)
+	mockGenreRepo.On("FindGenresBySlugs", mock.Anything, []string{"crime", "drama"}).Return([]domain.Genre{
+		{ID: 3, Name: "Crime", Slug: "crime"},
+		{ID: 4, Name: "Drama", Slug: "drama"},
+	}, nil)
+	var event *domain.AuditEvent
+	captureAuditEvent(mockRepo.On("UpdateFilm", mock.Anything, mock.Anything, mock.Anything).Return(nil), &event)
+
+	genres := []string{"crime", "drama"}
+	_, err := service.UpdateFilm(ctx, 10, 6, domain.RoleEditor, usecase.UpdateFilmData{
+		Title:    strPtr("Heat (1995)"),
+		Synopsis: strPtr("A heist."),
+		Genres:   &genres,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, domain.AuditActionUpdate, event.Action)
+	assert.Equal(t, uint(6), *event.ActorID)
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "title", Before: "Heat", After: "Heat (1995)"},
+		{Field: "genres", Before: []string{"crime"}, After: []string{"crime", "drama"}},
+	}, event.Changes)
+}
+
+func TestDeleteFilm_RecordsAuditEvent(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(&domain.Film{ID: 10, UserID: 5}, nil)
+	var event *domain.AuditEvent
+	captureAuditEvent(mockRepo.On("DeleteFilmByID", mock.Anything, uint(10), mock.Anything).Return(nil), &event)
+
+	assert.NoError(t, service.DeleteFilm(ctx, 10, 5, domain.RoleUser))
+	assert.Equal(t, domain.AuditActionDelete, event.Action)
+	assert.Equal(t, uint(5), *event.ActorID)
+	assert.Empty(t, event.Changes)
+}
+
+func TestListAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	mockAuditRepo := new(repository.MockAuditRepository)
+	service := usecase.NewAuditService(mockAuditRepo, new(repository.MockFilmRepository))
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	events := []domain.AuditEvent{{ID: 1, FilmID: 10, Action: domain.AuditActionUpdate}}
+	mockAuditRepo.On("FindAuditEvents", mock.Anything, repository.AuditFilters{
+		ActorID: 5,
+		Action:  domain.AuditActionUpdate,
+		From:    from,
+		Limit:   usecase.MaxPageSize,
+		Offset:  usecase.MaxPageSize,
+	}).Return(events, int64(101), nil)
+
+	page, err := service.ListAuditEvents(ctx, usecase.AuditQuery{
+		ActorID:  5,
+		Action:   domain.AuditActionUpdate,
+		From:     from,
+		Page:     2,
+		PageSize: 500,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, events, page.Events)
+	assert.Equal(t, int64(101), page.Total)
+	assert.Equal(t, usecase.MaxPageSize, page.PageSize)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestListAuditEvents_InvalidFilters(t *testing.T) {
+	ctx := context.Background()
+	mockAuditRepo := new(repository.MockAuditRepository)
+	service := usecase.NewAuditService(mockAuditRepo, new(repository.MockFilmRepository))
+
+	_, err := service.ListAuditEvents(ctx, usecase.AuditQuery{Action: "rename"})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = service.ListAuditEvents(ctx, usecase.AuditQuery{From: time.Now(), To: time.Now().Add(-time.Hour)})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+
+	mockAuditRepo.AssertNotCalled(t, "FindAuditEvents", mock.Anything, mock.Anything)
+}
+
+func TestFilmHistory(t *testing.T) {
+	ctx := context.Background()
+	mockAuditRepo := new(repository.MockAuditRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewAuditService(mockAuditRepo, mockFilmRepo)
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(&domain.Film{ID: 10}, nil)
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(11)).Return(nil, nil)
+	mockAuditRepo.On("FindAuditEvents", mock.Anything, repository.AuditFilters{FilmID: 10, Limit: usecase.DefaultPageSize}).
+		Return([]domain.AuditEvent{{ID: 1, FilmID: 10}}, int64(1), nil)
+
+	page, err := service.FilmHistory(ctx, 10, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, page.Events, 1)
+
+	_, err = service.FilmHistory(ctx, 11, 1, 20)
+	assert.Equal(t, "film_not_found", domain.ErrorCode(err))
+	mockAuditRepo.AssertExpectations(t)
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+)
+
+type FavoriteService interface {
+	ListFavorites(ctx context.Context, userID uint, page, pageSize int) (*FilmPage, error)
+	AddFavorite(ctx context.Context, userID, filmID uint) error
+	RemoveFavorite(ctx context.Context, userID, filmID uint) error
+}
+
+type favoriteService struct {
+	favoriteRepo repository.FavoriteRepository
+	filmRepo     repository.FilmRepository
+}
+
+func NewFavoriteService(repo repository.FavoriteRepository, filmRepo repository.FilmRepository) FavoriteService {
+	return &favoriteService{favoriteRepo: repo, filmRepo: filmRepo}
+}
+
+func (s *favoriteService) ListFavorites(ctx context.Context, userID uint, page, pageSize int) (*FilmPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	films, total, err := s.favoriteRepo.FindFavoriteFilms(ctx, repository.FavoriteFilters{
+		UserID: userID,
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilmPage{
+		Films:    films,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		HasMore:  int64(page*pageSize) < total,
+	}, nil
+}
+
+func (s *favoriteService) AddFavorite(ctx context.Context, userID, filmID uint) error {
+	film, err := s.filmRepo.GetFilmByID(ctx, filmID)
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	if film == nil {
+		return domain.NotFound("film_not_found", "film not found")
+	}
+
+	return s.favoriteRepo.AddFavorite(ctx, &domain.Favorite{UserID: userID, FilmID: filmID})
+}
+
+func (s *favoriteService) RemoveFavorite(ctx context.Context, userID, filmID uint) error {
+	favorite, err := s.favoriteRepo.GetFavorite(ctx, userID, filmID)
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	if favorite == nil {
+		return domain.NotFound("favorite_not_found", "film is not in your favorites")
+	}
+
+	return s.favoriteRepo.RemoveFavorite(ctx, userID, filmID)
+}
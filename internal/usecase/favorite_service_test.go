@@ -0,0 +1,78 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/usecase"
+)
+
+func TestListFavorites(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFavoriteRepository)
+	service := usecase.NewFavoriteService(mockRepo, new(repository.MockFilmRepository))
+
+	films := []domain.Film{{ID: 3, Title: "Heat"}, {ID: 1, Title: "Alien"}}
+	mockRepo.On("FindFavoriteFilms", mock.Anything, repository.FavoriteFilters{UserID: 5, Limit: 2, Offset: 2}).
+		Return(films, int64(5), nil)
+
+	page, err := service.ListFavorites(ctx, 5, 2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, films, page.Films)
+	assert.True(t, page.HasNext())
+	assert.True(t, page.HasPrev())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddFavorite(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFavoriteRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFavoriteService(mockRepo, mockFilmRepo)
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(3)).Return(&domain.Film{ID: 3}, nil)
+	mockRepo.On("AddFavorite", mock.Anything, &domain.Favorite{UserID: 5, FilmID: 3}).Return(nil)
+
+	assert.NoError(t, service.AddFavorite(ctx, 5, 3))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddFavorite_FilmNotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFavoriteRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFavoriteService(mockRepo, mockFilmRepo)
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(3)).Return(nil, nil)
+
+	assert.EqualError(t, service.AddFavorite(ctx, 5, 3), "film not found")
+	mockRepo.AssertNotCalled(t, "AddFavorite", mock.Anything, mock.Anything)
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFavoriteRepository)
+	service := usecase.NewFavoriteService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetFavorite", mock.Anything, uint(5), uint(3)).Return(&domain.Favorite{UserID: 5, FilmID: 3}, nil)
+	mockRepo.On("RemoveFavorite", mock.Anything, uint(5), uint(3)).Return(nil)
+
+	assert.NoError(t, service.RemoveFavorite(ctx, 5, 3))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRemoveFavorite_NotFavorite(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFavoriteRepository)
+	service := usecase.NewFavoriteService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetFavorite", mock.Anything, uint(5), uint(3)).Return(nil, nil)
+
+	assert.EqualError(t, service.RemoveFavorite(ctx, 5, 3), "film is not in your favorites")
+	mockRepo.AssertNotCalled(t, "RemoveFavorite", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"go-films-api/internal/domain"
+)
+
+// ExportBatchSize is the number of films ExportFilms passes on at a time.
+const ExportBatchSize = 500
+
+// ExportFilms passes every film matching the filters and sort order of query
+// to fn, in batches of up to ExportBatchSize films. Page, PageSize and Cursor
+// are ignored. Films are read from the database as they are exported, so an
+// export of any size only holds one batch in memory. An error from fn stops
+// the export and is returned as is.
+func (s *filmService) ExportFilms(ctx context.Context, query ListFilmsQuery, fn func(films []domain.Film) error) error {
+	return s.filmRepo.StreamFilms(ctx, filmFilters(query), ExportBatchSize, fn)
+}
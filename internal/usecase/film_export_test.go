@@ -0,0 +1,66 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
+	"go-films-api/internal/usecase"
+)
+
+func TestExportFilms(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	films := make([]domain.Film, usecase.ExportBatchSize+1)
+	for i := range films {
+		films[i].ID = uint(i + 1)
+	}
+	// Paging is ignored; filters and sort order are mapped like ListFilms
+	mockRepo.On("StreamFilms", mock.Anything, repository.FilmFilters{
+		Query:    "heist",
+		Genres:   []string{"science-fiction"},
+		SortBy:   "release_date",
+		SortDesc: true,
+	}, usecase.ExportBatchSize).Return(films, nil)
+
+	var batches []int
+	err := filmService.ExportFilms(ctx, usecase.ListFilmsQuery{
+		Query:    " heist ",
+		Genres:   []string{"Science Fiction"},
+		Sort:     "-release_date",
+		Page:     3,
+		PageSize: 10,
+		Cursor:   "ignored",
+	}, func(batch []domain.Film) error {
+		batches = append(batches, len(batch))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{usecase.ExportBatchSize, 1}, batches)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExportFilms_StopsOnError(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	writeErr := errors.New("client went away")
+	mockRepo.On("StreamFilms", mock.Anything, repository.FilmFilters{}, usecase.ExportBatchSize).
+		Return([]domain.Film{{ID: 1}}, nil)
+
+	err := filmService.ExportFilms(ctx, usecase.ListFilmsQuery{}, func([]domain.Film) error {
+		return writeErr
+	})
+
+	assert.ErrorIs(t, err, writeErr)
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+)
+
+// ImportMode decides what happens to the rest of an import when a row fails.
+type ImportMode string
+
+const (
+	// ImportAtomic writes every row in one transaction, which is rolled back
+	// if any row fails
+	ImportAtomic ImportMode = "atomic"
+	// ImportBestEffort writes each valid row on its own and skips the others
+	ImportBestEffort ImportMode = "best_effort"
+)
+
+func (m ImportMode) IsValid() bool {
+	return m == ImportAtomic || m == ImportBestEffort
+}
+
+// Outcomes of an imported row
+const (
+	ImportRowCreated   = "created"
+	ImportRowDuplicate = "duplicate"
+	ImportRowFailed    = "failed"
+)
+
+// ImportRow is one decoded row of an import. Err is set when the row could
+// not be decoded; it is then reported as failed without being validated.
+type ImportRow struct {
+	Data CreateFilmData
+	Err  error
+}
+
+// ImportSource yields the rows of an import one at a time, so that large
+// files are never held in memory as a whole. Next returns io.EOF after the
+// last row; any other error aborts the import.
+type ImportSource interface {
+	Next() (ImportRow, error)
+}
+
+// ImportOptions configures ImportFilms. A DryRun validates and checks every
+// row against the database, then rolls everything back.
+type ImportOptions struct {
+	Mode   ImportMode
+	DryRun bool
+}
+
+// ImportReport describes the outcome of every row. When Committed is false
+// nothing was written, and created rows are the ones that would have been.
+type ImportReport struct {
+	Mode       ImportMode
+	DryRun     bool
+	Committed  bool
+	Total      int
+	Created    int
+	Duplicates int
+	Failed     int
+	Rows       []ImportRowResult
+}
+
+// ImportRowResult is the outcome of one row. Row numbers start at 1 and do
+// not count a CSV header. Code, Message and Fields explain failed and
+// duplicate rows.
+type ImportRowResult struct {
+	Row     int
+	Title   string
+	Status  string
+	FilmID  uint
+	Code    string
+	Message string
+	Fields  []domain.FieldError
+}
+
+// errImportRollback rolls back the transaction of an import that must not
+// be committed.
+var errImportRollback = errors.New("import rolled back")
+
+// ImportFilms creates a film from every row of source, validated like
+// CreateFilm. Rows whose title is already taken are skipped as duplicates.
+// Best-effort imports write rows as they are read; the others read and
+// validate every row before opening their transaction, so that a slow upload
+// cannot hold it open. An error from source or the database aborts the
+// import, leaving rows a best-effort import already wrote.
+func (s *filmService) ImportFilms(ctx context.Context, source ImportSource, opts ImportOptions, userID uint) (*ImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = ImportAtomic
+	}
+	if !opts.Mode.IsValid() {
+		return nil, domain.Invalid("mode", "mode must be atomic or best_effort")
+	}
+
+	report := &ImportReport{Mode: opts.Mode, DryRun: opts.DryRun}
+
+	// Best-effort imports commit every row on its own; the others share one
+	// transaction so they can be rolled back together
+	if opts.Mode == ImportBestEffort && !opts.DryRun {
+		if err := s.streamRows(ctx, source, userID, report); err != nil {
+			return nil, err
+		}
+		report.Committed = true
+		return report, nil
+	}
+
+	films, err := s.readRows(ctx, source, userID, report)
+	if err != nil {
+		return nil, err
+	}
+	err = s.filmRepo.Transaction(ctx, func(repo repository.FilmRepository) error {
+		for _, pending := range films {
+			result := &report.Rows[pending.row]
+			if err := report.record(result, pending.film, createFilm(ctx, repo, pending.film)); err != nil {
+				return err
+			}
+		}
+		if opts.DryRun || report.Failed > 0 {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		return nil, err
+	}
+	report.Committed = err == nil
+	if !report.Committed {
+		for i := range report.Rows {
+			report.Rows[i].FilmID = 0
+		}
+	}
+	return report, nil
+}
+
+// pendingFilm is a validated film waiting to be written for a row of the
+// report.
+type pendingFilm struct {
+	row  int
+	film *domain.Film
+}
+
+// streamRows writes the film of every row as soon as it is read.
+func (s *filmService) streamRows(ctx context.Context, source ImportSource, userID uint, report *ImportReport) error {
+	for {
+		row, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result, film, err := s.importRow(ctx, row, userID, report)
+		if err == nil {
+			err = createFilm(ctx, s.filmRepo, film)
+		}
+		if err := report.record(&result, film, err); err != nil {
+			return err
+		}
+		report.Rows = append(report.Rows, result)
+	}
+}
+
+// readRows reads and validates every row, reporting the invalid ones, and
+// returns the films of the others still to be written.
+func (s *filmService) readRows(ctx context.Context, source ImportSource, userID uint, report *ImportReport) ([]pendingFilm, error) {
+	var films []pendingFilm
+	for {
+		row, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			return films, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result, film, err := s.importRow(ctx, row, userID, report)
+		if err != nil {
+			if err := report.record(&result, nil, err); err != nil {
+				return nil, err
+			}
+		} else {
+			films = append(films, pendingFilm{row: len(report.Rows), film: film})
+		}
+		report.Rows = append(report.Rows, result)
+	}
+}
+
+// importRow numbers row and builds its film.
+func (s *filmService) importRow(ctx context.Context, row ImportRow, userID uint, report *ImportReport) (ImportRowResult, *domain.Film, error) {
+	report.Total++
+	result := ImportRowResult{Row: report.Total, Title: row.Data.Title}
+	if row.Err != nil {
+		return result, nil, row.Err
+	}
+	film, err := s.newFilm(ctx, row.Data, userID)
+	if err != nil {
+		return result, nil, err
+	}
+	result.Title = film.Title
+	return result, film, nil
+}
+
+// record sets the outcome of a row from the error of importing it. Errors
+// that are not about the row, such as a lost database connection or a
+// canceled request, are returned and abort the import.
+func (r *ImportReport) record(result *ImportRowResult, film *domain.Film, err error) error {
+	var typed *domain.Error
+	switch {
+	case err == nil:
+		result.Status = ImportRowCreated
+		result.FilmID = film.ID
+		r.Created++
+	case !errors.As(err, &typed) || errors.Is(err, domain.ErrUnavailable) || errors.Is(err, domain.ErrTimeout):
+		return err
+	case domain.ErrorCode(err) == "film_title_taken":
+		result.Status = ImportRowDuplicate
+		result.Code, result.Message = typed.Code, typed.Message
+		r.Duplicates++
+	default:
+		result.Status = ImportRowFailed
+		result.Code, result.Message, result.Fields = typed.Code, typed.Message, typed.Fields
+		r.Failed++
+	}
+	return nil
+}
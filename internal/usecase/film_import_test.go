@@ -0,0 +1,180 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
+	"go-films-api/internal/usecase"
+)
+
+// sliceImportSource yields rows from memory, then err (io.EOF by default).
+type sliceImportSource struct {
+	rows []usecase.ImportRow
+	err  error
+}
+
+func (s *sliceImportSource) Next() (usecase.ImportRow, error) {
+	if len(s.rows) == 0 {
+		if s.err != nil {
+			return usecase.ImportRow{}, s.err
+		}
+		return usecase.ImportRow{}, io.EOF
+	}
+	row := s.rows[0]
+	s.rows = s.rows[1:]
+	return row, nil
+}
+
+func importRows() *sliceImportSource {
+	return &sliceImportSource{rows: []usecase.ImportRow{
+		{Data: usecase.CreateFilmData{Title: " Heat "}},
+		{Data: usecase.CreateFilmData{Title: "Ronin"}},
+		{Data: usecase.CreateFilmData{Title: ""}},
+		{Err: domain.Invalid("release_date", "invalid release_date format, expected YYYY-MM-DD")},
+	}}
+}
+
+// setupImportRepo makes Heat importable and Ronin a duplicate.
+func setupImportRepo() *repository.MockFilmRepository {
+	mockRepo := new(repository.MockFilmRepository)
+	mockRepo.On("CreateFilm", mock.Anything, mock.MatchedBy(func(f *domain.Film) bool { return f.Title == "Heat" }), mock.Anything).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Film).ID = 100 }).
+		Return(nil)
+	mockRepo.On("CreateFilm", mock.Anything, mock.MatchedBy(func(f *domain.Film) bool { return f.Title == "Ronin" }), mock.Anything).
+		Return(domain.Conflict("film_title_taken", "film with title 'Ronin' already exists"))
+	return mockRepo
+}
+
+func TestImportFilms_BestEffort(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := setupImportRepo()
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	report, err := service.ImportFilms(ctx, importRows(), usecase.ImportOptions{Mode: usecase.ImportBestEffort}, 5)
+	assert.NoError(t, err)
+
+	assert.True(t, report.Committed)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Duplicates)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, usecase.ImportRowResult{Row: 1, Title: "Heat", Status: usecase.ImportRowCreated, FilmID: 100}, report.Rows[0])
+	assert.Equal(t, usecase.ImportRowDuplicate, report.Rows[1].Status)
+	assert.Equal(t, "film_title_taken", report.Rows[1].Code)
+	assert.Equal(t, usecase.ImportRowFailed, report.Rows[2].Status)
+	assert.Equal(t, "title", report.Rows[2].Fields[0].Field)
+	assert.Equal(t, 4, report.Rows[3].Row)
+	assert.Equal(t, "release_date", report.Rows[3].Fields[0].Field)
+
+	// Every row is written on its own
+	mockRepo.AssertNotCalled(t, "Transaction", mock.Anything, mock.Anything)
+}
+
+func TestImportFilms_AtomicRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := setupImportRepo()
+	mockRepo.On("Transaction", mock.Anything, mock.Anything).Return(nil)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	report, err := service.ImportFilms(ctx, importRows(), usecase.ImportOptions{}, 5)
+	assert.NoError(t, err)
+
+	assert.Equal(t, usecase.ImportAtomic, report.Mode)
+	assert.False(t, report.Committed)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 2, report.Failed)
+	// The film was rolled back, so it has no ID to report
+	assert.Equal(t, uint(0), report.Rows[0].FilmID)
+	mockRepo.AssertCalled(t, "Transaction", mock.Anything, mock.Anything)
+}
+
+func TestImportFilms_AtomicCommitsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := setupImportRepo()
+	mockRepo.On("Transaction", mock.Anything, mock.Anything).Return(nil)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	source := importRows()
+	source.rows = source.rows[:2]
+	report, err := service.ImportFilms(ctx, source, usecase.ImportOptions{Mode: usecase.ImportAtomic}, 5)
+	assert.NoError(t, err)
+
+	assert.True(t, report.Committed)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Duplicates)
+	assert.Equal(t, uint(100), report.Rows[0].FilmID)
+}
+
+func TestImportFilms_AtomicReadsBeforeTransaction(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := setupImportRepo()
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	// The whole upload is read before the transaction opens, so a slow
+	// client cannot keep it open
+	source := importRows()
+	source.rows = source.rows[:2]
+	mockRepo.On("Transaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { assert.Empty(t, source.rows, "rows left to read") }).
+		Return(nil)
+	report, err := service.ImportFilms(ctx, source, usecase.ImportOptions{}, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{usecase.ImportRowCreated, usecase.ImportRowDuplicate},
+		[]string{report.Rows[0].Status, report.Rows[1].Status})
+
+	// and an upload that breaks off never opens it
+	mockRepo = setupImportRepo()
+	service = usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+	source = importRows()
+	source.err = domain.Invalid("body", "invalid import: could not read body: unexpected EOF")
+	_, err = service.ImportFilms(ctx, source, usecase.ImportOptions{}, 5)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	mockRepo.AssertNotCalled(t, "Transaction", mock.Anything)
+}
+
+func TestImportFilms_DryRun(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := setupImportRepo()
+	mockRepo.On("Transaction", mock.Anything, mock.Anything).Return(nil)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	source := importRows()
+	source.rows = source.rows[:1]
+	report, err := service.ImportFilms(ctx, source, usecase.ImportOptions{Mode: usecase.ImportBestEffort, DryRun: true}, 5)
+	assert.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.False(t, report.Committed)
+	assert.Equal(t, 1, report.Created)
+	mockRepo.AssertCalled(t, "Transaction", mock.Anything, mock.Anything)
+}
+
+func TestImportFilms_Errors(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := setupImportRepo()
+	mockRepo.On("Transaction", mock.Anything, mock.Anything).Return(nil)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	_, err := service.ImportFilms(ctx, importRows(), usecase.ImportOptions{Mode: "all_or_nothing"}, 5)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	mockRepo.AssertNotCalled(t, "CreateFilm", mock.Anything, mock.Anything, mock.Anything)
+
+	// A broken source aborts the import instead of being reported as a row
+	readErr := errors.New("connection reset")
+	_, err = service.ImportFilms(ctx, &sliceImportSource{err: readErr}, usecase.ImportOptions{}, 5)
+	assert.ErrorIs(t, err, readErr)
+
+	mockRepo.On("CreateFilm", mock.Anything, mock.MatchedBy(func(f *domain.Film) bool { return f.Title == "Thief" }), mock.Anything).
+		Return(errors.New("could not create film: driver: bad connection"))
+	source := &sliceImportSource{rows: []usecase.ImportRow{{Data: usecase.CreateFilmData{Title: "Thief"}}}}
+	_, err = service.ImportFilms(ctx, source, usecase.ImportOptions{Mode: usecase.ImportBestEffort}, 5)
+	assert.EqualError(t, err, "could not create film: driver: bad connection")
+}
@@ -1,140 +1,438 @@
 package usecase
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"go-films-api/internal/config"
 	"go-films-api/internal/domain"
 	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
 )
 
 type FilmService interface {
-	ListFilms(title, genre string, releaseDate time.Time) ([]domain.Film, error)
-	GetFilmDetails(id uint) (*domain.Film, error)
-	CreateFilm(title, director, cast, genre, synopsis string, releaseDate time.Time, userID uint) (*domain.Film, error)
-	UpdateFilm(id, userID uint, data UpdateFilmData) (*domain.Film, error)
-	DeleteFilm(id, userID uint) error
+	ListFilms(ctx context.Context, query ListFilmsQuery) (*FilmPage, error)
+	GetFilmDetails(ctx context.Context, id uint) (*domain.Film, error)
+	CreateFilm(ctx context.Context, data CreateFilmData, userID uint) (*domain.Film, error)
+	UpdateFilm(ctx context.Context, id, userID uint, role domain.Role, data UpdateFilmData) (*domain.Film, error)
+	DeleteFilm(ctx context.Context, id, userID uint, role domain.Role) error
+
+	ListTrash(ctx context.Context, userID uint, page, pageSize int) (*FilmPage, error)
+	RestoreFilm(ctx context.Context, id, userID uint, role domain.Role) (*domain.Film, error)
+	PurgeFilm(ctx context.Context, id, actorID uint) error
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	ImportFilms(ctx context.Context, source ImportSource, opts ImportOptions, userID uint) (*ImportReport, error)
+	ExportFilms(ctx context.Context, query ListFilmsQuery, fn func(films []domain.Film) error) error
+}
+
+// Pagination constants
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ListFilmsQuery holds the filters, page and sort order for ListFilms.
+// Sort is a field name, optionally prefixed with "-" for descending order.
+// When Cursor is set it replaces Page and the sort order stored in the cursor is used.
+// Results of a full-text Query are ranked by relevance unless Sort is set.
+type ListFilmsQuery struct {
+	Query           string
+	Title           string
+	Director        string
+	PersonID        uint
+	CreditRole      domain.CreditRole
+	Genres          []string
+	ReleaseDate     time.Time
+	ReleaseDateFrom time.Time
+	ReleaseDateTo   time.Time
+	Year            int
+	CreatedBy       uint
+	CreatedAfter    time.Time
+	Page            int
+	PageSize        int
+	Sort            string
+	Cursor          string
+}
+
+// FilmPage is a single page of films together with the total number of matches.
+// NextCursor is empty on the last page and for relevance-ranked results,
+// which can only be paged with Page.
+type FilmPage struct {
+	Films      []domain.Film
+	Total      int64
+	Page       int
+	PageSize   int
+	NextCursor string
+	HasMore    bool
+}
+
+func (p *FilmPage) HasNext() bool {
+	return p.HasMore
+}
+
+func (p *FilmPage) HasPrev() bool {
+	return p.Page > 1
+}
+
+// IsValidFilmSort reports whether field (without a "-" prefix) can be used to sort films.
+func IsValidFilmSort(field string) bool {
+	return repository.IsValidFilmSort(field)
 }
 
+// CreateFilmData holds the fields of a new film. Genres are genre slugs and
+// a zero ReleaseDate means the date is unknown.
+type CreateFilmData struct {
+	Title       string
+	ReleaseDate time.Time
+	Genres      []string
+	Credits     []CreditData
+	Synopsis    string
+}
+
+// UpdateFilmData holds the fields to change; nil fields are left untouched.
+// Genres and Credits replace the film's current ones, and a zero ReleaseDate
+// clears the film's release date. Version is the film
+// version the change is based on; when it is set and the film has moved on,
+// the update fails with domain.ErrFilmModified.
 type UpdateFilmData struct {
 	Title       *string
-	Director    *string
 	ReleaseDate *time.Time
-	Cast        *string
-	Genre       *string
+	Genres      *[]string
+	Credits     *[]CreditData
 	Synopsis    *string
+	Version     uint
 }
 
 type filmService struct {
-	filmRepo repository.FilmRepository
+	filmRepo   repository.FilmRepository
+	genreRepo  repository.GenreRepository
+	personRepo repository.PersonRepository
+	blobs      storage.BlobStore
+	cursorKey  []byte
 }
 
-func NewFilmService(repo repository.FilmRepository) FilmService {
-	return &filmService{filmRepo: repo}
+func NewFilmService(
+	repo repository.FilmRepository,
+	genreRepo repository.GenreRepository,
+	personRepo repository.PersonRepository,
+	blobs storage.BlobStore,
+	auth config.Auth,
+) FilmService {
+	return &filmService{
+		filmRepo:   repo,
+		genreRepo:  genreRepo,
+		personRepo: personRepo,
+		blobs:      blobs,
+		cursorKey:  deriveCursorKey(auth.JWTSecret),
+	}
 }
 
-func (s *filmService) ListFilms(title, genre string, releaseDate time.Time) ([]domain.Film, error) {
-	filters := repository.FilmFilters{
-		Title:       title,
-		Genre:       genre,
-		ReleaseDate: releaseDate,
+func (s *filmService) ListFilms(ctx context.Context, query ListFilmsQuery) (*FilmPage, error) {
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := query.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	filters := filmFilters(query)
+	// Fetch one extra row to find out whether there is a next page
+	filters.Limit = pageSize + 1
+	filters.Offset = (page - 1) * pageSize
+	sortBy, sortDesc := filters.SortBy, filters.SortDesc
+
+	if query.Cursor != "" {
+		cur, err := decodeCursor(query.Cursor, s.cursorKey)
+		if err != nil {
+			return nil, err
+		}
+		if query.Sort != "" && (cur.Sort != sortBy || cur.Desc != sortDesc) {
+			return nil, ErrInvalidCursor
+		}
+		filters.SortBy, filters.SortDesc = cur.Sort, cur.Desc
+		filters.Offset = 0
+		filters.After = &repository.FilmKeyset{SortValue: cur.Value, ID: cur.ID}
+	}
+	rankedByRelevance := filters.Query != "" && filters.SortBy == ""
+	if rankedByRelevance && filters.After != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	films, total, err := s.filmRepo.FindFilms(ctx, filters)
+	if err != nil {
+		return nil, err
 	}
-	return s.filmRepo.FindFilms(filters)
+
+	result := &FilmPage{
+		Films:    films,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}
+	if len(films) > pageSize {
+		result.Films = films[:pageSize]
+		result.HasMore = true
+		if !rankedByRelevance {
+			last := newFilmCursor(result.Films[pageSize-1], filters.SortBy, filters.SortDesc)
+			result.NextCursor = encodeCursor(last, s.cursorKey)
+		}
+	}
+	if filters.Query != "" {
+		for i := range result.Films {
+			highlightFilm(&result.Films[i], filters.Query)
+		}
+	}
+
+	return result, nil
 }
 
-func (s *filmService) GetFilmDetails(id uint) (*domain.Film, error) {
-	film, err := s.filmRepo.GetFilmByID(id)
+// filmFilters maps the filters and sort order of query to the repository.
+func filmFilters(query ListFilmsQuery) repository.FilmFilters {
+	var genres []string
+	for _, g := range query.Genres {
+		genres = append(genres, Slugify(g))
+	}
+
+	return repository.FilmFilters{
+		Query:           strings.TrimSpace(query.Query),
+		Title:           query.Title,
+		Director:        query.Director,
+		PersonID:        query.PersonID,
+		CreditRole:      query.CreditRole,
+		Genres:          genres,
+		ReleaseDate:     query.ReleaseDate,
+		ReleaseDateFrom: query.ReleaseDateFrom,
+		ReleaseDateTo:   query.ReleaseDateTo,
+		Year:            query.Year,
+		CreatedBy:       query.CreatedBy,
+		CreatedAfter:    query.CreatedAfter,
+		SortBy:          strings.TrimPrefix(query.Sort, "-"),
+		SortDesc:        strings.HasPrefix(query.Sort, "-"),
+	}
+}
+
+func (s *filmService) GetFilmDetails(ctx context.Context, id uint) (*domain.Film, error) {
+	film, err := s.filmRepo.GetFilmByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	if film == nil {
-		return nil, errors.New("film not found")
+		return nil, domain.NotFound("film_not_found", "film not found")
+	}
+	return film, nil
+}
+
+func (s *filmService) CreateFilm(ctx context.Context, data CreateFilmData, userID uint) (*domain.Film, error) {
+	film, err := s.newFilm(ctx, data, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := createFilm(ctx, s.filmRepo, film); err != nil {
+		return nil, err
 	}
 	return film, nil
 }
 
-func (s *filmService) CreateFilm(
-	title, director, cast, genre, synopsis string,
-	releaseDate time.Time,
-	userID uint,
-) (*domain.Film, error) {
-	if title == "" {
-		return nil, errors.New("title is required")
+// newFilm validates data and builds the film it describes.
+func (s *filmService) newFilm(ctx context.Context, data CreateFilmData, userID uint) (*domain.Film, error) {
+	var errs fieldErrors
+	title := validateTitle(data.Title, &errs)
+	synopsis := validateSynopsis(data.Synopsis, &errs)
+	validateReleaseDate(data.ReleaseDate, &errs)
+
+	genres, err := resolveGenres(ctx, s.genreRepo, data.Genres, &errs)
+	if err != nil {
+		return nil, err
+	}
+	credits, err := resolveCredits(ctx, s.personRepo, data.Credits, &errs)
+	if err != nil {
+		return nil, err
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
 	}
 
 	film := &domain.Film{
 		UserID:      userID,
 		Title:       title,
-		Director:    director,
-		ReleaseDate: releaseDate,
-		Cast:        cast,
-		Genre:       genre,
+		ReleaseDate: optionalDate(data.ReleaseDate),
+		Genres:      genres,
+		Credits:     credits,
 		Synopsis:    synopsis,
+		Version:     1,
 	}
-
-	if err := s.filmRepo.CreateFilm(film); err != nil {
-		return nil, err
-	}
-
 	return film, nil
 }
 
-func (s *filmService) UpdateFilm(id, userID uint, data UpdateFilmData) (*domain.Film, error) {
-	film, err := s.filmRepo.GetFilmByID(id)
+// createFilm stores a film built by newFilm together with its audit event.
+func createFilm(ctx context.Context, repo repository.FilmRepository, film *domain.Film) error {
+	event := newAuditEvent(domain.AuditActionCreate, film.UserID, diffAuditFields(nil, filmAuditFields(film)))
+	return repo.CreateFilm(ctx, film, event)
+}
+
+func (s *filmService) UpdateFilm(ctx context.Context, id, userID uint, role domain.Role, data UpdateFilmData) (*domain.Film, error) {
+	film, err := s.filmRepo.GetFilmByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("repository error: %w", err)
 	}
 	if film == nil {
-		return nil, errors.New("film not found")
+		return nil, domain.NotFound("film_not_found", "film not found")
 	}
 
-	if film.UserID != userID {
-		return nil, errors.New("forbidden: only creator can update this film")
+	if film.UserID != userID && !role.Can(domain.PermEditAnyFilm) {
+		return nil, domain.Forbidden("not_film_creator", "forbidden: only creator can update this film")
+	}
+	if data.Version != 0 && data.Version != film.Version {
+		return nil, domain.ErrFilmModified
 	}
 
+	before := filmAuditFields(film)
+
+	// Validate every provided field before changing the film
+	var errs fieldErrors
 	if data.Title != nil {
-		film.Title = *data.Title
-	}
-	if data.Director != nil {
-		film.Director = *data.Director
+		film.Title = validateTitle(*data.Title, &errs)
 	}
 	if data.ReleaseDate != nil {
-		film.ReleaseDate = *data.ReleaseDate
+		validateReleaseDate(*data.ReleaseDate, &errs)
+		film.ReleaseDate = optionalDate(*data.ReleaseDate)
 	}
-	if data.Cast != nil {
-		film.Cast = *data.Cast
+	if data.Synopsis != nil {
+		film.Synopsis = validateSynopsis(*data.Synopsis, &errs)
 	}
-	if data.Genre != nil {
-		film.Genre = *data.Genre
+	if data.Genres != nil {
+		genres, err := resolveGenres(ctx, s.genreRepo, *data.Genres, &errs)
+		if err != nil {
+			return nil, err
+		}
+		film.Genres = genres
 	}
-	if data.Synopsis != nil {
-		film.Synopsis = *data.Synopsis
+	if data.Credits != nil {
+		credits, err := resolveCredits(ctx, s.personRepo, *data.Credits, &errs)
+		if err != nil {
+			return nil, err
+		}
+		film.Credits = credits
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
 	}
 
-	if err := s.filmRepo.UpdateFilm(film); err != nil {
+	event := newAuditEvent(domain.AuditActionUpdate, userID, diffAuditFields(before, filmAuditFields(film)))
+	if err := s.filmRepo.UpdateFilm(ctx, film, event); err != nil {
 		return nil, err
 	}
 
 	return film, nil
 }
 
-func (s *filmService) DeleteFilm(id, userID uint) error {
-	film, err := s.filmRepo.GetFilmByID(id)
+func (s *filmService) DeleteFilm(ctx context.Context, id, userID uint, role domain.Role) error {
+	film, err := s.filmRepo.GetFilmByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("repository error: %w", err)
 	}
 	if film == nil {
-		return errors.New("film not found")
+		return domain.NotFound("film_not_found", "film not found")
 	}
 
-	if film.UserID != userID {
-		return errors.New("forbidden: only creator can delete this film")
+	if film.UserID != userID && !role.Can(domain.PermDeleteAnyFilm) {
+		return domain.Forbidden("not_film_creator", "forbidden: only creator can delete this film")
 	}
 
-	if err := s.filmRepo.DeleteFilmByID(id); err != nil {
+	if err := s.filmRepo.DeleteFilmByID(ctx, id, newAuditEvent(domain.AuditActionDelete, userID, nil)); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// ListTrash returns the films userID created that are in the trash, most
+// recently deleted first. A zero userID lists the whole trash.
+func (s *filmService) ListTrash(ctx context.Context, userID uint, page, pageSize int) (*FilmPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	films, total, err := s.filmRepo.FindTrashedFilms(ctx, repository.TrashFilters{
+		UserID: userID,
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilmPage{
+		Films:    films,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		HasMore:  int64(page*pageSize) < total,
+	}, nil
+}
+
+// RestoreFilm takes a film out of the trash. Whoever may delete a film may
+// restore it.
+func (s *filmService) RestoreFilm(ctx context.Context, id, userID uint, role domain.Role) (*domain.Film, error) {
+	film, err := s.filmRepo.GetTrashedFilmByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if film == nil {
+		return nil, domain.NotFound("film_not_in_trash", "film not found in trash")
+	}
+
+	if film.UserID != userID && !role.Can(domain.PermDeleteAnyFilm) {
+		return nil, domain.Forbidden("not_film_creator", "forbidden: only creator can restore this film")
+	}
+
+	if err := s.filmRepo.RestoreFilmByID(ctx, id, newAuditEvent(domain.AuditActionRestore, userID, nil)); err != nil {
+		return nil, err
+	}
+
+	film.DeletedAt.Valid = false
+	return film, nil
+}
+
+// PurgeFilm permanently deletes a film from the trash, together with the
+// blobs of its images. Films that are not trashed must be deleted first.
+func (s *filmService) PurgeFilm(ctx context.Context, id, actorID uint) error {
+	film, err := s.filmRepo.GetTrashedFilmByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	if film == nil {
+		return domain.NotFound("film_not_in_trash", "film not found in trash")
+	}
+
+	images, err := s.filmRepo.PurgeFilmByID(ctx, id, newAuditEvent(domain.AuditActionPurge, actorID, nil))
+	if err != nil {
+		return err
+	}
+	deleteImageBlobs(ctx, s.blobs, images...)
+	return nil
+}
+
+// PurgeTrash permanently deletes the films trashed before deletedBefore,
+// with the blobs of their images, and returns how many there were.
+func (s *filmService) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	purged, images, err := s.filmRepo.PurgeFilmsDeletedBefore(ctx, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	deleteImageBlobs(ctx, s.blobs, images...)
+	return purged, nil
+}
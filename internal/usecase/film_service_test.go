@@ -1,7 +1,13 @@
 package usecase_test
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,76 +16,308 @@ import (
 
 	"go-films-api/internal/domain"
 	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
 	"go-films-api/internal/usecase"
 )
 
 func TestListFilms_NoFilters(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	filmService := usecase.NewFilmService(mockRepo)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
 	expectedFilms := []domain.Film{
-		{ID: 1, Title: "Film One", Genre: "Action"},
-		{ID: 2, Title: "Film Two", Genre: "Drama"},
+		{ID: 1, Title: "Film One"},
+		{ID: 2, Title: "Film Two"},
 	}
 
-	mockRepo.On("FindFilms", repository.FilmFilters{}).
-		Return(expectedFilms, nil)
+	mockRepo.On("FindFilms", mock.Anything, repository.FilmFilters{Limit: usecase.DefaultPageSize + 1}).
+		Return(expectedFilms, int64(2), nil)
 
-	films, err := filmService.ListFilms("", "", time.Time{})
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{})
 	assert.NoError(t, err)
-	assert.Equal(t, 2, len(films))
-	assert.Equal(t, "Film One", films[0].Title)
+	assert.Equal(t, 2, len(page.Films))
+	assert.Equal(t, "Film One", page.Films[0].Title)
+	assert.Equal(t, int64(2), page.Total)
+	assert.Equal(t, 1, page.Page)
+	assert.False(t, page.HasNext())
 	mockRepo.AssertExpectations(t)
 }
 
 func TestListFilms_WithTitleFilter(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	filmService := usecase.NewFilmService(mockRepo)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
 	expectedFilms := []domain.Film{
-		{ID: 3, Title: "Matrix Reloaded", Genre: "Sci-Fi"},
+		{ID: 3, Title: "Matrix Reloaded"},
 	}
 
 	filters := repository.FilmFilters{
 		Title: "Matrix",
+		Limit: usecase.DefaultPageSize + 1,
 	}
 
-	mockRepo.On("FindFilms", filters).
-		Return(expectedFilms, nil)
+	mockRepo.On("FindFilms", mock.Anything, filters).
+		Return(expectedFilms, int64(1), nil)
 
-	films, err := filmService.ListFilms("Matrix", "", time.Time{})
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{Title: "Matrix"})
 	assert.NoError(t, err)
-	assert.Len(t, films, 1)
-	assert.Equal(t, "Matrix Reloaded", films[0].Title)
+	assert.Len(t, page.Films, 1)
+	assert.Equal(t, "Matrix Reloaded", page.Films[0].Title)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestListFilms_WithGenreAndDate(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	filmService := usecase.NewFilmService(mockRepo)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
 	date, _ := time.Parse("2006-01-02", "2023-01-01")
 	filters := repository.FilmFilters{
-		Genre:       "Action",
+		Genres:      []string{"action"},
 		ReleaseDate: date,
+		Limit:       usecase.DefaultPageSize + 1,
 	}
 
 	expectedFilms := []domain.Film{
-		{ID: 4, Title: "Action Film 2023", Genre: "Action"},
+		{ID: 4, Title: "Action Film 2023"},
 	}
-	mockRepo.On("FindFilms", filters).
-		Return(expectedFilms, nil)
+	mockRepo.On("FindFilms", mock.Anything, filters).
+		Return(expectedFilms, int64(1), nil)
 
-	films, err := filmService.ListFilms("", "Action", date)
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{Genres: []string{"Action"}, ReleaseDate: date})
 	assert.NoError(t, err)
-	assert.Len(t, films, 1)
-	assert.Equal(t, uint(4), films[0].ID)
+	assert.Len(t, page.Films, 1)
+	assert.Equal(t, uint(4), page.Films[0].ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListFilms_RangeFilters(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	from, _ := time.Parse("2006-01-02", "1990-01-01")
+	to, _ := time.Parse("2006-01-02", "1999-12-31")
+	createdAfter, _ := time.Parse(time.RFC3339, "2024-05-01T10:00:00Z")
+	query := usecase.ListFilmsQuery{
+		Director:        "Scorsese",
+		Genres:          []string{"Action", "Drama"},
+		ReleaseDateFrom: from,
+		ReleaseDateTo:   to,
+		Year:            1995,
+		CreatedBy:       3,
+		CreatedAfter:    createdAfter,
+	}
+	filters := repository.FilmFilters{
+		Director:        "Scorsese",
+		Genres:          []string{"action", "drama"},
+		ReleaseDateFrom: from,
+		ReleaseDateTo:   to,
+		Year:            1995,
+		CreatedBy:       3,
+		CreatedAfter:    createdAfter,
+		Limit:           usecase.DefaultPageSize + 1,
+	}
+	mockRepo.On("FindFilms", mock.Anything, filters).
+		Return([]domain.Film{{ID: 8, Title: "Casino"}}, int64(1), nil)
+
+	page, err := filmService.ListFilms(ctx, query)
+	assert.NoError(t, err)
+	assert.Len(t, page.Films, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListFilms_PageAndSort(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	filters := repository.FilmFilters{
+		Limit:    3,
+		Offset:   4,
+		SortBy:   "release_date",
+		SortDesc: true,
+	}
+	mockRepo.On("FindFilms", mock.Anything, filters).
+		Return([]domain.Film{{ID: 5}, {ID: 6}, {ID: 7}}, int64(9), nil)
+
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{Page: 3, PageSize: 2, Sort: "-release_date"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page.Page)
+	assert.Len(t, page.Films, 2)
+	assert.True(t, page.HasNext())
+	assert.True(t, page.HasPrev())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListFilms_Cursor(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	firstPage := repository.FilmFilters{Limit: 3, SortBy: "title"}
+	mockRepo.On("FindFilms", mock.Anything, firstPage).
+		Return([]domain.Film{{ID: 4, Title: "A"}, {ID: 2, Title: "B"}, {ID: 9, Title: "C"}}, int64(5), nil)
+
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{PageSize: 2, Sort: "title"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, page.NextCursor)
+
+	secondPage := repository.FilmFilters{
+		Limit:  3,
+		SortBy: "title",
+		After:  &repository.FilmKeyset{SortValue: "B", ID: 2},
+	}
+	mockRepo.On("FindFilms", mock.Anything, secondPage).
+		Return([]domain.Film{{ID: 9, Title: "C"}}, int64(5), nil)
+
+	page, err = filmService.ListFilms(ctx, usecase.ListFilmsQuery{PageSize: 2, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	assert.Len(t, page.Films, 1)
+	assert.False(t, page.HasNext())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListFilms_CursorByRating(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	firstPage := repository.FilmFilters{Limit: 2, SortBy: "average_rating", SortDesc: true}
+	mockRepo.On("FindFilms", mock.Anything, firstPage).
+		Return([]domain.Film{{ID: 3, AverageRating: 8.25}, {ID: 1, AverageRating: 7}}, int64(2), nil)
+
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{PageSize: 1, Sort: "-average_rating"})
+	assert.NoError(t, err)
+
+	secondPage := repository.FilmFilters{
+		Limit:    2,
+		SortBy:   "average_rating",
+		SortDesc: true,
+		After:    &repository.FilmKeyset{SortValue: "8.25", ID: 3},
+	}
+	mockRepo.On("FindFilms", mock.Anything, secondPage).
+		Return([]domain.Film{{ID: 1, AverageRating: 7}}, int64(2), nil)
+
+	_, err = filmService.ListFilms(ctx, usecase.ListFilmsQuery{PageSize: 1, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListFilms_CursorByDirector(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	// The cursor holds the key the database sorted by, which may differ
+	// from a byte-wise minimum of the credited names
+	directors := func(names ...string) []domain.FilmCredit {
+		credits := make([]domain.FilmCredit, len(names))
+		for i, name := range names {
+			credits[i] = domain.FilmCredit{Role: domain.CreditRoleDirector, Person: domain.Person{Name: name}}
+		}
+		return credits
+	}
+	firstPage := repository.FilmFilters{Limit: 2, SortBy: "director"}
+	mockRepo.On("FindFilms", mock.Anything, firstPage).
+		Return([]domain.Film{
+			{ID: 5, Credits: directors("ethan Coen", "Joel Coen"), SortKey: "ethan Coen"},
+			{ID: 1, SortKey: "Zhang Yimou"},
+		}, int64(2), nil)
+
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{PageSize: 1, Sort: "director"})
+	assert.NoError(t, err)
+
+	secondPage := repository.FilmFilters{
+		Limit:  2,
+		SortBy: "director",
+		After:  &repository.FilmKeyset{SortValue: "ethan Coen", ID: 5},
+	}
+	mockRepo.On("FindFilms", mock.Anything, secondPage).
+		Return([]domain.Film{{ID: 1, SortKey: "Zhang Yimou"}}, int64(2), nil)
+
+	_, err = filmService.ListFilms(ctx, usecase.ListFilmsQuery{PageSize: 1, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListFilms_CursorNotSignedWithJWTSecret(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockRepo.On("FindFilms", mock.Anything, mock.Anything).
+		Return([]domain.Film{{ID: 1, Title: "A"}, {ID: 2, Title: "B"}}, int64(2), nil)
+
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{PageSize: 1, Sort: "title"})
+	assert.NoError(t, err)
+
+	encoded, sig, _ := strings.Cut(page.NextCursor, ".")
+	mac := hmac.New(sha256.New, []byte(testAuth.JWTSecret))
+	mac.Write([]byte(encoded))
+	assert.NotEqual(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), sig)
+}
+
+func TestListFilms_InvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockRepo.On("FindFilms", mock.Anything, mock.Anything).
+		Return([]domain.Film{{ID: 1, Title: "A"}, {ID: 2, Title: "B"}}, int64(2), nil)
+
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{PageSize: 1, Sort: "title"})
+	assert.NoError(t, err)
+
+	// Tampered signature
+	_, err = filmService.ListFilms(ctx, usecase.ListFilmsQuery{Cursor: page.NextCursor + "x"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidCursor)
+
+	// Cursor used with a different sort order
+	_, err = filmService.ListFilms(ctx, usecase.ListFilmsQuery{Cursor: page.NextCursor, Sort: "-title"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidCursor)
+
+	_, err = filmService.ListFilms(ctx, usecase.ListFilmsQuery{Cursor: "garbage"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidCursor)
+}
+
+func TestListFilms_Search(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	films := []domain.Film{
+		{ID: 1, Title: "Heat", Synopsis: "A heist <thriller>.", Score: 2.1, Credits: []domain.FilmCredit{
+			{Role: domain.CreditRoleActor, Person: domain.Person{Name: "Al Pacino"}},
+			{Role: domain.CreditRoleActor, Person: domain.Person{Name: "Robert De Niro"}},
+		}},
+		{ID: 2, Title: "Casino", Score: 1.3, Credits: []domain.FilmCredit{
+			{Role: domain.CreditRoleDirector, Person: domain.Person{Name: "Martin Scorsese"}},
+			{Role: domain.CreditRoleActor, Person: domain.Person{Name: "Robert De Niro"}},
+		}},
+	}
+	filters := repository.FilmFilters{Query: "robert heist", Limit: 2}
+	mockRepo.On("FindFilms", mock.Anything, filters).Return(films, int64(3), nil)
+
+	page, err := filmService.ListFilms(ctx, usecase.ListFilmsQuery{Query: " robert heist ", PageSize: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Films, 1)
+	assert.Equal(t, 2.1, page.Films[0].Score)
+	assert.Equal(t, "Al Pacino, <em>Robert</em> De Niro", page.Films[0].Highlights["cast"])
+	assert.Equal(t, "A <em>heist</em> &lt;thriller&gt;.", page.Films[0].Highlights["synopsis"])
+	assert.NotContains(t, page.Films[0].Highlights, "title")
+
+	// Relevance-ranked results have more pages but no keyset cursor
+	assert.True(t, page.HasNext())
+	assert.Empty(t, page.NextCursor)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestGetFilmDetails_Found(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	service := usecase.NewFilmService(mockRepo)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
 	expectedFilm := &domain.Film{
 		ID:    1,
@@ -87,9 +325,9 @@ func TestGetFilmDetails_Found(t *testing.T) {
 		User:  domain.User{ID: 2, Username: "creator"},
 	}
 
-	mockRepo.On("GetFilmByID", uint(1)).Return(expectedFilm, nil)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(expectedFilm, nil)
 
-	film, err := service.GetFilmDetails(1)
+	film, err := service.GetFilmDetails(ctx, 1)
 	assert.NoError(t, err)
 	assert.Equal(t, uint(1), film.ID)
 	assert.Equal(t, "creator", film.User.Username)
@@ -97,64 +335,293 @@ func TestGetFilmDetails_Found(t *testing.T) {
 }
 
 func TestGetFilmDetails_NotFound(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	service := usecase.NewFilmService(mockRepo)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
-	mockRepo.On("GetFilmByID", uint(99)).Return(nil, nil)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(99)).Return(nil, nil)
 
-	film, err := service.GetFilmDetails(99)
+	film, err := service.GetFilmDetails(ctx, 99)
 	assert.Nil(t, film)
 	assert.EqualError(t, err, "film not found")
 	mockRepo.AssertExpectations(t)
 }
 
 func TestCreateFilm_Success(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	filmService := usecase.NewFilmService(mockRepo)
+	mockGenreRepo := new(repository.MockGenreRepository)
+	mockPersonRepo := new(repository.MockPersonRepository)
+	filmService := usecase.NewFilmService(mockRepo, mockGenreRepo, mockPersonRepo, new(storage.MockBlobStore), testAuth)
+
+	action := domain.Genre{ID: 1, Name: "Action", Slug: "action"}
+	mockGenreRepo.On("FindGenresBySlugs", mock.Anything, []string{"action"}).Return([]domain.Genre{action}, nil)
 
-	mockRepo.On("CreateFilm", mock.AnythingOfType("*domain.Film")).
+	director := domain.Person{ID: 7, Name: "Michael Mann"}
+	actor := domain.Person{ID: 8, Name: "Al Pacino"}
+	mockPersonRepo.On("FindPeopleByIDs", mock.Anything, []uint{7, 8}).Return([]domain.Person{actor, director}, nil)
+
+	mockRepo.On("CreateFilm", mock.Anything, mock.AnythingOfType("*domain.Film"), mock.Anything).
 		Return(nil).
 		Run(func(args mock.Arguments) {
 			// Simulate setting an auto-increment ID
-			arg := args.Get(0).(*domain.Film)
+			arg := args.Get(1).(*domain.Film)
 			arg.ID = 100
 		})
 
-	res, err := filmService.CreateFilm(
-		"Unique Title", "Director", "Cast", "Action", "Some synopsis", time.Time{}, 1,
-	)
+	res, err := filmService.CreateFilm(ctx, usecase.CreateFilmData{
+		Title:  "Unique Title",
+		Genres: []string{"Action", "action"},
+		Credits: []usecase.CreditData{
+			{PersonID: 7, Role: domain.CreditRoleDirector},
+			{PersonID: 8, Role: domain.CreditRoleActor, Character: " Vincent Hanna "},
+		},
+		Synopsis: "Some synopsis",
+	}, 1)
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
 	assert.Equal(t, uint(100), res.ID)
+	assert.Equal(t, []domain.Genre{action}, res.Genres)
+	assert.Equal(t, []domain.FilmCredit{
+		{PersonID: 7, Role: domain.CreditRoleDirector, BillingOrder: 0, Person: director},
+		{PersonID: 8, Role: domain.CreditRoleActor, Character: "Vincent Hanna", BillingOrder: 1, Person: actor},
+	}, res.Credits)
 	mockRepo.AssertExpectations(t)
+	mockGenreRepo.AssertExpectations(t)
+	mockPersonRepo.AssertExpectations(t)
+}
+
+func TestCreateFilm_UnknownPerson(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	mockPersonRepo := new(repository.MockPersonRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), mockPersonRepo, new(storage.MockBlobStore), testAuth)
+
+	mockPersonRepo.On("FindPeopleByIDs", mock.Anything, []uint{7, 9}).Return([]domain.Person{{ID: 7, Name: "Michael Mann"}}, nil)
+
+	res, err := filmService.CreateFilm(ctx, usecase.CreateFilmData{
+		Title: "Heat",
+		Credits: []usecase.CreditData{
+			{PersonID: 7, Role: domain.CreditRoleDirector},
+			{PersonID: 9, Role: domain.CreditRoleActor},
+		},
+	}, 1)
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, usecase.ErrInvalidCredit)
+	assert.EqualError(t, err, "invalid credit: person 9 not found")
+	mockRepo.AssertNotCalled(t, "CreateFilm", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateFilm_InvalidCredit(t *testing.T) {
+	ctx := context.Background()
+	negative := -1
+	cases := map[string]struct {
+		credit usecase.CreditData
+		err    string
+	}{
+		"missing person":   {usecase.CreditData{Role: domain.CreditRoleActor}, "invalid credit: person_id is required"},
+		"unknown role":     {usecase.CreditData{PersonID: 1, Role: "producer"}, "invalid credit: role must be director, actor, writer or composer"},
+		"crew character":   {usecase.CreditData{PersonID: 1, Role: domain.CreditRoleWriter, Character: "Narrator"}, "invalid credit: character is only allowed for actors"},
+		"negative billing": {usecase.CreditData{PersonID: 1, Role: domain.CreditRoleActor, BillingOrder: &negative}, "invalid credit: billing_order must not be negative"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(repository.MockFilmRepository)
+			mockPersonRepo := new(repository.MockPersonRepository)
+			filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), mockPersonRepo, new(storage.MockBlobStore), testAuth)
+
+			res, err := filmService.CreateFilm(ctx, usecase.CreateFilmData{
+				Title:   "Heat",
+				Credits: []usecase.CreditData{tc.credit},
+			}, 1)
+			assert.Nil(t, res)
+			assert.ErrorIs(t, err, usecase.ErrInvalidCredit)
+			assert.EqualError(t, err, tc.err)
+			mockPersonRepo.AssertNotCalled(t, "FindPeopleByIDs", mock.Anything, mock.Anything)
+			mockRepo.AssertNotCalled(t, "CreateFilm", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateFilm_UnknownGenre(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	mockGenreRepo := new(repository.MockGenreRepository)
+	filmService := usecase.NewFilmService(mockRepo, mockGenreRepo, new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockGenreRepo.On("FindGenresBySlugs", mock.Anything, []string{"drama", "science-fiction"}).
+		Return([]domain.Genre{{ID: 2, Name: "Drama", Slug: "drama"}}, nil)
+
+	res, err := filmService.CreateFilm(ctx, usecase.CreateFilmData{
+		Title:  "Some Title",
+		Genres: []string{"Drama", "Science Fiction"},
+	}, 1)
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, usecase.ErrUnknownGenre)
+	assert.EqualError(t, err, "unknown genre 'science-fiction'")
+	mockRepo.AssertNotCalled(t, "CreateFilm", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestCreateFilm_DuplicateTitle(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	filmService := usecase.NewFilmService(mockRepo)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
-	mockRepo.On("CreateFilm", mock.Anything).
+	mockRepo.On("CreateFilm", mock.Anything, mock.Anything, mock.Anything).
 		Return(fmt.Errorf("film with title 'Duplicate' already exists"))
 
-	res, err := filmService.CreateFilm("Duplicate", "", "", "", "", time.Time{}, 1)
+	res, err := filmService.CreateFilm(ctx, usecase.CreateFilmData{Title: "Duplicate"}, 1)
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "film with title 'Duplicate' already exists")
 	mockRepo.AssertExpectations(t)
 }
 
 func TestCreateFilm_EmptyTitle(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	filmService := usecase.NewFilmService(mockRepo)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
-	res, err := filmService.CreateFilm("", "Dir", "Cast", "Genre", "Synopsis", time.Time{}, 1)
+	res, err := filmService.CreateFilm(ctx, usecase.CreateFilmData{Synopsis: "Synopsis"}, 1)
 	assert.Nil(t, res)
 	assert.EqualError(t, err, "title is required")
-	mockRepo.AssertNotCalled(t, "CreateFilm", mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateFilm", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateFilm_ReportsAllFieldErrors(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	mockGenreRepo := new(repository.MockGenreRepository)
+	mockPersonRepo := new(repository.MockPersonRepository)
+	filmService := usecase.NewFilmService(mockRepo, mockGenreRepo, mockPersonRepo, new(storage.MockBlobStore), testAuth)
+
+	mockGenreRepo.On("FindGenresBySlugs", mock.Anything, []string{"western"}).Return([]domain.Genre{}, nil)
+	mockPersonRepo.On("FindPeopleByIDs", mock.Anything, []uint{4}).Return([]domain.Person{}, nil)
+
+	res, err := filmService.CreateFilm(ctx, usecase.CreateFilmData{
+		Title:       "   ",
+		ReleaseDate: time.Date(9999, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Genres:      []string{"Western", "!!"},
+		Credits: []usecase.CreditData{
+			{PersonID: 1, Role: "producer"},
+			{PersonID: 4, Role: domain.CreditRoleActor},
+		},
+		Synopsis: strings.Repeat("a", usecase.FilmSynopsisMaxLen+1),
+	}, 1)
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, domain.ErrValidation)
+	assert.ErrorIs(t, err, usecase.ErrUnknownGenre)
+	assert.Equal(t, "validation_failed", domain.ErrorCode(err))
+
+	var typed *domain.Error
+	assert.True(t, errors.As(err, &typed))
+	assert.Equal(t, []domain.FieldError{
+		{Field: "title", Message: "title is required"},
+		{Field: "synopsis", Message: "synopsis must be at most 10000 characters"},
+		{Field: "release_date", Message: "release_date must be at most 10 years in the future"},
+		{Field: "genres[1]", Message: "genre must contain at least one letter or digit"},
+		{Field: "genres[0]", Message: "unknown genre 'western'"},
+		{Field: "credits[0].role", Message: "invalid credit: role must be director, actor, writer or composer"},
+		{Field: "credits[1].person_id", Message: "invalid credit: person 4 not found"},
+	}, typed.Fields)
+	mockRepo.AssertNotCalled(t, "CreateFilm", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateFilm_Limits(t *testing.T) {
+	ctx := context.Background()
+	cases := map[string]struct {
+		data  usecase.CreateFilmData
+		field string
+		err   string
+	}{
+		"long title": {
+			usecase.CreateFilmData{Title: strings.Repeat("t", usecase.FilmTitleMaxLen+1)},
+			"title", "title must be at most 255 characters",
+		},
+		"early release": {
+			usecase.CreateFilmData{Title: "Heat", ReleaseDate: time.Date(1850, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			"release_date", "release_date must not be before 1888-10-14",
+		},
+		"too many genres": {
+			usecase.CreateFilmData{Title: "Heat", Genres: make([]string, usecase.FilmMaxGenres+1)},
+			"genres", "a film can have at most 10 genres",
+		},
+		"long character": {
+			usecase.CreateFilmData{Title: "Heat", Credits: []usecase.CreditData{
+				{PersonID: 1, Role: domain.CreditRoleActor, Character: strings.Repeat("c", usecase.CharacterMaxLen+1)},
+			}},
+			"credits[0].character", "invalid credit: character must be at most 255 characters",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(repository.MockFilmRepository)
+			filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+			res, err := filmService.CreateFilm(ctx, tc.data, 1)
+			assert.Nil(t, res)
+			assert.EqualError(t, err, tc.err)
+			var typed *domain.Error
+			assert.True(t, errors.As(err, &typed))
+			assert.Equal(t, []domain.FieldError{{Field: tc.field, Message: tc.err}}, typed.Fields)
+			mockRepo.AssertNotCalled(t, "CreateFilm", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateFilm_NormalizesText(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockRepo.On("CreateFilm", mock.Anything, mock.AnythingOfType("*domain.Film"), mock.Anything).Return(nil)
+
+	res, err := filmService.CreateFilm(ctx, usecase.CreateFilmData{
+		Title:    "  The   Thin\tRed Line ",
+		Synopsis: "\r\n  First line.\r\nSecond line.  \n",
+	}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "The Thin Red Line", res.Title)
+	assert.Equal(t, "First line.\nSecond line.", res.Synopsis)
+}
+
+func TestUpdateFilm_ValidationErrors(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(&domain.Film{ID: 10, UserID: 5, Title: "Old Title"}, nil)
+
+	future := time.Now().AddDate(usecase.FilmMaxYearsAhead+1, 0, 0)
+	updated, err := service.UpdateFilm(ctx, 10, 5, domain.RoleUser, usecase.UpdateFilmData{
+		Title:       strPtr(""),
+		ReleaseDate: &future,
+	})
+	assert.Nil(t, updated)
+	assert.EqualError(t, err, "title is required; release_date must be at most 10 years in the future")
+	mockRepo.AssertNotCalled(t, "UpdateFilm", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateFilm_ClearReleaseDate(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	releaseDate := time.Date(1995, time.December, 15, 0, 0, 0, 0, time.UTC)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(&domain.Film{ID: 10, UserID: 5, Title: "Heat", ReleaseDate: &releaseDate}, nil)
+	mockRepo.On("UpdateFilm", mock.Anything, mock.MatchedBy(func(film *domain.Film) bool {
+		return film.ReleaseDate == nil
+	}), mock.Anything).Return(nil)
+
+	updated, err := service.UpdateFilm(ctx, 10, 5, domain.RoleUser, usecase.UpdateFilmData{ReleaseDate: &time.Time{}})
+	assert.NoError(t, err)
+	assert.Nil(t, updated.ReleaseDate)
+	mockRepo.AssertExpectations(t)
 }
 
 func TestUpdateFilm_Success(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	service := usecase.NewFilmService(mockRepo)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
 	existingFilm := &domain.Film{
 		ID:     10,
@@ -162,29 +629,103 @@ func TestUpdateFilm_Success(t *testing.T) {
 		Title:  "Old Title",
 	}
 
-	mockRepo.On("GetFilmByID", uint(10)).Return(existingFilm, nil)
-	mockRepo.On("UpdateFilm", mock.Anything).Return(nil)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(existingFilm, nil)
+	mockRepo.On("UpdateFilm", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	data := usecase.UpdateFilmData{
 		Title: strPtr("New Title"),
 	}
-	updatedFilm, err := service.UpdateFilm(10, 5, data)
+	updatedFilm, err := service.UpdateFilm(ctx, 10, 5, domain.RoleUser, data)
 	assert.NoError(t, err)
 	assert.Equal(t, "New Title", updatedFilm.Title)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUpdateFilm_StaleVersion(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(&domain.Film{ID: 10, UserID: 5, Title: "Old Title", Version: 4}, nil)
+
+	updated, err := service.UpdateFilm(ctx, 10, 5, domain.RoleUser, usecase.UpdateFilmData{
+		Title:   strPtr("New Title"),
+		Version: 3,
+	})
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+	assert.Equal(t, "film_modified", domain.ErrorCode(err))
+	mockRepo.AssertNotCalled(t, "UpdateFilm", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateFilm_ReplaceGenres(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	mockGenreRepo := new(repository.MockGenreRepository)
+	service := usecase.NewFilmService(mockRepo, mockGenreRepo, new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	existingFilm := &domain.Film{
+		ID:     10,
+		UserID: 5,
+		Title:  "Old Title",
+		Genres: []domain.Genre{{ID: 1, Name: "Action", Slug: "action"}},
+	}
+	drama := domain.Genre{ID: 2, Name: "Drama", Slug: "drama"}
+
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(existingFilm, nil)
+	mockGenreRepo.On("FindGenresBySlugs", mock.Anything, []string{"drama"}).Return([]domain.Genre{drama}, nil)
+	mockRepo.On("UpdateFilm", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	genres := []string{"drama"}
+	updatedFilm, err := service.UpdateFilm(ctx, 10, 5, domain.RoleUser, usecase.UpdateFilmData{Genres: &genres})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Genre{drama}, updatedFilm.Genres)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateFilm_ReplaceCredits(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	mockPersonRepo := new(repository.MockPersonRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), mockPersonRepo, new(storage.MockBlobStore), testAuth)
+
+	existingFilm := &domain.Film{
+		ID:      10,
+		UserID:  5,
+		Title:   "Old Title",
+		Credits: []domain.FilmCredit{{ID: 1, FilmID: 10, PersonID: 3, Role: domain.CreditRoleDirector}},
+	}
+	composer := domain.Person{ID: 4, Name: "Elliot Goldenthal"}
+
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(existingFilm, nil)
+	mockPersonRepo.On("FindPeopleByIDs", mock.Anything, []uint{4}).Return([]domain.Person{composer}, nil)
+	mockRepo.On("UpdateFilm", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	billing := 5
+	credits := []usecase.CreditData{{PersonID: 4, Role: domain.CreditRoleComposer, BillingOrder: &billing}}
+	updatedFilm, err := service.UpdateFilm(ctx, 10, 5, domain.RoleUser, usecase.UpdateFilmData{Credits: &credits})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.FilmCredit{
+		{PersonID: 4, Role: domain.CreditRoleComposer, BillingOrder: 5, Person: composer},
+	}, updatedFilm.Credits)
+
+	mockRepo.AssertExpectations(t)
+	mockPersonRepo.AssertExpectations(t)
+}
+
 func TestUpdateFilm_NotFound(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	service := usecase.NewFilmService(mockRepo)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
-	mockRepo.On("GetFilmByID", uint(99)).Return(nil, nil)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(99)).Return(nil, nil)
 
 	data := usecase.UpdateFilmData{
 		Title: strPtr("Whatever"),
 	}
-	film, err := service.UpdateFilm(99, 5, data)
+	film, err := service.UpdateFilm(ctx, 99, 5, domain.RoleUser, data)
 	assert.Nil(t, film)
 	assert.EqualError(t, err, "film not found")
 
@@ -192,53 +733,212 @@ func TestUpdateFilm_NotFound(t *testing.T) {
 }
 
 func TestUpdateFilm_Forbidden(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	service := usecase.NewFilmService(mockRepo)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
 	existingFilm := &domain.Film{ID: 10, UserID: 7, Title: "Owned by someone else"}
-	mockRepo.On("GetFilmByID", uint(10)).Return(existingFilm, nil)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(existingFilm, nil)
 
 	data := usecase.UpdateFilmData{Title: strPtr("New Title")}
-	film, err := service.UpdateFilm(10, 5, data)
+	film, err := service.UpdateFilm(ctx, 10, 5, domain.RoleUser, data)
 	assert.Nil(t, film)
 	assert.EqualError(t, err, "forbidden: only creator can update this film")
 }
 
+func TestUpdateFilm_EditorBypassesOwnership(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	existingFilm := &domain.Film{ID: 10, UserID: 7, Title: "Teh Godfather"}
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(existingFilm, nil)
+	mockRepo.On("UpdateFilm", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	film, err := service.UpdateFilm(ctx, 10, 5, domain.RoleEditor, usecase.UpdateFilmData{Title: strPtr("The Godfather")})
+	assert.NoError(t, err)
+	assert.Equal(t, "The Godfather", film.Title)
+	assert.Equal(t, uint(7), film.UserID, "the creator does not change")
+}
+
 func strPtr(s string) *string {
 	return &s
 }
 
 func TestDeleteFilm_Success(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	service := usecase.NewFilmService(mockRepo)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
 	existingFilm := &domain.Film{ID: 10, UserID: 5}
-	mockRepo.On("GetFilmByID", uint(10)).Return(existingFilm, nil)
-	mockRepo.On("DeleteFilmByID", uint(10)).Return(nil)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(existingFilm, nil)
+	mockRepo.On("DeleteFilmByID", mock.Anything, uint(10), mock.Anything).Return(nil)
 
-	err := service.DeleteFilm(10, 5)
+	err := service.DeleteFilm(ctx, 10, 5, domain.RoleUser)
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestDeleteFilm_NotFound(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	service := usecase.NewFilmService(mockRepo)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
-	mockRepo.On("GetFilmByID", uint(999)).Return(nil, nil)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(999)).Return(nil, nil)
 
-	err := service.DeleteFilm(999, 5)
+	err := service.DeleteFilm(ctx, 999, 5, domain.RoleUser)
 	assert.EqualError(t, err, "film not found")
 }
 
 func TestDeleteFilm_Forbidden(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockFilmRepository)
-	service := usecase.NewFilmService(mockRepo)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
 
 	existingFilm := &domain.Film{ID: 10, UserID: 7} // userID=7, not 5
-	mockRepo.On("GetFilmByID", uint(10)).Return(existingFilm, nil)
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(existingFilm, nil)
+
+	err := service.DeleteFilm(ctx, 10, 5, domain.RoleUser)
+	assert.EqualError(t, err, "forbidden: only creator can delete this film")
+}
+
+func TestDeleteFilm_RoleBypass(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	existingFilm := &domain.Film{ID: 10, UserID: 7}
+	mockRepo.On("GetFilmByID", mock.Anything, uint(10)).Return(existingFilm, nil)
+	mockRepo.On("DeleteFilmByID", mock.Anything, uint(10), mock.Anything).Return(nil)
 
-	err := service.DeleteFilm(10, 5)
+	// Editors can fix other users' films but not delete them
+	err := service.DeleteFilm(ctx, 10, 5, domain.RoleEditor)
 	assert.EqualError(t, err, "forbidden: only creator can delete this film")
+
+	assert.NoError(t, service.DeleteFilm(ctx, 10, 5, domain.RoleAdmin))
+	mockRepo.AssertCalled(t, "DeleteFilmByID", mock.Anything, uint(10), mock.Anything)
+}
+
+func TestListTrash(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	trashed := []domain.Film{{ID: 10, UserID: 5}}
+	mockRepo.On("FindTrashedFilms", mock.Anything, repository.TrashFilters{UserID: 5, Limit: 10, Offset: 10}).
+		Return(trashed, int64(21), nil)
+
+	page, err := service.ListTrash(ctx, 5, 2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, trashed, page.Films)
+	assert.Equal(t, int64(21), page.Total)
+	assert.True(t, page.HasNext())
+	assert.True(t, page.HasPrev())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRestoreFilm(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth)
+
+	trashed := &domain.Film{ID: 10, UserID: 7}
+	trashed.DeletedAt.Time = time.Now()
+	trashed.DeletedAt.Valid = true
+	mockRepo.On("GetTrashedFilmByID", mock.Anything, uint(10)).Return(trashed, nil)
+	mockRepo.On("GetTrashedFilmByID", mock.Anything, uint(11)).Return(nil, nil)
+	mockRepo.On("RestoreFilmByID", mock.Anything, uint(10), mock.Anything).Return(nil)
+
+	_, err := service.RestoreFilm(ctx, 11, 7, domain.RoleUser)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	assert.Equal(t, "film_not_in_trash", domain.ErrorCode(err))
+
+	_, err = service.RestoreFilm(ctx, 10, 5, domain.RoleEditor)
+	assert.EqualError(t, err, "forbidden: only creator can restore this film")
+	mockRepo.AssertNotCalled(t, "RestoreFilmByID", mock.Anything, uint(10), mock.Anything)
+
+	film, err := service.RestoreFilm(ctx, 10, 7, domain.RoleUser)
+	assert.NoError(t, err)
+	assert.False(t, film.DeletedAt.Valid)
+
+	_, err = service.RestoreFilm(ctx, 10, 5, domain.RoleAdmin)
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "RestoreFilmByID", 2)
+}
+
+func TestPurgeFilm(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockFilmRepository)
+	mockBlobs := new(storage.MockBlobStore)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), mockBlobs, testAuth)
+
+	mockRepo.On("GetTrashedFilmByID", mock.Anything, uint(10)).Return(&domain.Film{ID: 10}, nil)
+	mockRepo.On("GetTrashedFilmByID", mock.Anything, uint(11)).Return(nil, nil)
+	mockRepo.On("PurgeFilmByID", mock.Anything, uint(10), mock.Anything).Return([]domain.FilmImage{{
+		Key:        "films/10/abc/original.jpg",
+		Thumbnails: []domain.ImageThumbnail{{Key: "films/10/abc/small.jpg"}},
+	}}, nil)
+	mockBlobs.On("Delete", mock.Anything, "films/10/abc/original.jpg").Return(nil)
+	// A blob that cannot be deleted does not fail the purge
+	mockBlobs.On("Delete", mock.Anything, "films/10/abc/small.jpg").Return(errors.New("connection refused"))
+
+	// Films that were never deleted cannot be purged
+	err := service.PurgeFilm(ctx, 11, 1)
+	assert.Equal(t, "film_not_in_trash", domain.ErrorCode(err))
+	mockRepo.AssertNotCalled(t, "PurgeFilmByID", mock.Anything, uint(11), mock.Anything)
+
+	assert.NoError(t, service.PurgeFilm(ctx, 10, 1))
+	mockRepo.AssertExpectations(t)
+	mockBlobs.AssertExpectations(t)
+}
+
+func TestPurgeFilm_Failed(t *testing.T) {
+	mockRepo := new(repository.MockFilmRepository)
+	mockBlobs := new(storage.MockBlobStore)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), mockBlobs, testAuth)
+
+	mockRepo.On("GetTrashedFilmByID", mock.Anything, uint(10)).Return(&domain.Film{ID: 10}, nil)
+	mockRepo.On("PurgeFilmByID", mock.Anything, uint(10), mock.Anything).Return(nil, domain.Unavailable(errors.New("connection refused")))
+
+	// Blobs are only deleted once the purge is committed
+	assert.ErrorIs(t, service.PurgeFilm(context.Background(), 10, 1), domain.ErrUnavailable)
+	mockBlobs.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestRunTrashRetention(t *testing.T) {
+	mockRepo := new(repository.MockFilmRepository)
+	mockBlobs := new(storage.MockBlobStore)
+	service := usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), mockBlobs, testAuth)
+
+	retention := 30 * 24 * time.Hour
+	purged := make(chan time.Time, 10)
+	mockRepo.On("PurgeFilmsDeletedBefore", mock.Anything, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { purged <- args.Get(1).(time.Time) }).
+		Return(int64(2), []domain.FilmImage{{Key: "films/3/abc/original.png"}}, nil)
+	mockBlobs.On("Delete", mock.Anything, "films/3/abc/original.png").Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		usecase.RunTrashRetention(ctx, service, retention, 10*time.Millisecond)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case cutoff := <-purged:
+			assert.WithinDuration(t, time.Now().Add(-retention), cutoff, time.Minute)
+		case <-time.After(time.Second):
+			t.Fatal("trash was not purged")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("retention job did not stop")
+	}
+	mockBlobs.AssertCalled(t, "Delete", mock.Anything, "films/3/abc/original.png")
 }
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go-films-api/internal/domain"
+)
+
+// Limits of the film payload. Text limits are in characters and match the
+// columns they are stored in.
+const (
+	FilmTitleMaxLen    = 255
+	FilmSynopsisMaxLen = 10000
+	FilmMaxGenres      = 10
+	FilmMaxCredits     = 200
+	CharacterMaxLen    = 255
+
+	// Films may be announced this many years before their release
+	FilmMaxYearsAhead = 10
+)
+
+// EarliestReleaseDate is the date of the oldest surviving motion picture.
+var EarliestReleaseDate = time.Date(1888, time.October, 14, 0, 0, 0, 0, time.UTC)
+
+// fieldErrors collects every rejected field of a payload so they can be
+// reported together instead of one request at a time.
+type fieldErrors struct {
+	entries []fieldErrorEntry
+}
+
+type fieldErrorEntry struct {
+	domain.FieldError
+	code  string
+	cause error
+}
+
+func (e *fieldErrors) add(field, message string) {
+	e.addCause(field, message, "validation_failed", nil)
+}
+
+// addCause records a field error with its own code, wrapping cause so callers
+// can still match it with errors.Is.
+func (e *fieldErrors) addCause(field, message, code string, cause error) {
+	e.entries = append(e.entries, fieldErrorEntry{
+		FieldError: domain.FieldError{Field: field, Message: message},
+		code:       code,
+		cause:      cause,
+	})
+}
+
+func (e *fieldErrors) empty() bool {
+	return len(e.entries) == 0
+}
+
+// err returns nil when no field was rejected. A single error keeps its own
+// code; several are reported as one validation_failed error listing them all.
+func (e *fieldErrors) err() error {
+	if e.empty() {
+		return nil
+	}
+	if len(e.entries) == 1 {
+		entry := e.entries[0]
+		return &domain.Error{
+			Kind:    domain.ErrValidation,
+			Code:    entry.code,
+			Message: entry.Message,
+			Fields:  []domain.FieldError{entry.FieldError},
+			Err:     entry.cause,
+		}
+	}
+
+	fields := make([]domain.FieldError, len(e.entries))
+	for i, entry := range e.entries {
+		fields[i] = entry.FieldError
+	}
+	err := domain.ValidationFailed(fields)
+	for _, entry := range e.entries {
+		if entry.cause != nil {
+			err.Err = entry.cause
+			break
+		}
+	}
+	return err
+}
+
+// normalizeTitle trims a title and collapses runs of whitespace inside it,
+// so "  The   Matrix " and "The Matrix" are the same unique title.
+func normalizeTitle(title string) string {
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// normalizeSynopsis trims a synopsis and unifies its line endings.
+func normalizeSynopsis(synopsis string) string {
+	synopsis = strings.ReplaceAll(synopsis, "\r\n", "\n")
+	return strings.TrimSpace(synopsis)
+}
+
+func validateTitle(title string, errs *fieldErrors) string {
+	title = normalizeTitle(title)
+	if title == "" {
+		errs.add("title", "title is required")
+	} else if len([]rune(title)) > FilmTitleMaxLen {
+		errs.add("title", fmt.Sprintf("title must be at most %d characters", FilmTitleMaxLen))
+	}
+	return title
+}
+
+func validateSynopsis(synopsis string, errs *fieldErrors) string {
+	synopsis = normalizeSynopsis(synopsis)
+	if len([]rune(synopsis)) > FilmSynopsisMaxLen {
+		errs.add("synopsis", fmt.Sprintf("synopsis must be at most %d characters", FilmSynopsisMaxLen))
+	}
+	return synopsis
+}
+
+// optionalDate returns nil for a zero date, which stands for an unknown one.
+func optionalDate(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+// validateReleaseDate accepts an unknown (zero) date or one between
+// EarliestReleaseDate and FilmMaxYearsAhead years from now.
+func validateReleaseDate(date time.Time, errs *fieldErrors) {
+	if date.IsZero() {
+		return
+	}
+	if date.Before(EarliestReleaseDate) {
+		errs.add("release_date", "release_date must not be before "+EarliestReleaseDate.Format("2006-01-02"))
+	} else if latest := time.Now().AddDate(FilmMaxYearsAhead, 0, 0); date.After(latest) {
+		errs.add("release_date", fmt.Sprintf("release_date must be at most %d years in the future", FilmMaxYearsAhead))
+	}
+}
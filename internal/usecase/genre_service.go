@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+)
+
+type GenreService interface {
+	ListGenres(ctx context.Context) ([]domain.Genre, error)
+	GetGenre(ctx context.Context, slug string) (*domain.Genre, error)
+	CreateGenre(ctx context.Context, name string) (*domain.Genre, error)
+	UpdateGenre(ctx context.Context, slug, name string) (*domain.Genre, error)
+	DeleteGenre(ctx context.Context, slug string) error
+}
+
+var ErrUnknownGenre = errors.New("unknown genre")
+
+// GenreNameMaxLen matches the genres.name column
+const GenreNameMaxLen = 50
+
+type genreService struct {
+	genreRepo repository.GenreRepository
+}
+
+func NewGenreService(repo repository.GenreRepository) GenreService {
+	return &genreService{genreRepo: repo}
+}
+
+// Slugify turns a genre name into its URL-safe slug, e.g. "Science Fiction" -> "science-fiction".
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+func (s *genreService) ListGenres(ctx context.Context) ([]domain.Genre, error) {
+	return s.genreRepo.FindGenres(ctx)
+}
+
+func (s *genreService) GetGenre(ctx context.Context, slug string) (*domain.Genre, error) {
+	genre, err := s.genreRepo.GetGenreBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if genre == nil {
+		return nil, domain.NotFound("genre_not_found", "genre not found")
+	}
+	return genre, nil
+}
+
+func (s *genreService) CreateGenre(ctx context.Context, name string) (*domain.Genre, error) {
+	name, slug, err := normalizeGenreName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.genreRepo.GetGenreBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if existing != nil {
+		return nil, domain.Conflict("genre_exists", fmt.Sprintf("genre '%s' already exists", existing.Name))
+	}
+
+	genre := &domain.Genre{Name: name, Slug: slug}
+	if err := s.genreRepo.CreateGenre(ctx, genre); err != nil {
+		return nil, err
+	}
+	return genre, nil
+}
+
+func (s *genreService) UpdateGenre(ctx context.Context, slug, name string) (*domain.Genre, error) {
+	genre, err := s.genreRepo.GetGenreBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if genre == nil {
+		return nil, domain.NotFound("genre_not_found", "genre not found")
+	}
+
+	name, newSlug, err := normalizeGenreName(name)
+	if err != nil {
+		return nil, err
+	}
+	if newSlug != genre.Slug {
+		existing, err := s.genreRepo.GetGenreBySlug(ctx, newSlug)
+		if err != nil {
+			return nil, fmt.Errorf("repository error: %w", err)
+		}
+		if existing != nil {
+			return nil, domain.Conflict("genre_exists", fmt.Sprintf("genre '%s' already exists", existing.Name))
+		}
+	}
+
+	genre.Name = name
+	genre.Slug = newSlug
+	if err := s.genreRepo.UpdateGenre(ctx, genre); err != nil {
+		return nil, err
+	}
+	return genre, nil
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, slug string) error {
+	genre, err := s.genreRepo.GetGenreBySlug(ctx, slug)
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	if genre == nil {
+		return domain.NotFound("genre_not_found", "genre not found")
+	}
+	return s.genreRepo.DeleteGenreByID(ctx, genre.ID)
+}
+
+func normalizeGenreName(name string) (string, string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", "", domain.Invalid("name", "name is required")
+	}
+	if len([]rune(name)) > GenreNameMaxLen {
+		return "", "", domain.Invalid("name", fmt.Sprintf("name must be at most %d characters", GenreNameMaxLen))
+	}
+	slug := Slugify(name)
+	if slug == "" {
+		return "", "", domain.Invalid("name", "name must contain at least one letter or digit")
+	}
+	return name, slug, nil
+}
+
+// resolveGenres looks up the genres referenced by name or slug. Empty and
+// unknown references are added to errs; only repository failures are returned.
+func resolveGenres(ctx context.Context, repo repository.GenreRepository, refs []string, errs *fieldErrors) ([]domain.Genre, error) {
+	if len(refs) > FilmMaxGenres {
+		errs.add("genres", fmt.Sprintf("a film can have at most %d genres", FilmMaxGenres))
+		return nil, nil
+	}
+
+	slugs := make([]string, 0, len(refs))
+	fields := map[string]string{}
+	for i, ref := range refs {
+		slug := Slugify(ref)
+		field := fmt.Sprintf("genres[%d]", i)
+		if slug == "" {
+			errs.add(field, "genre must contain at least one letter or digit")
+			continue
+		}
+		if _, seen := fields[slug]; !seen {
+			fields[slug] = field
+			slugs = append(slugs, slug)
+		}
+	}
+	if len(slugs) == 0 {
+		return []domain.Genre{}, nil
+	}
+
+	genres, err := repo.FindGenresBySlugs(ctx, slugs)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	found := map[string]bool{}
+	for _, g := range genres {
+		found[g.Slug] = true
+	}
+	for _, slug := range slugs {
+		if !found[slug] {
+			errs.addCause(fields[slug], fmt.Sprintf("unknown genre '%s'", slug), "unknown_genre", ErrUnknownGenre)
+		}
+	}
+	return genres, nil
+}
@@ -0,0 +1,104 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/usecase"
+)
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "science-fiction", usecase.Slugify("  Science   Fiction "))
+	assert.Equal(t, "sci-fi", usecase.Slugify("Sci-Fi"))
+	assert.Equal(t, "film-noir", usecase.Slugify("Film_Noir!"))
+	assert.Equal(t, "", usecase.Slugify("--"))
+}
+
+func TestCreateGenre_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockGenreRepository)
+	service := usecase.NewGenreService(mockRepo)
+
+	mockRepo.On("GetGenreBySlug", mock.Anything, "science-fiction").Return(nil, nil)
+	mockRepo.On("CreateGenre", mock.Anything, mock.AnythingOfType("*domain.Genre")).Return(nil)
+
+	genre, err := service.CreateGenre(ctx, " Science  Fiction ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Science Fiction", genre.Name)
+	assert.Equal(t, "science-fiction", genre.Slug)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateGenre_AlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockGenreRepository)
+	service := usecase.NewGenreService(mockRepo)
+
+	existing := &domain.Genre{ID: 1, Name: "Sci Fi", Slug: "sci-fi"}
+	mockRepo.On("GetGenreBySlug", mock.Anything, "sci-fi").Return(existing, nil)
+
+	genre, err := service.CreateGenre(ctx, "Sci-Fi")
+	assert.Nil(t, genre)
+	assert.EqualError(t, err, "genre 'Sci Fi' already exists")
+	mockRepo.AssertNotCalled(t, "CreateGenre", mock.Anything, mock.Anything)
+}
+
+func TestCreateGenre_InvalidName(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockGenreRepository)
+	service := usecase.NewGenreService(mockRepo)
+
+	_, err := service.CreateGenre(ctx, "   ")
+	assert.EqualError(t, err, "name is required")
+
+	_, err = service.CreateGenre(ctx, "!!!")
+	assert.EqualError(t, err, "name must contain at least one letter or digit")
+
+	_, err = service.CreateGenre(ctx, "This genre name is definitely longer than fifty chars")
+	assert.EqualError(t, err, "name must be at most 50 characters")
+}
+
+func TestUpdateGenre_Rename(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockGenreRepository)
+	service := usecase.NewGenreService(mockRepo)
+
+	existing := &domain.Genre{ID: 3, Name: "SciFi", Slug: "scifi"}
+	mockRepo.On("GetGenreBySlug", mock.Anything, "scifi").Return(existing, nil)
+	mockRepo.On("GetGenreBySlug", mock.Anything, "science-fiction").Return(nil, nil)
+	mockRepo.On("UpdateGenre", mock.Anything, existing).Return(nil)
+
+	genre, err := service.UpdateGenre(ctx, "scifi", "Science Fiction")
+	assert.NoError(t, err)
+	assert.Equal(t, "science-fiction", genre.Slug)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateGenre_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockGenreRepository)
+	service := usecase.NewGenreService(mockRepo)
+
+	mockRepo.On("GetGenreBySlug", mock.Anything, "missing").Return(nil, nil)
+
+	genre, err := service.UpdateGenre(ctx, "missing", "Whatever")
+	assert.Nil(t, genre)
+	assert.EqualError(t, err, "genre not found")
+}
+
+func TestDeleteGenre_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockGenreRepository)
+	service := usecase.NewGenreService(mockRepo)
+
+	mockRepo.On("GetGenreBySlug", mock.Anything, "drama").Return(&domain.Genre{ID: 2, Slug: "drama"}, nil)
+	mockRepo.On("DeleteGenreByID", mock.Anything, uint(2)).Return(nil)
+
+	assert.NoError(t, service.DeleteGenre(ctx, "drama"))
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"go-films-api/internal/domain"
+)
+
+// snippetRadius is the number of characters kept on each side of the first
+// match when a long field is cut down to a snippet.
+const snippetRadius = 80
+
+// highlightFilm fills film.Highlights with every searchable field that contains
+// one of the query terms, HTML-escaped and with the matches wrapped in <em>.
+func highlightFilm(film *domain.Film, query string) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return
+	}
+
+	fields := map[string]string{
+		"title":    film.Title,
+		"synopsis": film.Synopsis,
+		"cast":     strings.Join(film.CreditNames(domain.CreditRoleActor), ", "),
+		"director": strings.Join(film.CreditNames(domain.CreditRoleDirector), ", "),
+	}
+	for name, value := range fields {
+		if snippet, ok := highlight(value, terms); ok {
+			if film.Highlights == nil {
+				film.Highlights = map[string]string{}
+			}
+			film.Highlights[name] = snippet
+		}
+	}
+}
+
+// searchTerms splits a full-text query into lower-cased words.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func highlight(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		// Lower-casing changed the length, fall back to a byte-for-byte match
+		lower = runes
+	}
+
+	type span struct{ start, end int }
+	var spans []span
+	for i := 0; i < len(lower); {
+		matched := 0
+		if i == 0 || !isWordRune(lower[i-1]) {
+			for _, term := range terms {
+				t := []rune(term)
+				end := i + len(t)
+				if hasPrefixAt(lower, t, i) && (end == len(lower) || !isWordRune(lower[end])) && len(t) > matched {
+					matched = len(t)
+				}
+			}
+		}
+		if matched > 0 {
+			spans = append(spans, span{i, i + matched})
+			i += matched
+		} else {
+			i++
+		}
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(runes)
+	if to > 2*snippetRadius {
+		from = max(spans[0].start-snippetRadius, 0)
+		to = min(spans[0].end+snippetRadius, len(runes))
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, s := range spans {
+		if s.start < from || s.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:s.start])))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(string(runes[s.start:s.end])))
+		b.WriteString("</em>")
+		pos = s.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:to])))
+	if to < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+func hasPrefixAt(text, prefix []rune, at int) bool {
+	if at+len(prefix) > len(text) {
+		return false
+	}
+	for i, r := range prefix {
+		if text[at+i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
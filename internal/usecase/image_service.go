@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"log"
+	"net/http"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
+)
+
+type ImageService interface {
+	UploadFilmImage(ctx context.Context, filmID, userID uint, role domain.Role, upload ImageUpload) (*domain.FilmImage, error)
+	DeleteFilmImage(ctx context.Context, filmID, imageID, userID uint, role domain.Role) error
+}
+
+// Upload limits
+const (
+	MaxImageSize   = 10 << 20
+	MaxImagePixels = 40_000_000
+)
+
+// imageFormats maps the accepted content types to the file extension of the
+// original and of its thumbnails. GIFs get PNG thumbnails, which keep their
+// transparency.
+var imageFormats = map[string]struct{ ext, thumbnailType string }{
+	"image/jpeg": {".jpg", "image/jpeg"},
+	"image/png":  {".png", "image/png"},
+	"image/gif":  {".gif", "image/png"},
+}
+
+var thumbnailExts = map[string]string{"image/jpeg": ".jpg", "image/png": ".png"}
+
+// ImageUpload is an uploaded image file. Its content type is sniffed from
+// Data rather than taken from the client.
+type ImageUpload struct {
+	Kind domain.ImageKind
+	Data []byte
+}
+
+type imageService struct {
+	imageRepo repository.ImageRepository
+	filmRepo  repository.FilmRepository
+	blobs     storage.BlobStore
+}
+
+func NewImageService(repo repository.ImageRepository, filmRepo repository.FilmRepository, blobs storage.BlobStore) ImageService {
+	return &imageService{imageRepo: repo, filmRepo: filmRepo, blobs: blobs}
+}
+
+// UploadFilmImage stores an image of a film together with a thumbnail in
+// every ThumbnailSizes. Whoever may update the film may add images to it.
+func (s *imageService) UploadFilmImage(ctx context.Context, filmID, userID uint, role domain.Role, upload ImageUpload) (*domain.FilmImage, error) {
+	if upload.Kind == "" {
+		upload.Kind = domain.ImageKindPoster
+	}
+	if !upload.Kind.IsValid() {
+		return nil, domain.Invalid("kind", "kind must be poster, backdrop or still")
+	}
+	if err := s.checkFilmAccess(ctx, filmID, userID, role); err != nil {
+		return nil, err
+	}
+
+	if len(upload.Data) == 0 {
+		return nil, domain.Invalid("image", "image is required")
+	}
+	if len(upload.Data) > MaxImageSize {
+		return nil, domain.Invalid("image", fmt.Sprintf("image must be at most %d MiB", MaxImageSize>>20))
+	}
+	contentType := http.DetectContentType(upload.Data)
+	format, ok := imageFormats[contentType]
+	if !ok {
+		return nil, domain.UnsupportedMediaType("unsupported_image_type", "image must be a JPEG, PNG or GIF file")
+	}
+
+	// Check the dimensions before decoding, so a small file cannot make the
+	// server allocate a huge image
+	config, _, err := image.DecodeConfig(bytes.NewReader(upload.Data))
+	if err != nil || config.Width < 1 || config.Height < 1 {
+		return nil, domain.Invalid("image", "image could not be decoded")
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, domain.Invalid("image", fmt.Sprintf("image must have at most %d megapixels", MaxImagePixels/1_000_000))
+	}
+	img, _, err := image.Decode(bytes.NewReader(upload.Data))
+	if err != nil {
+		return nil, domain.Invalid("image", "image could not be decoded")
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("films/%d/%s/", filmID, token)
+
+	filmImage := &domain.FilmImage{
+		FilmID:      filmID,
+		Kind:        upload.Kind,
+		ContentType: contentType,
+		Width:       config.Width,
+		Height:      config.Height,
+		Size:        int64(len(upload.Data)),
+		Key:         prefix + "original" + format.ext,
+	}
+	filmImage.URL = s.blobs.URL(filmImage.Key)
+	if err := s.put(ctx, filmImage.Key, upload.Data, contentType); err != nil {
+		return nil, err
+	}
+
+	// Each thumbnail is scaled from the previous, larger one
+	scaled := toRGBA(img)
+	for _, size := range ThumbnailSizes {
+		width, height := thumbnailBounds(scaled.Rect.Dx(), scaled.Rect.Dy(), size.Max)
+		scaled = scaleDown(scaled, width, height)
+
+		var buf bytes.Buffer
+		if format.thumbnailType == "image/jpeg" {
+			err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&buf, scaled)
+		}
+		if err != nil {
+			deleteImageBlobs(ctx, s.blobs, *filmImage)
+			return nil, fmt.Errorf("could not encode thumbnail: %w", err)
+		}
+
+		thumbnail := domain.ImageThumbnail{
+			Name:   size.Name,
+			Key:    prefix + size.Name + thumbnailExts[format.thumbnailType],
+			Width:  width,
+			Height: height,
+		}
+		thumbnail.URL = s.blobs.URL(thumbnail.Key)
+		if err := s.put(ctx, thumbnail.Key, buf.Bytes(), format.thumbnailType); err != nil {
+			deleteImageBlobs(ctx, s.blobs, *filmImage)
+			return nil, err
+		}
+		filmImage.Thumbnails = append(filmImage.Thumbnails, thumbnail)
+	}
+
+	if err := s.imageRepo.CreateFilmImage(ctx, filmImage); err != nil {
+		deleteImageBlobs(ctx, s.blobs, *filmImage)
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	return filmImage, nil
+}
+
+// DeleteFilmImage removes an image and its thumbnails. Blobs that cannot be
+// deleted are logged and left behind, since the image is gone either way.
+func (s *imageService) DeleteFilmImage(ctx context.Context, filmID, imageID, userID uint, role domain.Role) error {
+	if err := s.checkFilmAccess(ctx, filmID, userID, role); err != nil {
+		return err
+	}
+	filmImage, err := s.imageRepo.GetFilmImage(ctx, filmID, imageID)
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	if filmImage == nil {
+		return domain.NotFound("image_not_found", "image not found")
+	}
+
+	if err := s.imageRepo.DeleteFilmImageByID(ctx, imageID); err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	deleteImageBlobs(ctx, s.blobs, *filmImage)
+	return nil
+}
+
+func (s *imageService) checkFilmAccess(ctx context.Context, filmID, userID uint, role domain.Role) error {
+	film, err := s.filmRepo.GetFilmByID(ctx, filmID)
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	if film == nil {
+		return domain.NotFound("film_not_found", "film not found")
+	}
+	if film.UserID != userID && !role.Can(domain.PermEditAnyFilm) {
+		return domain.Forbidden("not_film_creator", "forbidden: only creator can change the images of this film")
+	}
+	return nil
+}
+
+func (s *imageService) put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := s.blobs.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return domain.Unavailable(fmt.Errorf("could not store image: %w", err))
+	}
+	return nil
+}
+
+// deleteImageBlobs removes the originals and the thumbnails of images that
+// were stored so far. It runs even when ctx was canceled, since it cleans up
+// after a failed or finished request.
+func deleteImageBlobs(ctx context.Context, blobs storage.BlobStore, images ...domain.FilmImage) {
+	ctx = context.WithoutCancel(ctx)
+	var keys []string
+	for _, image := range images {
+		keys = append(keys, image.Key)
+		for _, thumbnail := range image.Thumbnails {
+			keys = append(keys, thumbnail.Key)
+		}
+	}
+	for _, key := range keys {
+		if err := blobs.Delete(ctx, key); err != nil {
+			log.Printf("could not delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// randomToken returns a random hex string that keeps the blob keys of
+// different uploads apart and hard to guess.
+func randomToken() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate image key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,198 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
+	"go-films-api/internal/usecase"
+)
+
+func pngImage(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func newImageService() (usecase.ImageService, *repository.MockImageRepository, *repository.MockFilmRepository, *storage.MockBlobStore) {
+	mockRepo := new(repository.MockImageRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	mockBlobs := new(storage.MockBlobStore)
+	return usecase.NewImageService(mockRepo, mockFilmRepo, mockBlobs), mockRepo, mockFilmRepo, mockBlobs
+}
+
+func TestUploadFilmImage(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo, mockFilmRepo, mockBlobs := newImageService()
+
+	data := pngImage(2000, 1000)
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1, UserID: 2}, nil)
+	mockBlobs.On("Put", mock.Anything, mock.Anything, mock.Anything, "image/png").Return(nil)
+	mockRepo.On("CreateFilmImage", mock.Anything, mock.Anything).Return(nil)
+
+	filmImage, err := service.UploadFilmImage(ctx, 1, 2, domain.RoleUser, usecase.ImageUpload{Data: data})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImageKindPoster, filmImage.Kind)
+	assert.Equal(t, "image/png", filmImage.ContentType)
+	assert.Equal(t, 2000, filmImage.Width)
+	assert.Equal(t, int64(len(data)), filmImage.Size)
+	assert.Regexp(t, `^films/1/[0-9a-f]{16}/original\.png$`, filmImage.Key)
+	assert.Equal(t, "https://cdn.example.com/"+filmImage.Key, filmImage.URL)
+
+	prefix := regexp.MustCompile(`original\.png$`).ReplaceAllString(filmImage.Key, "")
+	assert.Equal(t, []domain.ImageThumbnail{
+		{Name: "large", Key: prefix + "large.png", URL: "https://cdn.example.com/" + prefix + "large.png", Width: 1024, Height: 512},
+		{Name: "medium", Key: prefix + "medium.png", URL: "https://cdn.example.com/" + prefix + "medium.png", Width: 480, Height: 240},
+		{Name: "small", Key: prefix + "small.png", URL: "https://cdn.example.com/" + prefix + "small.png", Width: 160, Height: 80},
+	}, filmImage.Thumbnails)
+
+	// The original is stored as uploaded, the thumbnails are real PNGs
+	mockBlobs.AssertCalled(t, "Put", mock.Anything, filmImage.Key, data, "image/png")
+	for _, call := range mockBlobs.Calls[1:] {
+		config, err := png.DecodeConfig(bytes.NewReader(call.Arguments.Get(2).([]byte)))
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, config.Width, 1024)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUploadFilmImage_SmallImagesAreNotScaledUp(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo, mockFilmRepo, mockBlobs := newImageService()
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1, UserID: 2}, nil)
+	mockBlobs.On("Put", mock.Anything, mock.Anything, mock.Anything, "image/png").Return(nil)
+	mockRepo.On("CreateFilmImage", mock.Anything, mock.Anything).Return(nil)
+
+	filmImage, err := service.UploadFilmImage(ctx, 1, 9, domain.RoleEditor, usecase.ImageUpload{
+		Kind: domain.ImageKindStill,
+		Data: pngImage(300, 200),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImageKindStill, filmImage.Kind)
+	sizes := map[string][2]int{}
+	for _, thumbnail := range filmImage.Thumbnails {
+		sizes[thumbnail.Name] = [2]int{thumbnail.Width, thumbnail.Height}
+	}
+	assert.Equal(t, map[string][2]int{"large": {300, 200}, "medium": {300, 200}, "small": {160, 106}}, sizes)
+}
+
+func TestUploadFilmImage_Rejected(t *testing.T) {
+	ctx := context.Background()
+	// A GIF header claiming 65535x65535 pixels
+	hugeGIF := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+
+	tests := []struct {
+		name   string
+		userID uint
+		upload usecase.ImageUpload
+		kind   error
+		code   string
+	}{
+		{"not the creator", 3, usecase.ImageUpload{Data: pngImage(10, 10)}, domain.ErrForbidden, "not_film_creator"},
+		{"invalid kind", 2, usecase.ImageUpload{Kind: "banner", Data: pngImage(10, 10)}, domain.ErrValidation, "validation_failed"},
+		{"empty", 2, usecase.ImageUpload{}, domain.ErrValidation, "validation_failed"},
+		{"not an image", 2, usecase.ImageUpload{Data: []byte("%PDF-1.7")}, domain.ErrUnsupportedMediaType, "unsupported_image_type"},
+		{"too many pixels", 2, usecase.ImageUpload{Data: hugeGIF}, domain.ErrValidation, "validation_failed"},
+		{"corrupt", 2, usecase.ImageUpload{Data: pngImage(10, 10)[:60]}, domain.ErrValidation, "validation_failed"},
+		{"too large", 2, usecase.ImageUpload{Data: append(pngImage(10, 10), make([]byte, usecase.MaxImageSize)...)}, domain.ErrValidation, "validation_failed"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, mockFilmRepo, mockBlobs := newImageService()
+			mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1, UserID: 2}, nil)
+
+			_, err := service.UploadFilmImage(ctx, 1, tc.userID, domain.RoleUser, tc.upload)
+
+			assert.ErrorIs(t, err, tc.kind)
+			assert.Equal(t, tc.code, domain.ErrorCode(err))
+			mockBlobs.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUploadFilmImage_FilmNotFound(t *testing.T) {
+	ctx := context.Background()
+	service, _, mockFilmRepo, _ := newImageService()
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(nil, nil)
+
+	_, err := service.UploadFilmImage(ctx, 1, 2, domain.RoleUser, usecase.ImageUpload{Data: pngImage(10, 10)})
+
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestUploadFilmImage_CleansUpOnFailure(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo, mockFilmRepo, mockBlobs := newImageService()
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1, UserID: 2}, nil)
+	mockBlobs.On("Put", mock.Anything, mock.Anything, mock.Anything, "image/png").Return(nil)
+	mockBlobs.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("CreateFilmImage", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	_, err := service.UploadFilmImage(ctx, 1, 2, domain.RoleUser, usecase.ImageUpload{Data: pngImage(10, 10)})
+
+	assert.Error(t, err)
+	// The original and all three thumbnails are removed again
+	mockBlobs.AssertNumberOfCalls(t, "Delete", 4)
+}
+
+func TestUploadFilmImage_BlobStoreDown(t *testing.T) {
+	ctx := context.Background()
+	service, _, mockFilmRepo, mockBlobs := newImageService()
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1, UserID: 2}, nil)
+	mockBlobs.On("Put", mock.Anything, mock.Anything, mock.Anything, "image/png").Return(errors.New("connection refused"))
+
+	_, err := service.UploadFilmImage(ctx, 1, 2, domain.RoleUser, usecase.ImageUpload{Data: pngImage(10, 10)})
+
+	assert.ErrorIs(t, err, domain.ErrUnavailable)
+}
+
+func TestDeleteFilmImage(t *testing.T) {
+	ctx := context.Background()
+	service, mockRepo, mockFilmRepo, mockBlobs := newImageService()
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1, UserID: 2}, nil)
+	mockRepo.On("GetFilmImage", mock.Anything, uint(1), uint(7)).Return(&domain.FilmImage{
+		ID:         7,
+		FilmID:     1,
+		Key:        "films/1/abc/original.jpg",
+		Thumbnails: []domain.ImageThumbnail{{Name: "small", Key: "films/1/abc/small.jpg"}},
+	}, nil)
+	mockRepo.On("GetFilmImage", mock.Anything, uint(1), uint(8)).Return(nil, nil)
+	mockRepo.On("DeleteFilmImageByID", mock.Anything, uint(7)).Return(nil)
+	mockBlobs.On("Delete", mock.Anything, "films/1/abc/original.jpg").Return(nil)
+	// A blob that cannot be deleted does not fail the request
+	mockBlobs.On("Delete", mock.Anything, "films/1/abc/small.jpg").Return(errors.New("timeout"))
+
+	assert.NoError(t, service.DeleteFilmImage(ctx, 1, 7, 2, domain.RoleUser))
+
+	err := service.DeleteFilmImage(ctx, 1, 8, 2, domain.RoleUser)
+	assert.Equal(t, "image_not_found", domain.ErrorCode(err))
+
+	err = service.DeleteFilmImage(ctx, 1, 7, 3, domain.RoleUser)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+
+	mockRepo.AssertExpectations(t)
+	mockBlobs.AssertExpectations(t)
+}
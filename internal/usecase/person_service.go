@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+)
+
+type PersonService interface {
+	ListPeople(ctx context.Context, name string, page, pageSize int) (*PersonPage, error)
+	GetPerson(ctx context.Context, id uint) (*domain.Person, error)
+	CreatePerson(ctx context.Context, name string) (*domain.Person, error)
+	UpdatePerson(ctx context.Context, id uint, name string) (*domain.Person, error)
+	DeletePerson(ctx context.Context, id uint) error
+}
+
+var ErrInvalidCredit = errors.New("invalid credit")
+
+// PersonNameMaxLen matches the people.name column
+const PersonNameMaxLen = 255
+
+// CreditData references an existing person credited on a film. BillingOrder
+// defaults to the credit's position in the list.
+type CreditData struct {
+	PersonID     uint
+	Role         domain.CreditRole
+	Character    string
+	BillingOrder *int
+}
+
+// PersonPage is a single page of people together with the total number of matches.
+type PersonPage struct {
+	People   []domain.Person
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+type personService struct {
+	personRepo repository.PersonRepository
+}
+
+func NewPersonService(repo repository.PersonRepository) PersonService {
+	return &personService{personRepo: repo}
+}
+
+func (s *personService) ListPeople(ctx context.Context, name string, page, pageSize int) (*PersonPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	people, total, err := s.personRepo.FindPeople(ctx, repository.PersonFilters{
+		Name:   strings.TrimSpace(name),
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PersonPage{
+		People:   people,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func (s *personService) GetPerson(ctx context.Context, id uint) (*domain.Person, error) {
+	person, err := s.personRepo.GetPersonByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if person == nil {
+		return nil, domain.NotFound("person_not_found", "person not found")
+	}
+	return person, nil
+}
+
+func (s *personService) CreatePerson(ctx context.Context, name string) (*domain.Person, error) {
+	name, err := normalizePersonName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	person := &domain.Person{Name: name}
+	if err := s.personRepo.CreatePerson(ctx, person); err != nil {
+		return nil, err
+	}
+	return person, nil
+}
+
+func (s *personService) UpdatePerson(ctx context.Context, id uint, name string) (*domain.Person, error) {
+	person, err := s.personRepo.GetPersonByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if person == nil {
+		return nil, domain.NotFound("person_not_found", "person not found")
+	}
+
+	person.Name, err = normalizePersonName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.personRepo.UpdatePerson(ctx, person); err != nil {
+		return nil, err
+	}
+	return person, nil
+}
+
+func (s *personService) DeletePerson(ctx context.Context, id uint) error {
+	person, err := s.personRepo.GetPersonByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	if person == nil {
+		return domain.NotFound("person_not_found", "person not found")
+	}
+	return s.personRepo.DeletePersonByID(ctx, id)
+}
+
+func normalizePersonName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", domain.Invalid("name", "name is required")
+	}
+	if len([]rune(name)) > PersonNameMaxLen {
+		return "", domain.Invalid("name", fmt.Sprintf("name must be at most %d characters", PersonNameMaxLen))
+	}
+	return name, nil
+}
+
+// invalidCredit records a rejected credit field as an error wrapping ErrInvalidCredit.
+func invalidCredit(errs *fieldErrors, field, reason string) {
+	errs.addCause(field, ErrInvalidCredit.Error()+": "+reason, "invalid_credit", ErrInvalidCredit)
+}
+
+// resolveCredits validates the credits and loads the people they reference.
+// Invalid credits are added to errs; only repository failures are returned.
+func resolveCredits(ctx context.Context, repo repository.PersonRepository, data []CreditData, errs *fieldErrors) ([]domain.FilmCredit, error) {
+	if len(data) > FilmMaxCredits {
+		errs.add("credits", fmt.Sprintf("a film can have at most %d credits", FilmMaxCredits))
+		return nil, nil
+	}
+
+	credits := make([]domain.FilmCredit, 0, len(data))
+	var ids []uint
+	var positions []int // index in data of each valid credit
+	for i, d := range data {
+		field := fmt.Sprintf("credits[%d]", i)
+		valid := true
+		if d.PersonID == 0 {
+			invalidCredit(errs, field+".person_id", "person_id is required")
+			valid = false
+		}
+		if !d.Role.IsValid() {
+			invalidCredit(errs, field+".role", "role must be director, actor, writer or composer")
+			valid = false
+		}
+		character := strings.Join(strings.Fields(d.Character), " ")
+		if character != "" && d.Role != domain.CreditRoleActor {
+			invalidCredit(errs, field+".character", "character is only allowed for actors")
+			valid = false
+		} else if len([]rune(character)) > CharacterMaxLen {
+			invalidCredit(errs, field+".character", fmt.Sprintf("character must be at most %d characters", CharacterMaxLen))
+			valid = false
+		}
+		billingOrder := i
+		if d.BillingOrder != nil {
+			if *d.BillingOrder < 0 {
+				invalidCredit(errs, field+".billing_order", "billing_order must not be negative")
+				valid = false
+			}
+			billingOrder = *d.BillingOrder
+		}
+		if !valid {
+			continue
+		}
+
+		credits = append(credits, domain.FilmCredit{
+			PersonID:     d.PersonID,
+			Role:         d.Role,
+			Character:    character,
+			BillingOrder: billingOrder,
+		})
+		ids = append(ids, d.PersonID)
+		positions = append(positions, i)
+	}
+	if len(ids) == 0 {
+		return credits, nil
+	}
+
+	people, err := repo.FindPeopleByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	byID := make(map[uint]domain.Person, len(people))
+	for _, p := range people {
+		byID[p.ID] = p
+	}
+	for i := range credits {
+		person, ok := byID[credits[i].PersonID]
+		if !ok {
+			invalidCredit(errs, fmt.Sprintf("credits[%d].person_id", positions[i]), fmt.Sprintf("person %d not found", credits[i].PersonID))
+			continue
+		}
+		credits[i].Person = person
+	}
+	return credits, nil
+}
@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/usecase"
+)
+
+func TestListPeople_Paginated(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	people := []domain.Person{{ID: 1, Name: "Robert De Niro"}}
+	mockRepo.On("FindPeople", mock.Anything, repository.PersonFilters{Name: "robert", Limit: 10, Offset: 10}).
+		Return(people, int64(11), nil)
+
+	page, err := service.ListPeople(ctx, " robert ", 2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, people, page.People)
+	assert.Equal(t, int64(11), page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, 10, page.PageSize)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListPeople_Defaults(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	mockRepo.On("FindPeople", mock.Anything, repository.PersonFilters{Limit: usecase.DefaultPageSize}).
+		Return([]domain.Person{}, int64(0), nil)
+
+	page, err := service.ListPeople(ctx, "", 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, page.Page)
+	assert.Equal(t, usecase.DefaultPageSize, page.PageSize)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetPerson_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	mockRepo.On("GetPersonByID", mock.Anything, uint(9)).Return(nil, nil)
+
+	person, err := service.GetPerson(ctx, 9)
+	assert.Nil(t, person)
+	assert.EqualError(t, err, "person not found")
+}
+
+func TestCreatePerson_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	mockRepo.On("CreatePerson", mock.Anything, mock.AnythingOfType("*domain.Person")).Return(nil)
+
+	person, err := service.CreatePerson(ctx, "  Al   Pacino ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Al Pacino", person.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreatePerson_InvalidName(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	_, err := service.CreatePerson(ctx, "   ")
+	assert.EqualError(t, err, "name is required")
+
+	_, err = service.CreatePerson(ctx, strings.Repeat("a", 256))
+	assert.EqualError(t, err, "name must be at most 255 characters")
+	mockRepo.AssertNotCalled(t, "CreatePerson", mock.Anything, mock.Anything)
+}
+
+func TestUpdatePerson_Rename(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	existing := &domain.Person{ID: 3, Name: "Bob De Niro"}
+	mockRepo.On("GetPersonByID", mock.Anything, uint(3)).Return(existing, nil)
+	mockRepo.On("UpdatePerson", mock.Anything, existing).Return(nil)
+
+	person, err := service.UpdatePerson(ctx, 3, "Robert De Niro")
+	assert.NoError(t, err)
+	assert.Equal(t, "Robert De Niro", person.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdatePerson_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	mockRepo.On("GetPersonByID", mock.Anything, uint(3)).Return(nil, nil)
+
+	_, err := service.UpdatePerson(ctx, 3, "Robert De Niro")
+	assert.EqualError(t, err, "person not found")
+	mockRepo.AssertNotCalled(t, "UpdatePerson", mock.Anything, mock.Anything)
+}
+
+func TestDeletePerson_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	mockRepo.On("GetPersonByID", mock.Anything, uint(3)).Return(&domain.Person{ID: 3}, nil)
+	mockRepo.On("DeletePersonByID", mock.Anything, uint(3)).Return(nil)
+
+	assert.NoError(t, service.DeletePerson(ctx, 3))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeletePerson_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockPersonRepository)
+	service := usecase.NewPersonService(mockRepo)
+
+	mockRepo.On("GetPersonByID", mock.Anything, uint(3)).Return(nil, nil)
+
+	assert.EqualError(t, service.DeletePerson(ctx, 3), "person not found")
+	mockRepo.AssertNotCalled(t, "DeletePersonByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+)
+
+type ReviewService interface {
+	ListReviews(ctx context.Context, filmID uint, page, pageSize int) (*ReviewPage, error)
+	CreateReview(ctx context.Context, filmID, userID uint, data ReviewData) (*domain.Review, error)
+	UpdateReview(ctx context.Context, filmID, reviewID, userID uint, role domain.Role, data ReviewData) (*domain.Review, error)
+	DeleteReview(ctx context.Context, filmID, reviewID, userID uint, role domain.Role) error
+}
+
+const (
+	MinRating = 1
+	MaxRating = 10
+
+	ReviewBodyMaxLen = 5000
+)
+
+type ReviewData struct {
+	Rating int
+	Body   string
+}
+
+// ReviewPage is a single page of a film's reviews together with the total number of reviews.
+type ReviewPage struct {
+	Reviews  []domain.Review
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+type reviewService struct {
+	reviewRepo repository.ReviewRepository
+	filmRepo   repository.FilmRepository
+}
+
+func NewReviewService(repo repository.ReviewRepository, filmRepo repository.FilmRepository) ReviewService {
+	return &reviewService{reviewRepo: repo, filmRepo: filmRepo}
+}
+
+func (s *reviewService) ListReviews(ctx context.Context, filmID uint, page, pageSize int) (*ReviewPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	if err := s.ensureFilmExists(ctx, filmID); err != nil {
+		return nil, err
+	}
+
+	reviews, total, err := s.reviewRepo.FindReviews(ctx, repository.ReviewFilters{
+		FilmID: filmID,
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReviewPage{
+		Reviews:  reviews,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func (s *reviewService) CreateReview(ctx context.Context, filmID, userID uint, data ReviewData) (*domain.Review, error) {
+	body, err := validateReview(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureFilmExists(ctx, filmID); err != nil {
+		return nil, err
+	}
+
+	review := &domain.Review{
+		FilmID: filmID,
+		UserID: userID,
+		Rating: data.Rating,
+		Body:   body,
+	}
+	if err := s.reviewRepo.CreateReview(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *reviewService) UpdateReview(ctx context.Context, filmID, reviewID, userID uint, role domain.Role, data ReviewData) (*domain.Review, error) {
+	review, err := s.getFilmReview(ctx, filmID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.UserID != userID && !role.Can(domain.PermModerateReviews) {
+		return nil, domain.Forbidden("not_review_author", "forbidden: only creator can update this review")
+	}
+
+	body, err := validateReview(data)
+	if err != nil {
+		return nil, err
+	}
+	review.Rating = data.Rating
+	review.Body = body
+
+	if err := s.reviewRepo.UpdateReview(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, filmID, reviewID, userID uint, role domain.Role) error {
+	review, err := s.getFilmReview(ctx, filmID, reviewID)
+	if err != nil {
+		return err
+	}
+
+	if review.UserID != userID && !role.Can(domain.PermModerateReviews) {
+		return domain.Forbidden("not_review_author", "forbidden: only creator can delete this review")
+	}
+
+	return s.reviewRepo.DeleteReviewByID(ctx, reviewID)
+}
+
+func (s *reviewService) ensureFilmExists(ctx context.Context, filmID uint) error {
+	film, err := s.filmRepo.GetFilmByID(ctx, filmID)
+	if err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	if film == nil {
+		return domain.NotFound("film_not_found", "film not found")
+	}
+	return nil
+}
+
+// getFilmReview loads a review, treating reviews of other films as missing.
+func (s *reviewService) getFilmReview(ctx context.Context, filmID, reviewID uint) (*domain.Review, error) {
+	review, err := s.reviewRepo.GetReviewByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if review == nil || review.FilmID != filmID {
+		return nil, domain.NotFound("review_not_found", "review not found")
+	}
+	return review, nil
+}
+
+func validateReview(data ReviewData) (string, error) {
+	if data.Rating < MinRating || data.Rating > MaxRating {
+		return "", domain.Invalid("rating", fmt.Sprintf("rating must be between %d and %d", MinRating, MaxRating))
+	}
+	body := strings.TrimSpace(data.Body)
+	if len([]rune(body)) > ReviewBodyMaxLen {
+		return "", domain.Invalid("body", fmt.Sprintf("body must be at most %d characters", ReviewBodyMaxLen))
+	}
+	return body, nil
+}
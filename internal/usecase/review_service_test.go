@@ -0,0 +1,186 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/usecase"
+)
+
+func TestListReviews_Paginated(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewReviewService(mockRepo, mockFilmRepo)
+
+	reviews := []domain.Review{{ID: 4, FilmID: 1, UserID: 2, Rating: 8}}
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1}, nil)
+	mockRepo.On("FindReviews", mock.Anything, repository.ReviewFilters{FilmID: 1, Limit: 5, Offset: 5}).
+		Return(reviews, int64(6), nil)
+
+	page, err := service.ListReviews(ctx, 1, 2, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, reviews, page.Reviews)
+	assert.Equal(t, int64(6), page.Total)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListReviews_FilmNotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewReviewService(mockRepo, mockFilmRepo)
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(nil, nil)
+
+	page, err := service.ListReviews(ctx, 1, 1, 10)
+	assert.Nil(t, page)
+	assert.EqualError(t, err, "film not found")
+	mockRepo.AssertNotCalled(t, "FindReviews", mock.Anything, mock.Anything)
+}
+
+func TestCreateReview_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewReviewService(mockRepo, mockFilmRepo)
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1}, nil)
+	mockRepo.On("CreateReview", mock.Anything, mock.AnythingOfType("*domain.Review")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Review).ID = 12
+		})
+
+	review, err := service.CreateReview(ctx, 1, 2, usecase.ReviewData{Rating: 9, Body: "  Great heist film. "})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(12), review.ID)
+	assert.Equal(t, uint(1), review.FilmID)
+	assert.Equal(t, uint(2), review.UserID)
+	assert.Equal(t, 9, review.Rating)
+	assert.Equal(t, "Great heist film.", review.Body)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateReview_InvalidRating(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewReviewService(mockRepo, mockFilmRepo)
+
+	for _, rating := range []int{0, 11, -3} {
+		_, err := service.CreateReview(ctx, 1, 2, usecase.ReviewData{Rating: rating})
+		assert.EqualError(t, err, "rating must be between 1 and 10")
+	}
+
+	_, err := service.CreateReview(ctx, 1, 2, usecase.ReviewData{Rating: 5, Body: strings.Repeat("a", 5001)})
+	assert.EqualError(t, err, "body must be at most 5000 characters")
+	mockRepo.AssertNotCalled(t, "CreateReview", mock.Anything, mock.Anything)
+}
+
+func TestCreateReview_AlreadyReviewed(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewReviewService(mockRepo, mockFilmRepo)
+
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(1)).Return(&domain.Film{ID: 1}, nil)
+	mockRepo.On("CreateReview", mock.Anything, mock.Anything).Return(errors.New("you have already reviewed this film"))
+
+	review, err := service.CreateReview(ctx, 1, 2, usecase.ReviewData{Rating: 7})
+	assert.Nil(t, review)
+	assert.EqualError(t, err, "you have already reviewed this film")
+}
+
+func TestUpdateReview_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	service := usecase.NewReviewService(mockRepo, new(repository.MockFilmRepository))
+
+	existing := &domain.Review{ID: 12, FilmID: 1, UserID: 2, Rating: 9, Body: "Great"}
+	mockRepo.On("GetReviewByID", mock.Anything, uint(12)).Return(existing, nil)
+	mockRepo.On("UpdateReview", mock.Anything, existing).Return(nil)
+
+	review, err := service.UpdateReview(ctx, 1, 12, 2, domain.RoleUser, usecase.ReviewData{Rating: 6, Body: "Good on a rewatch"})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, review.Rating)
+	assert.Equal(t, "Good on a rewatch", review.Body)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateReview_Forbidden(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	service := usecase.NewReviewService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetReviewByID", mock.Anything, uint(12)).Return(&domain.Review{ID: 12, FilmID: 1, UserID: 2}, nil)
+
+	review, err := service.UpdateReview(ctx, 1, 12, 3, domain.RoleUser, usecase.ReviewData{Rating: 1})
+	assert.Nil(t, review)
+	assert.EqualError(t, err, "forbidden: only creator can update this review")
+	mockRepo.AssertNotCalled(t, "UpdateReview", mock.Anything, mock.Anything)
+}
+
+func TestUpdateReview_OtherFilm(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	service := usecase.NewReviewService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetReviewByID", mock.Anything, uint(12)).Return(&domain.Review{ID: 12, FilmID: 5, UserID: 2}, nil)
+
+	_, err := service.UpdateReview(ctx, 1, 12, 2, domain.RoleUser, usecase.ReviewData{Rating: 4})
+	assert.EqualError(t, err, "review not found")
+}
+
+func TestDeleteReview_Success(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	service := usecase.NewReviewService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetReviewByID", mock.Anything, uint(12)).Return(&domain.Review{ID: 12, FilmID: 1, UserID: 2}, nil)
+	mockRepo.On("DeleteReviewByID", mock.Anything, uint(12)).Return(nil)
+
+	assert.NoError(t, service.DeleteReview(ctx, 1, 12, 2, domain.RoleUser))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteReview_Forbidden(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	service := usecase.NewReviewService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetReviewByID", mock.Anything, uint(12)).Return(&domain.Review{ID: 12, FilmID: 1, UserID: 2}, nil)
+
+	assert.EqualError(t, service.DeleteReview(ctx, 1, 12, 3, domain.RoleUser), "forbidden: only creator can delete this review")
+	mockRepo.AssertNotCalled(t, "DeleteReviewByID", mock.Anything, mock.Anything)
+}
+
+func TestDeleteReview_AdminModerates(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	service := usecase.NewReviewService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetReviewByID", mock.Anything, uint(12)).Return(&domain.Review{ID: 12, FilmID: 1, UserID: 2}, nil)
+	mockRepo.On("DeleteReviewByID", mock.Anything, uint(12)).Return(nil)
+
+	assert.EqualError(t, service.DeleteReview(ctx, 1, 12, 3, domain.RoleEditor), "forbidden: only creator can delete this review")
+	assert.NoError(t, service.DeleteReview(ctx, 1, 12, 3, domain.RoleAdmin))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteReview_NotFound(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockReviewRepository)
+	service := usecase.NewReviewService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetReviewByID", mock.Anything, uint(12)).Return(nil, nil)
+
+	assert.EqualError(t, service.DeleteReview(ctx, 1, 12, 2, domain.RoleUser), "review not found")
+}
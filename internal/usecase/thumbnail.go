@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"image"
+	"image/draw"
+)
+
+// ThumbnailSize is a named bound for the longer side of a thumbnail.
+type ThumbnailSize struct {
+	Name string
+	Max  int
+}
+
+// ThumbnailSizes are the thumbnails made of every uploaded image, largest
+// first. Images smaller than a bound are not scaled up.
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "large", Max: 1024},
+	{Name: "medium", Max: 480},
+	{Name: "small", Max: 160},
+}
+
+// thumbnailBounds scales width x height down to fit within bound x bound,
+// keeping the aspect ratio.
+func thumbnailBounds(width, height, bound int) (int, int) {
+	if width <= bound && height <= bound {
+		return width, height
+	}
+	if width >= height {
+		return bound, max(1, height*bound/width)
+	}
+	return max(1, width*bound/height), bound
+}
+
+// toRGBA converts img to RGBA once, so that it can be scaled several times
+// without going through the slow generic image.Image interface.
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Rect, img, b.Min, draw.Src)
+	return rgba
+}
+
+// scaleDown shrinks src to width x height with a box filter: every target
+// pixel is the average of the source pixels it covers.
+func scaleDown(src *image.RGBA, width, height int) *image.RGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	if sw == width && sh == height {
+		return src
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, max((y+1)*sh/height, y*sh/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, max((x+1)*sw/width, x*sw/width+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					r += uint64(row[i])
+					g += uint64(row[i+1])
+					b += uint64(row[i+2])
+					a += uint64(row[i+3])
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
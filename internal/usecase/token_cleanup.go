@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TokenCleanupInterval is how often RunTokenCleanup deletes expired tokens.
+const TokenCleanupInterval = time.Hour
+
+// RunTokenCleanup deletes expired refresh tokens and revocations, once right
+// away and then every interval, until ctx is done. Failures are logged and
+// retried on the next run.
+func RunTokenCleanup(ctx context.Context, users UserService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := users.DeleteExpiredTokens(ctx)
+		if err != nil {
+			log.Printf("token cleanup: %v", err)
+		} else if deleted > 0 {
+			log.Printf("token cleanup: deleted %d expired tokens", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
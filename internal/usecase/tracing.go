@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-films-api/internal/domain"
+)
+
+const tracerName = "go-films-api/internal/usecase"
+
+// startSpan starts the span of a service method, named like
+// "FilmService.ListFilms".
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends span, recording err. Only internal errors mark the span as
+// failed: a typed domain error is an answer the client gets to act on.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		var domainErr *domain.Error
+		if !errors.As(err, &domainErr) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+func filmIDAttr(id uint) attribute.KeyValue {
+	return attribute.Int64("film.id", int64(id))
+}
+
+func userIDAttr(id uint) attribute.KeyValue {
+	return attribute.Int64("user.id", int64(id))
+}
+
+// tracedFilmService wraps every FilmService method in a span.
+type tracedFilmService struct {
+	next FilmService
+}
+
+// TraceFilmService returns a FilmService that runs every call of s in a span
+// of its own.
+func TraceFilmService(s FilmService) FilmService {
+	return &tracedFilmService{next: s}
+}
+
+func (t *tracedFilmService) ListFilms(ctx context.Context, query ListFilmsQuery) (page *FilmPage, err error) {
+	ctx, span := startSpan(ctx, "FilmService.ListFilms",
+		attribute.Bool("films.search", query.Query != ""),
+		attribute.Bool("films.cursor", query.Cursor != ""),
+	)
+	defer func() { endSpan(span, err) }()
+	return t.next.ListFilms(ctx, query)
+}
+
+func (t *tracedFilmService) GetFilmDetails(ctx context.Context, id uint) (film *domain.Film, err error) {
+	ctx, span := startSpan(ctx, "FilmService.GetFilmDetails", filmIDAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetFilmDetails(ctx, id)
+}
+
+func (t *tracedFilmService) CreateFilm(ctx context.Context, data CreateFilmData, userID uint) (film *domain.Film, err error) {
+	ctx, span := startSpan(ctx, "FilmService.CreateFilm", userIDAttr(userID))
+	defer func() { endSpan(span, err) }()
+	return t.next.CreateFilm(ctx, data, userID)
+}
+
+func (t *tracedFilmService) UpdateFilm(ctx context.Context, id, userID uint, role domain.Role, data UpdateFilmData) (film *domain.Film, err error) {
+	ctx, span := startSpan(ctx, "FilmService.UpdateFilm", filmIDAttr(id), userIDAttr(userID))
+	defer func() { endSpan(span, err) }()
+	return t.next.UpdateFilm(ctx, id, userID, role, data)
+}
+
+func (t *tracedFilmService) DeleteFilm(ctx context.Context, id, userID uint, role domain.Role) (err error) {
+	ctx, span := startSpan(ctx, "FilmService.DeleteFilm", filmIDAttr(id), userIDAttr(userID))
+	defer func() { endSpan(span, err) }()
+	return t.next.DeleteFilm(ctx, id, userID, role)
+}
+
+func (t *tracedFilmService) ListTrash(ctx context.Context, userID uint, page, pageSize int) (result *FilmPage, err error) {
+	ctx, span := startSpan(ctx, "FilmService.ListTrash", userIDAttr(userID))
+	defer func() { endSpan(span, err) }()
+	return t.next.ListTrash(ctx, userID, page, pageSize)
+}
+
+func (t *tracedFilmService) RestoreFilm(ctx context.Context, id, userID uint, role domain.Role) (film *domain.Film, err error) {
+	ctx, span := startSpan(ctx, "FilmService.RestoreFilm", filmIDAttr(id), userIDAttr(userID))
+	defer func() { endSpan(span, err) }()
+	return t.next.RestoreFilm(ctx, id, userID, role)
+}
+
+func (t *tracedFilmService) PurgeFilm(ctx context.Context, id, actorID uint) (err error) {
+	ctx, span := startSpan(ctx, "FilmService.PurgeFilm", filmIDAttr(id), userIDAttr(actorID))
+	defer func() { endSpan(span, err) }()
+	return t.next.PurgeFilm(ctx, id, actorID)
+}
+
+func (t *tracedFilmService) PurgeTrash(ctx context.Context, deletedBefore time.Time) (purged int64, err error) {
+	ctx, span := startSpan(ctx, "FilmService.PurgeTrash")
+	defer func() {
+		span.SetAttributes(attribute.Int64("films.purged", purged))
+		endSpan(span, err)
+	}()
+	return t.next.PurgeTrash(ctx, deletedBefore)
+}
+
+func (t *tracedFilmService) ImportFilms(ctx context.Context, source ImportSource, opts ImportOptions, userID uint) (report *ImportReport, err error) {
+	ctx, span := startSpan(ctx, "FilmService.ImportFilms", userIDAttr(userID), attribute.Bool("import.dry_run", opts.DryRun))
+	defer func() {
+		if report != nil {
+			span.SetAttributes(attribute.Int("import.total", report.Total), attribute.Int("import.failed", report.Failed))
+		}
+		endSpan(span, err)
+	}()
+	return t.next.ImportFilms(ctx, source, opts, userID)
+}
+
+func (t *tracedFilmService) ExportFilms(ctx context.Context, query ListFilmsQuery, fn func(films []domain.Film) error) (err error) {
+	ctx, span := startSpan(ctx, "FilmService.ExportFilms")
+	defer func() { endSpan(span, err) }()
+	return t.next.ExportFilms(ctx, query, fn)
+}
+
+// tracedUserService wraps every UserService method in a span. Usernames are
+// left out of the spans; user IDs are enough to follow a request.
+type tracedUserService struct {
+	next UserService
+}
+
+// TraceUserService returns a UserService that runs every call of s in a span
+// of its own.
+func TraceUserService(s UserService) UserService {
+	return &tracedUserService{next: s}
+}
+
+func (t *tracedUserService) Register(ctx context.Context, username, password string) (err error) {
+	ctx, span := startSpan(ctx, "UserService.Register")
+	defer func() { endSpan(span, err) }()
+	return t.next.Register(ctx, username, password)
+}
+
+func (t *tracedUserService) Login(ctx context.Context, username, password string) (tokens *AuthTokens, err error) {
+	ctx, span := startSpan(ctx, "UserService.Login")
+	defer func() { endSpan(span, err) }()
+	return t.next.Login(ctx, username, password)
+}
+
+func (t *tracedUserService) Refresh(ctx context.Context, refreshToken string) (tokens *AuthTokens, err error) {
+	ctx, span := startSpan(ctx, "UserService.Refresh")
+	defer func() { endSpan(span, err) }()
+	return t.next.Refresh(ctx, refreshToken)
+}
+
+func (t *tracedUserService) Logout(ctx context.Context, sessionID string) (err error) {
+	ctx, span := startSpan(ctx, "UserService.Logout")
+	defer func() { endSpan(span, err) }()
+	return t.next.Logout(ctx, sessionID)
+}
+
+func (t *tracedUserService) ListUsers(ctx context.Context, role domain.Role, page, pageSize int) (result *UserPage, err error) {
+	ctx, span := startSpan(ctx, "UserService.ListUsers")
+	defer func() { endSpan(span, err) }()
+	return t.next.ListUsers(ctx, role, page, pageSize)
+}
+
+func (t *tracedUserService) GetUser(ctx context.Context, id uint) (user *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetUser", userIDAttr(id))
+	defer func() { endSpan(span, err) }()
+	return t.next.GetUser(ctx, id)
+}
+
+func (t *tracedUserService) SetUserRole(ctx context.Context, actorID, userID uint, role domain.Role) (user *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserService.SetUserRole", userIDAttr(userID), attribute.String("user.role", string(role)))
+	defer func() { endSpan(span, err) }()
+	return t.next.SetUserRole(ctx, actorID, userID, role)
+}
+
+func (t *tracedUserService) DeleteExpiredTokens(ctx context.Context) (deleted int64, err error) {
+	ctx, span := startSpan(ctx, "UserService.DeleteExpiredTokens")
+	defer func() {
+		span.SetAttributes(attribute.Int64("tokens.deleted", deleted))
+		endSpan(span, err)
+	}()
+	return t.next.DeleteExpiredTokens(ctx)
+}
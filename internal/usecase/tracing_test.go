@@ -0,0 +1,62 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/storage"
+	"go-films-api/internal/usecase"
+)
+
+func TestTraceFilmService(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	mockRepo := new(repository.MockFilmRepository)
+	filmService := usecase.TraceFilmService(usecase.NewFilmService(mockRepo, new(repository.MockGenreRepository), new(repository.MockPersonRepository), new(storage.MockBlobStore), testAuth))
+
+	var repoSpan trace.SpanContext
+	inSpan := mock.MatchedBy(func(ctx context.Context) bool {
+		repoSpan = trace.SpanContextFromContext(ctx)
+		return true
+	})
+	mockRepo.On("GetFilmByID", inSpan, uint(1)).Return(&domain.Film{ID: 1}, nil)
+	mockRepo.On("GetFilmByID", inSpan, uint(2)).Return(nil, nil)
+	mockRepo.On("GetFilmByID", inSpan, uint(3)).Return(nil, errors.New("connection refused"))
+
+	film, err := filmService.GetFilmDetails(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), film.ID)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "FilmService.GetFilmDetails", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), attribute.Int64("film.id", 1))
+		assert.Equal(t, spans[0].SpanContext(), repoSpan, "repositories see the span in their context")
+	}
+
+	_, err = filmService.GetFilmDetails(context.Background(), 2)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = filmService.GetFilmDetails(context.Background(), 3)
+	assert.Error(t, err)
+
+	spans = recorder.Ended()
+	if assert.Len(t, spans, 3) {
+		assert.Equal(t, codes.Unset, spans[1].Status().Code, "domain errors do not fail the span")
+		assert.Len(t, spans[1].Events(), 1, "but they are recorded")
+		assert.Equal(t, codes.Error, spans[2].Status().Code)
+	}
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// TrashRetentionInterval is how often RunTrashRetention empties the trash.
+const TrashRetentionInterval = time.Hour
+
+// RunTrashRetention permanently deletes the films that have been in the
+// trash for longer than retention, once right away and then every interval,
+// until ctx is done. Failures are logged and retried on the next run.
+func RunTrashRetention(ctx context.Context, films FilmService, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := films.PurgeTrash(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("trash retention: %v", err)
+		} else if purged > 0 {
+			log.Printf("trash retention: purged %d films", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
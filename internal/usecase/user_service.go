@@ -1,33 +1,67 @@
 package usecase
 
 import (
-	"errors"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
-	"os"
 	"regexp"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/crypto/bcrypt"
 
+	"go-films-api/internal/config"
 	"go-films-api/internal/domain"
 	"go-films-api/internal/repository"
 )
 
 type UserService interface {
-	Register(username, password string) error
-	Login(username, password string) (string, time.Time, error)
+	Register(ctx context.Context, username, password string) error
+	Login(ctx context.Context, username, password string) (*AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error)
+	Logout(ctx context.Context, sessionID string) error
+
+	// User administration
+	ListUsers(ctx context.Context, role domain.Role, page, pageSize int) (*UserPage, error)
+	GetUser(ctx context.Context, id uint) (*domain.User, error)
+	SetUserRole(ctx context.Context, actorID, userID uint, role domain.Role) (*domain.User, error)
+
+	DeleteExpiredTokens(ctx context.Context) (int64, error)
+}
+
+// UserPage is a single page of users together with the total number of matches.
+type UserPage struct {
+	Users    []domain.User
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// AuthTokens is the token pair handed out on login and on every refresh.
+type AuthTokens struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
 }
 
 type userService struct {
-	userRepo repository.UserRepository
-	jwtKey   []byte
+	userRepo  repository.UserRepository
+	tokenRepo repository.TokenRepository
+	auth      config.Auth
 }
 
-func NewUserService(repo repository.UserRepository) UserService {
+// NewUserService signs tokens with auth.JWTSecret. Access tokens stay
+// short-lived; refresh tokens are rotated on every use and each new one gets
+// the full lifetime again.
+func NewUserService(repo repository.UserRepository, tokenRepo repository.TokenRepository, auth config.Auth) UserService {
 	return &userService{
-		userRepo: repo,
-		jwtKey:   []byte(os.Getenv("JWT_SECRET")),
+		userRepo:  repo,
+		tokenRepo: tokenRepo,
+		auth:      auth,
 	}
 }
 
@@ -47,33 +81,33 @@ var (
 	specialCharRegex = regexp.MustCompile(`[^A-Za-z0-9]`) // anything not alphanumeric
 )
 
-func (s *userService) Register(username, password string) error {
+func (s *userService) Register(ctx context.Context, username, password string) error {
 	if !usernameRegex.MatchString(username) {
-		return errors.New("username must start with a letter and contain only alphanumeric characters")
+		return domain.Invalid("username", "username must start with a letter and contain only alphanumeric characters")
 	}
 
 	if len(password) < PasswordMinLen || len(password) > PasswordMaxLen {
-		return fmt.Errorf("password must be between %d and %d characters", PasswordMinLen, PasswordMaxLen)
+		return domain.Invalid("password", fmt.Sprintf("password must be between %d and %d characters", PasswordMinLen, PasswordMaxLen))
 	}
 
 	if !uppercaseRegex.MatchString(password) {
-		return errors.New("password must contain at least one uppercase letter")
+		return domain.Invalid("password", "password must contain at least one uppercase letter")
 	}
 
 	if !digitRegex.MatchString(password) {
-		return errors.New("password must contain at least one digit")
+		return domain.Invalid("password", "password must contain at least one digit")
 	}
 
 	if !specialCharRegex.MatchString(password) {
-		return errors.New("password must contain at least one special character")
+		return domain.Invalid("password", "password must contain at least one special character")
 	}
 
-	existing, err := s.userRepo.GetUserByUsername(username)
+	existing, err := s.userRepo.GetUserByUsername(ctx, username)
 	if err != nil {
 		return fmt.Errorf("repository error: %w", err)
 	}
 	if existing != nil {
-		return errors.New("username already taken")
+		return domain.Conflict("username_taken", "username already taken")
 	}
 
 	hashedPass, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -85,38 +119,247 @@ func (s *userService) Register(username, password string) error {
 		Username: username,
 		Password: string(hashedPass),
 	}
-	if err := s.userRepo.CreateUser(newUser); err != nil {
+	if err := s.userRepo.CreateUser(ctx, newUser); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *userService) Login(username, password string) (string, time.Time, error) {
-	user, err := s.userRepo.GetUserByUsername(username)
+func (s *userService) Login(ctx context.Context, username, password string) (*AuthTokens, error) {
+	user, err := s.userRepo.GetUserByUsername(ctx, username)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("repository error: %w", err)
+		return nil, fmt.Errorf("repository error: %w", err)
 	}
 
 	if user == nil {
-		return "", time.Time{}, errors.New("invalid username or password")
+		return nil, domain.Unauthorized("invalid_credentials", "invalid username or password")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", time.Time{}, errors.New("invalid username or password")
+		return nil, domain.Unauthorized("invalid_credentials", "invalid username or password")
+	}
+
+	// Each login starts a new session, identified by its token family
+	familyID, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+	tokens, record, err := s.issueTokens(user, familyID)
+	if err != nil {
+		return nil, err
 	}
+	if err := s.tokenRepo.CreateRefreshToken(ctx, record); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	return tokens, nil
+}
 
-	expirationTime := time.Now().Add(time.Hour)
+// Refresh exchanges a refresh token for a new token pair. The presented token
+// is single-use: presenting it again revokes the whole session.
+func (s *userService) Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	current, err := s.tokenRepo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if current == nil || current.RevokedAt != nil || time.Now().After(current.ExpiresAt) {
+		return nil, domain.Unauthorized("invalid_refresh_token", "invalid refresh token")
+	}
+	if current.UsedAt != nil {
+		return nil, s.revokeReusedFamily(ctx, current.FamilyID)
+	}
+
+	// The role is read again so role changes apply from the next refresh on
+	user, err := s.userRepo.GetUserByID(ctx, current.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if user == nil {
+		return nil, domain.Unauthorized("invalid_refresh_token", "invalid refresh token")
+	}
 
+	tokens, next, err := s.issueTokens(user, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := s.tokenRepo.RotateRefreshToken(ctx, current, next)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if !rotated {
+		// Another request used the same token first
+		return nil, s.revokeReusedFamily(ctx, current.FamilyID)
+	}
+	return tokens, nil
+}
+
+// Logout revokes the session, its refresh tokens and the access tokens still in flight.
+func (s *userService) Logout(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return domain.Unauthorized("invalid_session", "invalid session")
+	}
+	if err := s.tokenRepo.RevokeTokenFamily(ctx, sessionID); err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	return nil
+}
+
+func (s *userService) revokeReusedFamily(ctx context.Context, familyID string) error {
+	if err := s.tokenRepo.RevokeTokenFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("repository error: %w", err)
+	}
+	return domain.Unauthorized("refresh_token_reused", "refresh token reuse detected")
+}
+
+// issueTokens signs an access token and generates a refresh token for the
+// session, returning the pair and the refresh token record to store.
+func (s *userService) issueTokens(user *domain.User, familyID string) (*AuthTokens, *domain.RefreshToken, error) {
+	now := time.Now()
+	accessExp := now.Add(s.auth.AccessTokenTTL)
+	refreshExp := now.Add(s.auth.RefreshTokenTTL)
+
+	jti, err := randomID()
+	if err != nil {
+		return nil, nil, err
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub": user.ID,
-		"exp": expirationTime.Unix(),
+		"sub":  user.ID,
+		"exp":  accessExp.Unix(),
+		"jti":  jti,
+		"sid":  familyID,
+		"role": userRole(user),
+	})
+	signedToken, err := token.SignedString([]byte(s.auth.JWTSecret))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not sign token: %w", err)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, nil, fmt.Errorf("could not generate refresh token: %w", err)
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(raw)
+
+	tokens := &AuthTokens{
+		AccessToken:      signedToken,
+		AccessExpiresAt:  accessExp,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExp,
+	}
+	record := &domain.RefreshToken{
+		UserID:               user.ID,
+		FamilyID:             familyID,
+		TokenHash:            hashToken(refreshToken),
+		AccessTokenID:        jti,
+		AccessTokenExpiresAt: accessExp,
+		ExpiresAt:            refreshExp,
+	}
+	return tokens, record, nil
+}
+
+func (s *userService) ListUsers(ctx context.Context, role domain.Role, page, pageSize int) (*UserPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	if role != "" && !role.IsValid() {
+		return nil, domain.Invalid("role", "invalid role")
+	}
+
+	users, total, err := s.userRepo.FindUsers(ctx, repository.UserFilters{
+		Role:   role,
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	signedToken, err := token.SignedString(s.jwtKey)
+	return &UserPage{
+		Users:    users,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func (s *userService) GetUser(ctx context.Context, id uint) (*domain.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, id)
 	if err != nil {
-		return "", time.Time{}, fmt.Errorf("could not sign token: %w", err)
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if user == nil {
+		return nil, domain.NotFound("user_not_found", "user not found")
 	}
+	return user, nil
+}
 
-	return signedToken, expirationTime, nil
+// SetUserRole changes the role of a user and signs them out everywhere, so
+// tokens carrying the old role stop working right away.
+func (s *userService) SetUserRole(ctx context.Context, actorID, userID uint, role domain.Role) (*domain.User, error) {
+	if !role.IsValid() {
+		return nil, domain.Invalid("role", "invalid role")
+	}
+	// Keeps the last admin from locking everyone out by demoting themselves
+	if actorID == userID {
+		return nil, domain.Conflict("own_role_change", "you cannot change your own role")
+	}
+
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	if err := s.userRepo.UpdateUserRole(ctx, userID, role); err != nil {
+		return nil, err
+	}
+	user.Role = role
+
+	if err := s.tokenRepo.RevokeUserTokens(ctx, userID); err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	return user, nil
+}
+
+// DeleteExpiredTokens deletes the refresh tokens and access token
+// revocations that have expired, since expired tokens are rejected anyway,
+// and returns how many there were.
+func (s *userService) DeleteExpiredTokens(ctx context.Context) (int64, error) {
+	deleted, err := s.tokenRepo.DeleteExpiredTokens(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("repository error: %w", err)
+	}
+	return deleted, nil
+}
+
+// userRole returns the role of a user, treating an unset role as a plain user.
+func userRole(user *domain.User) domain.Role {
+	if user.Role == "" {
+		return domain.RoleUser
+	}
+	return user.Role
+}
+
+// hashToken returns the hex SHA-256 of a refresh token. Refresh tokens carry
+// 256 random bits, so a fast unsalted hash is enough.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomID returns 128 random bits as 32 hex characters, used for token and session IDs.
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
 }
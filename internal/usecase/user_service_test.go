@@ -1,155 +1,392 @@
 package usecase_test
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 	"time"
 
+	"go-films-api/internal/config"
 	"go-films-api/internal/domain"
 	"go-films-api/internal/repository"
 	"go-films-api/internal/usecase"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// testAuth configures the services under test like a real deployment.
+var testAuth = config.Auth{
+	JWTSecret:       "test-secret-that-is-long-enough-for-hs256",
+	AccessTokenTTL:  time.Hour,
+	RefreshTokenTTL: 30 * 24 * time.Hour,
+}
+
 func TestRegister_Success(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
-	mockRepo.On("GetUserByUsername", "newuser").Return(nil, nil)
-	mockRepo.On("CreateUser", mock.Anything).Return(nil)
+	mockRepo.On("GetUserByUsername", mock.Anything, "newuser").Return(nil, nil)
+	mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
 
-	err := service.Register("newuser", "password123")
+	err := service.Register(ctx, "newuser", "Password123!")
 	assert.NoError(t, err)
 
-	mockRepo.AssertCalled(t, "GetUserByUsername", "newuser")
-	mockRepo.AssertCalled(t, "CreateUser", mock.Anything)
+	mockRepo.AssertCalled(t, "GetUserByUsername", mock.Anything, "newuser")
+	mockRepo.AssertCalled(t, "CreateUser", mock.Anything, mock.Anything)
 }
 
 func TestRegister_UsernameTaken(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
 	existingUser := &domain.User{ID: 1, Username: "AlphaUser"}
-	mockRepo.On("GetUserByUsername", "AlphaUser").Return(existingUser, nil)
+	mockRepo.On("GetUserByUsername", mock.Anything, "AlphaUser").Return(existingUser, nil)
 
-	err := service.Register("AlphaUser", "secret12")
+	err := service.Register(ctx, "AlphaUser", "Secret12!")
 	assert.Error(t, err)
 	assert.Equal(t, "username already taken", err.Error())
 }
 
 func TestRegister_InvalidUsername(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
-	err := service.Register("123Invalid", "somepass")
+	err := service.Register(ctx, "123Invalid", "somepass")
 	assert.Error(t, err)
 	assert.Equal(t, "username must start with a letter and contain only alphanumeric characters", err.Error())
 
-	err = service.Register("John_Doe", "somepass")
+	err = service.Register(ctx, "John_Doe", "somepass")
 	assert.Error(t, err)
 	assert.Equal(t, "username must start with a letter and contain only alphanumeric characters", err.Error())
 }
 
 func TestRegister_PasswordTooShort(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
-	err := service.Register("AlphaUser", "123")
+	err := service.Register(ctx, "AlphaUser", "123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "password must be between 6 and 20 characters")
 }
 
 func TestRegister_PasswordTooLong(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
 	tooLongPass := "thispasswordisdefinitelymorethan20chars"
-	err := service.Register("BetaUser", tooLongPass)
+	err := service.Register(ctx, "BetaUser", tooLongPass)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "password must be between 6 and 20 characters")
 }
 
 func TestRegister_MissingUppercase(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
-	err := service.Register("UserTest", "abcd123#")
+	err := service.Register(ctx, "UserTest", "abcd123#")
 	assert.Error(t, err)
 	assert.Equal(t, "password must contain at least one uppercase letter", err.Error())
 }
 
 func TestRegister_MissingDigit(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
-	err := service.Register("UserTest", "Abcd#xyz")
+	err := service.Register(ctx, "UserTest", "Abcd#xyz")
 	assert.Error(t, err)
 	assert.Equal(t, "password must contain at least one digit", err.Error())
 }
 
 func TestRegister_MissingSpecialChar(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
-	err := service.Register("UserTest", "Abcd1234")
+	err := service.Register(ctx, "UserTest", "Abcd1234")
 	assert.Error(t, err)
 	assert.Equal(t, "password must contain at least one special character", err.Error())
 }
 
 func TestRegister_ValidAllRequirements(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
 	validPassword := "Abcd1234!"
-	mockRepo.On("GetUserByUsername", "ValidUser").Return(nil, nil)
-	mockRepo.On("CreateUser", mock.Anything).Return(nil)
+	mockRepo.On("GetUserByUsername", mock.Anything, "ValidUser").Return(nil, nil)
+	mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
 
-	err := service.Register("ValidUser", validPassword)
+	err := service.Register(ctx, "ValidUser", validPassword)
 	assert.NoError(t, err)
-	mockRepo.AssertCalled(t, "CreateUser", mock.Anything)
+	mockRepo.AssertCalled(t, "CreateUser", mock.Anything, mock.Anything)
 }
 
 func TestLogin_Success(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	tokenRepo := new(repository.MockTokenRepository)
+	service := usecase.NewUserService(mockRepo, tokenRepo, testAuth)
 
 	// Provide a hashed password that will pass bcrypt check:
 	hashed := "$2a$10$1fybhpdIC527ODopk5/FLu5L5o60g.2p1NGd7Zso75iv.R4siZm3e"
 	user := &domain.User{ID: 42, Username: "johndoe", Password: hashed}
 
-	mockRepo.On("GetUserByUsername", "johndoe").Return(user, nil)
+	mockRepo.On("GetUserByUsername", mock.Anything, "johndoe").Return(user, nil)
+	tokenRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
 
-	token, exp, err := service.Login("johndoe", "secret")
+	tokens, err := service.Login(ctx, "johndoe", "secret")
 	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
-	assert.WithinDuration(t, time.Now().Add(time.Hour), exp, 2*time.Second)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), tokens.AccessExpiresAt, 2*time.Second)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(testAuth.RefreshTokenTTL), tokens.RefreshExpiresAt, 2*time.Second)
+
+	// Only the hash of the refresh token is stored, next to the access token's jti
+	stored := tokenRepo.Calls[0].Arguments.Get(1).(*domain.RefreshToken)
+	assert.Equal(t, uint(42), stored.UserID)
+	assert.Len(t, stored.TokenHash, 64)
+	assert.NotEqual(t, tokens.RefreshToken, stored.TokenHash)
+	assert.NotEmpty(t, stored.FamilyID)
+	assert.NotEmpty(t, stored.AccessTokenID)
+	assert.Equal(t, stored.AccessTokenID, tokenClaims(t, tokens.AccessToken)["jti"])
+	assert.Equal(t, stored.FamilyID, tokenClaims(t, tokens.AccessToken)["sid"])
+	assert.Equal(t, "user", tokenClaims(t, tokens.AccessToken)["role"])
 }
 
 func TestLogin_InvalidPassword(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
 	// user with a known hashed password
 	hashed := "$2a$10$1fybhpdIC527ODopk5/FLu5L5o60g.2p1NGd7Zso75iv.R4siZm3e"
 	user := &domain.User{ID: 42, Username: "johndoe", Password: hashed}
 
-	mockRepo.On("GetUserByUsername", "johndoe").Return(user, nil)
+	mockRepo.On("GetUserByUsername", mock.Anything, "johndoe").Return(user, nil)
 
-	token, exp, err := service.Login("johndoe", "wrongpass")
-	assert.Empty(t, token)
-	assert.Equal(t, time.Time{}, exp)
+	tokens, err := service.Login(ctx, "johndoe", "wrongpass")
+	assert.Nil(t, tokens)
 	assert.EqualError(t, err, "invalid username or password")
 }
 
 func TestLogin_NoUser(t *testing.T) {
+	ctx := context.Background()
 	mockRepo := new(repository.MockUserRepository)
-	service := usecase.NewUserService(mockRepo)
+	service := usecase.NewUserService(mockRepo, new(repository.MockTokenRepository), testAuth)
 
-	mockRepo.On("GetUserByUsername", "unknown").Return(nil, nil)
+	mockRepo.On("GetUserByUsername", mock.Anything, "unknown").Return(nil, nil)
 
-	token, exp, err := service.Login("unknown", "secret")
-	assert.Empty(t, token)
-	assert.Equal(t, time.Time{}, exp)
+	tokens, err := service.Login(ctx, "unknown", "secret")
+	assert.Nil(t, tokens)
 	assert.EqualError(t, err, "invalid username or password")
 }
+
+func TestRefresh_RotatesToken(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(repository.MockUserRepository)
+	tokenRepo := new(repository.MockTokenRepository)
+	service := usecase.NewUserService(userRepo, tokenRepo, testAuth)
+
+	// The role was changed since the previous token was issued
+	userRepo.On("GetUserByID", mock.Anything, uint(42)).Return(&domain.User{ID: 42, Role: domain.RoleEditor}, nil)
+	current := &domain.RefreshToken{ID: 7, UserID: 42, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+	tokenRepo.On("GetRefreshTokenByHash", mock.Anything, sha256Hex("old-token")).Return(current, nil)
+	tokenRepo.On("RotateRefreshToken", mock.Anything, current, mock.MatchedBy(func(next *domain.RefreshToken) bool {
+		return next.UserID == 42 && next.FamilyID == "family" && next.TokenHash != sha256Hex("old-token")
+	})).Return(true, nil)
+
+	tokens, err := service.Refresh(ctx, "old-token")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEqual(t, "old-token", tokens.RefreshToken)
+	assert.Equal(t, "family", tokenClaims(t, tokens.AccessToken)["sid"])
+	assert.Equal(t, "editor", tokenClaims(t, tokens.AccessToken)["role"])
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestRefresh_UnknownToken(t *testing.T) {
+	ctx := context.Background()
+	tokenRepo := new(repository.MockTokenRepository)
+	service := usecase.NewUserService(new(repository.MockUserRepository), tokenRepo, testAuth)
+
+	tokenRepo.On("GetRefreshTokenByHash", mock.Anything, sha256Hex("nope")).Return(nil, nil)
+
+	tokens, err := service.Refresh(ctx, "nope")
+	assert.Nil(t, tokens)
+	assert.EqualError(t, err, "invalid refresh token")
+}
+
+func TestRefresh_ExpiredOrRevokedToken(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Now()
+	for name, token := range map[string]*domain.RefreshToken{
+		"expired": {ID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(-time.Minute)},
+		"revoked": {ID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tokenRepo := new(repository.MockTokenRepository)
+			service := usecase.NewUserService(new(repository.MockUserRepository), tokenRepo, testAuth)
+			tokenRepo.On("GetRefreshTokenByHash", mock.Anything, sha256Hex("token")).Return(token, nil)
+
+			_, err := service.Refresh(ctx, "token")
+			assert.EqualError(t, err, "invalid refresh token")
+			tokenRepo.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRefresh_ReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	tokenRepo := new(repository.MockTokenRepository)
+	service := usecase.NewUserService(new(repository.MockUserRepository), tokenRepo, testAuth)
+
+	usedAt := time.Now().Add(-time.Minute)
+	used := &domain.RefreshToken{ID: 7, UserID: 42, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+	tokenRepo.On("GetRefreshTokenByHash", mock.Anything, sha256Hex("stolen")).Return(used, nil)
+	tokenRepo.On("RevokeTokenFamily", mock.Anything, "family").Return(nil)
+
+	tokens, err := service.Refresh(ctx, "stolen")
+	assert.Nil(t, tokens)
+	assert.EqualError(t, err, "refresh token reuse detected")
+	tokenRepo.AssertCalled(t, "RevokeTokenFamily", mock.Anything, "family")
+	tokenRepo.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRefresh_ConcurrentUseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(repository.MockUserRepository)
+	tokenRepo := new(repository.MockTokenRepository)
+	service := usecase.NewUserService(userRepo, tokenRepo, testAuth)
+
+	userRepo.On("GetUserByID", mock.Anything, uint(42)).Return(&domain.User{ID: 42, Role: domain.RoleUser}, nil)
+	current := &domain.RefreshToken{ID: 7, UserID: 42, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+	tokenRepo.On("GetRefreshTokenByHash", mock.Anything, sha256Hex("token")).Return(current, nil)
+	tokenRepo.On("RotateRefreshToken", mock.Anything, current, mock.Anything).Return(false, nil)
+	tokenRepo.On("RevokeTokenFamily", mock.Anything, "family").Return(nil)
+
+	_, err := service.Refresh(ctx, "token")
+	assert.EqualError(t, err, "refresh token reuse detected")
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestLogout_RevokesSession(t *testing.T) {
+	ctx := context.Background()
+	tokenRepo := new(repository.MockTokenRepository)
+	service := usecase.NewUserService(new(repository.MockUserRepository), tokenRepo, testAuth)
+
+	tokenRepo.On("RevokeTokenFamily", mock.Anything, "family").Return(nil)
+
+	assert.NoError(t, service.Logout(ctx, "family"))
+	tokenRepo.AssertExpectations(t)
+
+	assert.EqualError(t, service.Logout(ctx, ""), "invalid session")
+}
+
+func TestListUsers_FilterByRole(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(repository.MockUserRepository)
+	service := usecase.NewUserService(userRepo, new(repository.MockTokenRepository), testAuth)
+
+	admins := []domain.User{{ID: 1, Username: "adminuser", Role: domain.RoleAdmin}}
+	userRepo.On("FindUsers", mock.Anything, repository.UserFilters{Role: domain.RoleAdmin, Limit: 20, Offset: 0}).
+		Return(admins, int64(1), nil)
+
+	page, err := service.ListUsers(ctx, domain.RoleAdmin, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, admins, page.Users)
+	assert.Equal(t, int64(1), page.Total)
+
+	_, err = service.ListUsers(ctx, "superuser", 1, 20)
+	assert.EqualError(t, err, "invalid role")
+}
+
+func TestSetUserRole_RevokesSessions(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(repository.MockUserRepository)
+	tokenRepo := new(repository.MockTokenRepository)
+	service := usecase.NewUserService(userRepo, tokenRepo, testAuth)
+
+	userRepo.On("GetUserByID", mock.Anything, uint(7)).Return(&domain.User{ID: 7, Username: "bob", Role: domain.RoleUser}, nil)
+	userRepo.On("UpdateUserRole", mock.Anything, uint(7), domain.RoleEditor).Return(nil)
+	tokenRepo.On("RevokeUserTokens", mock.Anything, uint(7)).Return(nil)
+
+	user, err := service.SetUserRole(ctx, 1, 7, domain.RoleEditor)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoleEditor, user.Role)
+	userRepo.AssertExpectations(t)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestSetUserRole_Errors(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(repository.MockUserRepository)
+	service := usecase.NewUserService(userRepo, new(repository.MockTokenRepository), testAuth)
+
+	userRepo.On("GetUserByID", mock.Anything, uint(9)).Return(nil, nil)
+
+	_, err := service.SetUserRole(ctx, 1, 7, "superuser")
+	assert.EqualError(t, err, "invalid role")
+
+	_, err = service.SetUserRole(ctx, 1, 1, domain.RoleUser)
+	assert.EqualError(t, err, "you cannot change your own role")
+
+	_, err = service.SetUserRole(ctx, 1, 9, domain.RoleEditor)
+	assert.EqualError(t, err, "user not found")
+	userRepo.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenClaims decodes the claims of a signed access token without verifying it.
+func tokenClaims(t *testing.T, tokenString string) jwt.MapClaims {
+	claims := jwt.MapClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(tokenString, claims)
+	assert.NoError(t, err)
+	return claims
+}
+
+func TestRunTokenCleanup(t *testing.T) {
+	mockTokenRepo := new(repository.MockTokenRepository)
+	service := usecase.NewUserService(new(repository.MockUserRepository), mockTokenRepo, testAuth)
+
+	cutoffs := make(chan time.Time, 10)
+	mockTokenRepo.On("DeleteExpiredTokens", mock.Anything, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { cutoffs <- args.Get(1).(time.Time) }).
+		Return(int64(3), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		usecase.RunTokenCleanup(ctx, service, 10*time.Millisecond)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case cutoff := <-cutoffs:
+			assert.WithinDuration(t, time.Now(), cutoff, time.Minute)
+		case <-time.After(time.Second):
+			t.Fatal("expired tokens were not deleted")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("token cleanup did not stop")
+	}
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+)
+
+type WatchlistService interface {
+	ListWatchlists(ctx context.Context, userID uint) ([]domain.Watchlist, error)
+	GetWatchlist(ctx context.Context, id, userID uint) (*domain.Watchlist, error)
+	CreateWatchlist(ctx context.Context, userID uint, data WatchlistData) (*domain.Watchlist, error)
+	UpdateWatchlist(ctx context.Context, id, userID uint, data WatchlistData) (*domain.Watchlist, error)
+	DeleteWatchlist(ctx context.Context, id, userID uint) error
+
+	AddWatchlistItem(ctx context.Context, id, userID, filmID uint, position *int) (*domain.Watchlist, error)
+	MoveWatchlistItem(ctx context.Context, id, userID, filmID uint, position int) (*domain.Watchlist, error)
+	RemoveWatchlistItem(ctx context.Context, id, userID, filmID uint) (*domain.Watchlist, error)
+}
+
+// WatchlistNameMaxLen matches the watchlists.name column
+const WatchlistNameMaxLen = 100
+
+type WatchlistData struct {
+	Name        string
+	Description string
+	Public      bool
+}
+
+type watchlistService struct {
+	watchlistRepo repository.WatchlistRepository
+	filmRepo      repository.FilmRepository
+}
+
+func NewWatchlistService(repo repository.WatchlistRepository, filmRepo repository.FilmRepository) WatchlistService {
+	return &watchlistService{watchlistRepo: repo, filmRepo: filmRepo}
+}
+
+func (s *watchlistService) ListWatchlists(ctx context.Context, userID uint) ([]domain.Watchlist, error) {
+	return s.watchlistRepo.FindWatchlistsByUser(ctx, userID)
+}
+
+// GetWatchlist returns a watchlist owned by userID or a public one. Private
+// watchlists of other users are reported as not found.
+func (s *watchlistService) GetWatchlist(ctx context.Context, id, userID uint) (*domain.Watchlist, error) {
+	watchlist, err := s.watchlistRepo.GetWatchlistByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if watchlist == nil || (!watchlist.Public && watchlist.UserID != userID) {
+		return nil, domain.NotFound("watchlist_not_found", "watchlist not found")
+	}
+	return watchlist, nil
+}
+
+func (s *watchlistService) CreateWatchlist(ctx context.Context, userID uint, data WatchlistData) (*domain.Watchlist, error) {
+	name, err := normalizeWatchlistName(data.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlist := &domain.Watchlist{
+		UserID:      userID,
+		Name:        name,
+		Description: strings.TrimSpace(data.Description),
+		Public:      data.Public,
+		Items:       []domain.WatchlistItem{},
+	}
+	if err := s.watchlistRepo.CreateWatchlist(ctx, watchlist); err != nil {
+		return nil, err
+	}
+	return watchlist, nil
+}
+
+func (s *watchlistService) UpdateWatchlist(ctx context.Context, id, userID uint, data WatchlistData) (*domain.Watchlist, error) {
+	watchlist, err := s.getOwnWatchlist(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlist.Name, err = normalizeWatchlistName(data.Name)
+	if err != nil {
+		return nil, err
+	}
+	watchlist.Description = strings.TrimSpace(data.Description)
+	watchlist.Public = data.Public
+
+	if err := s.watchlistRepo.UpdateWatchlist(ctx, watchlist); err != nil {
+		return nil, err
+	}
+	return watchlist, nil
+}
+
+func (s *watchlistService) DeleteWatchlist(ctx context.Context, id, userID uint) error {
+	if _, err := s.getOwnWatchlist(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.watchlistRepo.DeleteWatchlistByID(ctx, id)
+}
+
+// AddWatchlistItem inserts a film at position, or at the end of the list when position is nil.
+func (s *watchlistService) AddWatchlistItem(ctx context.Context, id, userID, filmID uint, position *int) (*domain.Watchlist, error) {
+	return s.updateOwnWatchlistItems(ctx, id, userID, func(watchlist *domain.Watchlist) error {
+		if watchlistItemIndex(watchlist, filmID) >= 0 {
+			return domain.Conflict("watchlist_item_exists", "film is already in this watchlist")
+		}
+
+		at := len(watchlist.Items)
+		if position != nil {
+			if *position < 0 || *position > len(watchlist.Items) {
+				return domain.Invalid("position", fmt.Sprintf("position must be between 0 and %d", len(watchlist.Items)))
+			}
+			at = *position
+		}
+
+		film, err := s.filmRepo.GetFilmByID(ctx, filmID)
+		if err != nil {
+			return fmt.Errorf("repository error: %w", err)
+		}
+		if film == nil {
+			return domain.NotFound("film_not_found", "film not found")
+		}
+
+		item := domain.WatchlistItem{WatchlistID: id, FilmID: filmID, Film: *film}
+		watchlist.Items = append(watchlist.Items[:at], append([]domain.WatchlistItem{item}, watchlist.Items[at:]...)...)
+		return nil
+	})
+}
+
+func (s *watchlistService) MoveWatchlistItem(ctx context.Context, id, userID, filmID uint, position int) (*domain.Watchlist, error) {
+	return s.updateOwnWatchlistItems(ctx, id, userID, func(watchlist *domain.Watchlist) error {
+		from := watchlistItemIndex(watchlist, filmID)
+		if from < 0 {
+			return domain.NotFound("watchlist_item_not_found", "film is not in this watchlist")
+		}
+		if position < 0 || position >= len(watchlist.Items) {
+			return domain.Invalid("position", fmt.Sprintf("position must be between 0 and %d", len(watchlist.Items)-1))
+		}
+
+		item := watchlist.Items[from]
+		items := append(watchlist.Items[:from:from], watchlist.Items[from+1:]...)
+		watchlist.Items = append(items[:position], append([]domain.WatchlistItem{item}, items[position:]...)...)
+		return nil
+	})
+}
+
+func (s *watchlistService) RemoveWatchlistItem(ctx context.Context, id, userID, filmID uint) (*domain.Watchlist, error) {
+	return s.updateOwnWatchlistItems(ctx, id, userID, func(watchlist *domain.Watchlist) error {
+		at := watchlistItemIndex(watchlist, filmID)
+		if at < 0 {
+			return domain.NotFound("watchlist_item_not_found", "film is not in this watchlist")
+		}
+		watchlist.Items = append(watchlist.Items[:at], watchlist.Items[at+1:]...)
+		return nil
+	})
+}
+
+// updateOwnWatchlistItems applies update to the items of a watchlist userID
+// may modify. The repository runs it with the watchlist locked, so that
+// concurrent changes to the list do not overwrite each other.
+func (s *watchlistService) updateOwnWatchlistItems(ctx context.Context, id, userID uint, update func(watchlist *domain.Watchlist) error) (*domain.Watchlist, error) {
+	notFound := domain.NotFound("watchlist_not_found", "watchlist not found")
+	watchlist, err := s.watchlistRepo.UpdateWatchlistItems(ctx, id, func(watchlist *domain.Watchlist) error {
+		if watchlist.UserID != userID {
+			return notFound
+		}
+		return update(watchlist)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if watchlist == nil {
+		return nil, notFound
+	}
+	return watchlist, nil
+}
+
+// getOwnWatchlist loads a watchlist that userID may modify. Watchlists of
+// other users are reported as not found, even public ones.
+func (s *watchlistService) getOwnWatchlist(ctx context.Context, id, userID uint) (*domain.Watchlist, error) {
+	watchlist, err := s.watchlistRepo.GetWatchlistByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("repository error: %w", err)
+	}
+	if watchlist == nil || watchlist.UserID != userID {
+		return nil, domain.NotFound("watchlist_not_found", "watchlist not found")
+	}
+	return watchlist, nil
+}
+
+func watchlistItemIndex(watchlist *domain.Watchlist, filmID uint) int {
+	for i, item := range watchlist.Items {
+		if item.FilmID == filmID {
+			return i
+		}
+	}
+	return -1
+}
+
+func normalizeWatchlistName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", domain.Invalid("name", "name is required")
+	}
+	if len([]rune(name)) > WatchlistNameMaxLen {
+		return "", domain.Invalid("name", fmt.Sprintf("name must be at most %d characters", WatchlistNameMaxLen))
+	}
+	return name, nil
+}
@@ -0,0 +1,215 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"go-films-api/internal/domain"
+	"go-films-api/internal/repository"
+	"go-films-api/internal/usecase"
+)
+
+// watchlistWithFilms returns a watchlist owned by user 5 holding the given films in order.
+func watchlistWithFilms(public bool, filmIDs ...uint) *domain.Watchlist {
+	watchlist := &domain.Watchlist{ID: 1, UserID: 5, Name: "Weekend", Public: public}
+	for i, id := range filmIDs {
+		watchlist.Items = append(watchlist.Items, domain.WatchlistItem{WatchlistID: 1, FilmID: id, Position: i})
+	}
+	return watchlist
+}
+
+func itemFilmIDs(watchlist *domain.Watchlist) []uint {
+	var ids []uint
+	for _, item := range watchlist.Items {
+		ids = append(ids, item.FilmID)
+	}
+	return ids
+}
+
+func TestGetWatchlist_Visibility(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	service := usecase.NewWatchlistService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetWatchlistByID", mock.Anything, uint(1)).Return(watchlistWithFilms(false), nil).Once()
+	_, err := service.GetWatchlist(ctx, 1, 5)
+	assert.NoError(t, err)
+
+	mockRepo.On("GetWatchlistByID", mock.Anything, uint(1)).Return(watchlistWithFilms(false), nil).Once()
+	_, err = service.GetWatchlist(ctx, 1, 6)
+	assert.EqualError(t, err, "watchlist not found")
+
+	mockRepo.On("GetWatchlistByID", mock.Anything, uint(1)).Return(watchlistWithFilms(true), nil).Once()
+	_, err = service.GetWatchlist(ctx, 1, 6)
+	assert.NoError(t, err)
+}
+
+func TestCreateWatchlist(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	service := usecase.NewWatchlistService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("CreateWatchlist", mock.Anything, mock.AnythingOfType("*domain.Watchlist")).Return(nil)
+
+	watchlist, err := service.CreateWatchlist(ctx, 5, usecase.WatchlistData{Name: "  Weekend   picks ", Public: true})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), watchlist.UserID)
+	assert.Equal(t, "Weekend picks", watchlist.Name)
+	assert.True(t, watchlist.Public)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateWatchlist_InvalidName(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	service := usecase.NewWatchlistService(mockRepo, new(repository.MockFilmRepository))
+
+	_, err := service.CreateWatchlist(ctx, 5, usecase.WatchlistData{Name: " "})
+	assert.EqualError(t, err, "name is required")
+	mockRepo.AssertNotCalled(t, "CreateWatchlist", mock.Anything, mock.Anything)
+}
+
+func TestUpdateWatchlist_OtherUser(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	service := usecase.NewWatchlistService(mockRepo, new(repository.MockFilmRepository))
+
+	// Public watchlists are readable but not editable by other users
+	mockRepo.On("GetWatchlistByID", mock.Anything, uint(1)).Return(watchlistWithFilms(true), nil)
+
+	_, err := service.UpdateWatchlist(ctx, 1, 6, usecase.WatchlistData{Name: "Mine now"})
+	assert.EqualError(t, err, "watchlist not found")
+	mockRepo.AssertNotCalled(t, "UpdateWatchlist", mock.Anything, mock.Anything)
+}
+
+func TestAddWatchlistItem_AtPosition(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewWatchlistService(mockRepo, mockFilmRepo)
+
+	mockRepo.On("UpdateWatchlistItems", mock.Anything, uint(1)).Return(watchlistWithFilms(false, 10, 11, 12), nil)
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(20)).Return(&domain.Film{ID: 20, Title: "Heat"}, nil)
+
+	position := 1
+	watchlist, err := service.AddWatchlistItem(ctx, 1, 5, 20, &position)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{10, 20, 11, 12}, itemFilmIDs(watchlist))
+	assert.Equal(t, "Heat", watchlist.Items[1].Film.Title)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAddWatchlistItem_Append(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewWatchlistService(mockRepo, mockFilmRepo)
+
+	mockRepo.On("UpdateWatchlistItems", mock.Anything, uint(1)).Return(watchlistWithFilms(false, 10), nil)
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(20)).Return(&domain.Film{ID: 20}, nil)
+
+	watchlist, err := service.AddWatchlistItem(ctx, 1, 5, 20, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{10, 20}, itemFilmIDs(watchlist))
+}
+
+func TestAddWatchlistItem_Errors(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	mockFilmRepo := new(repository.MockFilmRepository)
+	service := usecase.NewWatchlistService(mockRepo, mockFilmRepo)
+
+	watchlist := watchlistWithFilms(false, 10, 11)
+	mockRepo.On("UpdateWatchlistItems", mock.Anything, uint(1)).Return(watchlist, nil)
+	mockFilmRepo.On("GetFilmByID", mock.Anything, uint(99)).Return(nil, nil)
+
+	_, err := service.AddWatchlistItem(ctx, 1, 5, 11, nil)
+	assert.EqualError(t, err, "film is already in this watchlist")
+
+	position := 3
+	_, err = service.AddWatchlistItem(ctx, 1, 5, 20, &position)
+	assert.EqualError(t, err, "position must be between 0 and 2")
+
+	_, err = service.AddWatchlistItem(ctx, 1, 5, 99, nil)
+	assert.EqualError(t, err, "film not found")
+
+	// Other users' lists are not theirs to change, even public ones
+	watchlist.Public = true
+	_, err = service.AddWatchlistItem(ctx, 1, 6, 20, nil)
+	assert.EqualError(t, err, "watchlist not found")
+
+	assert.Equal(t, []uint{10, 11}, itemFilmIDs(watchlist))
+}
+
+func TestMoveWatchlistItem(t *testing.T) {
+	ctx := context.Background()
+	cases := map[string]struct {
+		filmID   uint
+		position int
+		want     []uint
+	}{
+		"forward":  {10, 2, []uint{11, 12, 10, 13}},
+		"backward": {13, 0, []uint{13, 10, 11, 12}},
+		"in place": {11, 1, []uint{10, 11, 12, 13}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(repository.MockWatchlistRepository)
+			service := usecase.NewWatchlistService(mockRepo, new(repository.MockFilmRepository))
+
+			mockRepo.On("UpdateWatchlistItems", mock.Anything, uint(1)).Return(watchlistWithFilms(false, 10, 11, 12, 13), nil)
+
+			watchlist, err := service.MoveWatchlistItem(ctx, 1, 5, tc.filmID, tc.position)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, itemFilmIDs(watchlist))
+		})
+	}
+}
+
+func TestMoveWatchlistItem_Errors(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	service := usecase.NewWatchlistService(mockRepo, new(repository.MockFilmRepository))
+
+	watchlist := watchlistWithFilms(false, 10, 11)
+	mockRepo.On("UpdateWatchlistItems", mock.Anything, uint(1)).Return(watchlist, nil)
+	mockRepo.On("UpdateWatchlistItems", mock.Anything, uint(2)).Return(nil, nil)
+
+	_, err := service.MoveWatchlistItem(ctx, 1, 5, 12, 0)
+	assert.EqualError(t, err, "film is not in this watchlist")
+
+	_, err = service.MoveWatchlistItem(ctx, 1, 5, 10, 2)
+	assert.EqualError(t, err, "position must be between 0 and 1")
+
+	_, err = service.MoveWatchlistItem(ctx, 2, 5, 10, 0)
+	assert.EqualError(t, err, "watchlist not found")
+	assert.Equal(t, []uint{10, 11}, itemFilmIDs(watchlist))
+}
+
+func TestRemoveWatchlistItem(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	service := usecase.NewWatchlistService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("UpdateWatchlistItems", mock.Anything, uint(1)).Return(watchlistWithFilms(false, 10, 11, 12), nil)
+
+	watchlist, err := service.RemoveWatchlistItem(ctx, 1, 5, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{10, 12}, itemFilmIDs(watchlist))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeleteWatchlist(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(repository.MockWatchlistRepository)
+	service := usecase.NewWatchlistService(mockRepo, new(repository.MockFilmRepository))
+
+	mockRepo.On("GetWatchlistByID", mock.Anything, uint(1)).Return(watchlistWithFilms(false), nil)
+	mockRepo.On("DeleteWatchlistByID", mock.Anything, uint(1)).Return(nil)
+
+	assert.NoError(t, service.DeleteWatchlist(ctx, 1, 5))
+	mockRepo.AssertExpectations(t)
+}
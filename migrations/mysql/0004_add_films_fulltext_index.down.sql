@@ -0,0 +1 @@
+ALTER TABLE films DROP INDEX ft_films_search;
@@ -0,0 +1 @@
+ALTER TABLE films ADD FULLTEXT INDEX ft_films_search (title, synopsis, `cast`, director);
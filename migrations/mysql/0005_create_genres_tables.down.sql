@@ -0,0 +1,14 @@
+ALTER TABLE films ADD COLUMN genre VARCHAR(50);
+
+-- Films with several genres keep the first one alphabetically
+UPDATE films f SET genre = (
+  SELECT g.name
+  FROM film_genres fg
+  JOIN genres g ON g.id = fg.genre_id
+  WHERE fg.film_id = f.id
+  ORDER BY g.name
+  LIMIT 1
+);
+
+DROP TABLE IF EXISTS film_genres;
+DROP TABLE IF EXISTS genres;
@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS genres (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(50) NOT NULL UNIQUE,
+  slug VARCHAR(50) NOT NULL UNIQUE,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS film_genres (
+  film_id INT NOT NULL,
+  genre_id INT NOT NULL,
+  PRIMARY KEY (film_id, genre_id),
+  FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+  FOREIGN KEY (genre_id) REFERENCES genres(id) ON DELETE CASCADE
+);
+
+-- Convert the free-text films.genre values: every spelling is reduced to a slug
+-- and known variants (e.g. "Sci-Fi", "SciFi", "science fiction") share one genre.
+-- Slugs are built like usecase.Slugify does: lower-cased, with every run of
+-- characters other than letters and digits turned into a single dash
+CREATE TEMPORARY TABLE genre_conversion AS
+SELECT raw,
+  CASE slug
+    WHEN 'scifi' THEN 'science-fiction'
+    WHEN 'sci-fi' THEN 'science-fiction'
+    WHEN 'sf' THEN 'science-fiction'
+    WHEN 'romcom' THEN 'romantic-comedy'
+    WHEN 'rom-com' THEN 'romantic-comedy'
+    WHEN 'doc' THEN 'documentary'
+    WHEN 'docu' THEN 'documentary'
+    WHEN 'animated' THEN 'animation'
+    ELSE slug
+  END AS slug
+FROM (
+  SELECT DISTINCT genre AS raw,
+    TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(genre), '[^\\p{L}\\p{Nd}]+', '-')) AS slug
+  FROM films
+  WHERE genre REGEXP '[\\p{L}\\p{Nd}]'
+) AS raw_genres;
+
+INSERT INTO genres (name, slug)
+SELECT
+  CASE slug
+    WHEN 'science-fiction' THEN 'Science Fiction'
+    WHEN 'romantic-comedy' THEN 'Romantic Comedy'
+    WHEN 'documentary' THEN 'Documentary'
+    WHEN 'animation' THEN 'Animation'
+    ELSE MIN(TRIM(raw))
+  END,
+  slug
+FROM genre_conversion
+GROUP BY slug;
+
+INSERT INTO film_genres (film_id, genre_id)
+SELECT f.id, g.id
+FROM films f
+JOIN genre_conversion c ON c.raw = f.genre
+JOIN genres g ON g.slug = c.slug;
+
+DROP TEMPORARY TABLE genre_conversion;
+
+ALTER TABLE films DROP COLUMN genre;
@@ -0,0 +1,21 @@
+ALTER TABLE films DROP INDEX ft_films_search;
+ALTER TABLE films ADD COLUMN director VARCHAR(100), ADD COLUMN `cast` TEXT;
+
+UPDATE films f SET
+  director = (
+    SELECT LEFT(GROUP_CONCAT(p.name ORDER BY fc.billing_order, fc.id SEPARATOR ', '), 100)
+    FROM film_credits fc
+    JOIN people p ON p.id = fc.person_id
+    WHERE fc.film_id = f.id AND fc.role = 'director'
+  ),
+  `cast` = (
+    SELECT GROUP_CONCAT(p.name ORDER BY fc.billing_order, fc.id SEPARATOR ', ')
+    FROM film_credits fc
+    JOIN people p ON p.id = fc.person_id
+    WHERE fc.film_id = f.id AND fc.role = 'actor'
+  );
+
+ALTER TABLE films ADD FULLTEXT INDEX ft_films_search (title, synopsis, `cast`, director);
+
+DROP TABLE IF EXISTS film_credits;
+DROP TABLE IF EXISTS people;
@@ -0,0 +1,62 @@
+CREATE TABLE IF NOT EXISTS people (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  INDEX idx_people_name (name),
+  FULLTEXT INDEX ft_people_name (name)
+);
+
+CREATE TABLE IF NOT EXISTS film_credits (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  film_id INT NOT NULL,
+  person_id INT NOT NULL,
+  role VARCHAR(20) NOT NULL,
+  character_name VARCHAR(255),
+  billing_order INT NOT NULL DEFAULT 0,
+  INDEX idx_film_credits_film (film_id),
+  INDEX idx_film_credits_person (person_id, role),
+  FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+  FOREIGN KEY (person_id) REFERENCES people(id) ON DELETE CASCADE
+);
+
+-- Directors: one person per distinct name
+INSERT INTO people (name)
+SELECT DISTINCT TRIM(director)
+FROM films
+WHERE director IS NOT NULL AND TRIM(director) <> '';
+
+-- Cast: split the comma-separated list, keeping its order as billing order
+CREATE TEMPORARY TABLE cast_split AS
+SELECT f.id AS film_id, TRIM(jt.name) AS name, jt.ord AS ord
+FROM films f,
+  JSON_TABLE(
+    CONCAT('["', REPLACE(REPLACE(REPLACE(f.`cast`, '\\', '\\\\'), '"', '\\"'), ',', '","'), '"]'),
+    '$[*]' COLUMNS (ord FOR ORDINALITY, name VARCHAR(255) PATH '$')
+  ) AS jt
+WHERE f.`cast` IS NOT NULL AND TRIM(f.`cast`) <> '';
+
+DELETE FROM cast_split WHERE name = '';
+
+INSERT INTO people (name)
+SELECT DISTINCT c.name
+FROM cast_split c
+WHERE NOT EXISTS (SELECT 1 FROM people p WHERE p.name = c.name);
+
+INSERT INTO film_credits (film_id, person_id, role, billing_order)
+SELECT f.id, p.id, 'director', 0
+FROM films f
+JOIN people p ON p.name = TRIM(f.director)
+WHERE f.director IS NOT NULL AND TRIM(f.director) <> '';
+
+INSERT INTO film_credits (film_id, person_id, role, billing_order)
+SELECT c.film_id, p.id, 'actor', c.ord
+FROM cast_split c
+JOIN people p ON p.name = c.name;
+
+DROP TEMPORARY TABLE cast_split;
+
+-- Cast and director are searched through ft_people_name from now on
+ALTER TABLE films DROP INDEX ft_films_search;
+ALTER TABLE films DROP COLUMN director, DROP COLUMN `cast`;
+ALTER TABLE films ADD FULLTEXT INDEX ft_films_search (title, synopsis);
@@ -0,0 +1,7 @@
+ALTER TABLE films
+  DROP INDEX idx_films_review_count,
+  DROP INDEX idx_films_average_rating,
+  DROP COLUMN review_count,
+  DROP COLUMN average_rating;
+
+DROP TABLE IF EXISTS reviews;
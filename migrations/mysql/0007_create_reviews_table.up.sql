@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS reviews (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  film_id INT NOT NULL,
+  user_id INT NOT NULL,
+  rating TINYINT NOT NULL,
+  body TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE INDEX uq_reviews_film_user (film_id, user_id),
+  INDEX idx_reviews_user (user_id),
+  CHECK (rating BETWEEN 1 AND 10),
+  FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Aggregates are kept on films so the list can be sorted by them without a join
+ALTER TABLE films
+  ADD COLUMN average_rating DECIMAL(4,2) NOT NULL DEFAULT 0,
+  ADD COLUMN review_count INT NOT NULL DEFAULT 0,
+  ADD INDEX idx_films_average_rating (average_rating),
+  ADD INDEX idx_films_review_count (review_count);
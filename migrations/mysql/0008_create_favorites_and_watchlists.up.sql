@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS favorites (
+  user_id INT NOT NULL,
+  film_id INT NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id, film_id),
+  INDEX idx_favorites_user_created (user_id, created_at),
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS watchlists (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  description TEXT,
+  public BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  INDEX idx_watchlists_user (user_id),
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS watchlist_items (
+  watchlist_id INT NOT NULL,
+  film_id INT NOT NULL,
+  position INT NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (watchlist_id, film_id),
+  INDEX idx_watchlist_items_position (watchlist_id, position),
+  FOREIGN KEY (watchlist_id) REFERENCES watchlists(id) ON DELETE CASCADE,
+  FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL,
+  family_id CHAR(32) NOT NULL,
+  token_hash CHAR(64) NOT NULL,
+  access_token_id CHAR(32) NOT NULL,
+  access_token_expires_at DATETIME NOT NULL,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME NULL,
+  revoked_at DATETIME NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE KEY uq_refresh_tokens_hash (token_hash),
+  INDEX idx_refresh_tokens_family (family_id),
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+  jti CHAR(32) PRIMARY KEY,
+  expires_at DATETIME NOT NULL,
+  INDEX idx_revoked_tokens_expires (expires_at)
+);
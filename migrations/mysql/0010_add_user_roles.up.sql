@@ -0,0 +1,6 @@
+ALTER TABLE users
+  ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' AFTER password,
+  ADD INDEX idx_users_role (role);
+
+-- The seeded admin account becomes the first administrator
+UPDATE users SET role = 'admin' WHERE username = 'adminuser';
@@ -0,0 +1 @@
+ALTER TABLE films DROP COLUMN version;
@@ -0,0 +1,2 @@
+-- Optimistic locking: every update increments the version served as ETag
+ALTER TABLE films ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER synopsis;
@@ -0,0 +1,3 @@
+DELETE FROM films WHERE deleted_at IS NOT NULL;
+DROP INDEX idx_films_deleted_at ON films;
+ALTER TABLE films DROP COLUMN deleted_at;
@@ -0,0 +1,3 @@
+-- Soft delete: trashed films keep their row, and their title, until purged
+ALTER TABLE films ADD COLUMN deleted_at DATETIME NULL AFTER updated_at;
+CREATE INDEX idx_films_deleted_at ON films (deleted_at);
@@ -0,0 +1,13 @@
+-- No foreign key on film_id: the history of a film survives its purge
+CREATE TABLE IF NOT EXISTS audit_events (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  film_id INT NOT NULL,
+  action VARCHAR(20) NOT NULL,
+  actor_id INT NULL,
+  changes JSON NULL,
+  created_at DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  INDEX idx_audit_events_film (film_id, created_at),
+  INDEX idx_audit_events_actor (actor_id, created_at),
+  INDEX idx_audit_events_created (created_at),
+  FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS film_images (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  film_id INT NOT NULL,
+  kind VARCHAR(20) NOT NULL,
+  content_type VARCHAR(50) NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  size BIGINT NOT NULL,
+  `key` VARCHAR(255) NOT NULL,
+  url VARCHAR(1024) NOT NULL,
+  thumbnails JSON NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  INDEX idx_film_images_film (film_id),
+  FOREIGN KEY (film_id) REFERENCES films(id) ON DELETE CASCADE
+);
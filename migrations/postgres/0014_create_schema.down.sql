@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS film_images;
+DROP TABLE IF EXISTS audit_events;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS watchlist_items;
+DROP TABLE IF EXISTS watchlists;
+DROP TABLE IF EXISTS favorites;
+DROP TABLE IF EXISTS reviews;
+DROP TABLE IF EXISTS film_credits;
+DROP TABLE IF EXISTS people;
+DROP TABLE IF EXISTS film_genres;
+DROP TABLE IF EXISTS genres;
+DROP TABLE IF EXISTS films;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,193 @@
+-- SQLite starts from the schema MySQL reached with migration 0014, so that
+-- later migrations share their version numbers across drivers. Times are
+-- stored as UTC text ("2006-01-02 15:04:05.999999999+00:00"), which sorts correctly.
+CREATE TABLE IF NOT EXISTS users (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  username VARCHAR(50) NOT NULL UNIQUE,
+  password VARCHAR(100) NOT NULL,
+  role VARCHAR(20) NOT NULL DEFAULT 'user',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_users_role ON users (role);
+
+CREATE TABLE IF NOT EXISTS films (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL REFERENCES users(id),
+  title VARCHAR(255) NOT NULL UNIQUE,
+  release_date DATE,
+  synopsis TEXT,
+  version INTEGER NOT NULL DEFAULT 1 CHECK (version >= 0),
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  deleted_at DATETIME NULL,
+  average_rating NUMERIC(4,2) NOT NULL DEFAULT 0,
+  review_count INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_films_deleted_at ON films (deleted_at);
+CREATE INDEX idx_films_average_rating ON films (average_rating);
+CREATE INDEX idx_films_review_count ON films (review_count);
+
+CREATE TABLE IF NOT EXISTS genres (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(50) NOT NULL UNIQUE,
+  slug VARCHAR(50) NOT NULL UNIQUE,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS film_genres (
+  film_id INTEGER NOT NULL REFERENCES films(id) ON DELETE CASCADE,
+  genre_id INTEGER NOT NULL REFERENCES genres(id) ON DELETE CASCADE,
+  PRIMARY KEY (film_id, genre_id)
+);
+
+CREATE TABLE IF NOT EXISTS people (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(255) NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_people_name ON people (name);
+
+CREATE TABLE IF NOT EXISTS film_credits (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  film_id INTEGER NOT NULL REFERENCES films(id) ON DELETE CASCADE,
+  person_id INTEGER NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+  role VARCHAR(20) NOT NULL,
+  character_name VARCHAR(255),
+  billing_order INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_film_credits_film ON film_credits (film_id);
+CREATE INDEX idx_film_credits_person ON film_credits (person_id, role);
+
+CREATE TABLE IF NOT EXISTS reviews (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  film_id INTEGER NOT NULL REFERENCES films(id) ON DELETE CASCADE,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 10),
+  body TEXT,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (film_id, user_id)
+);
+CREATE INDEX idx_reviews_user ON reviews (user_id);
+
+CREATE TABLE IF NOT EXISTS favorites (
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  film_id INTEGER NOT NULL REFERENCES films(id) ON DELETE CASCADE,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id, film_id)
+);
+CREATE INDEX idx_favorites_user_created ON favorites (user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS watchlists (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  description TEXT,
+  public BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_watchlists_user ON watchlists (user_id);
+
+CREATE TABLE IF NOT EXISTS watchlist_items (
+  watchlist_id INTEGER NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+  film_id INTEGER NOT NULL REFERENCES films(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (watchlist_id, film_id)
+);
+CREATE INDEX idx_watchlist_items_position ON watchlist_items (watchlist_id, position);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  family_id CHAR(32) NOT NULL,
+  token_hash CHAR(64) NOT NULL UNIQUE,
+  access_token_id CHAR(32) NOT NULL,
+  access_token_expires_at DATETIME NOT NULL,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME NULL,
+  revoked_at DATETIME NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_refresh_tokens_family ON refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+  jti CHAR(32) PRIMARY KEY,
+  expires_at DATETIME NOT NULL
+);
+CREATE INDEX idx_revoked_tokens_expires ON revoked_tokens (expires_at);
+
+-- No foreign key on film_id: the history of a film survives its purge
+CREATE TABLE IF NOT EXISTS audit_events (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  film_id INTEGER NOT NULL,
+  action VARCHAR(20) NOT NULL,
+  actor_id INTEGER NULL REFERENCES users(id) ON DELETE SET NULL,
+  changes TEXT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_audit_events_film ON audit_events (film_id, created_at);
+CREATE INDEX idx_audit_events_actor ON audit_events (actor_id, created_at);
+CREATE INDEX idx_audit_events_created ON audit_events (created_at);
+
+CREATE TABLE IF NOT EXISTS film_images (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  film_id INTEGER NOT NULL REFERENCES films(id) ON DELETE CASCADE,
+  kind VARCHAR(20) NOT NULL,
+  content_type VARCHAR(50) NOT NULL,
+  width INTEGER NOT NULL,
+  height INTEGER NOT NULL,
+  size INTEGER NOT NULL,
+  "key" VARCHAR(255) NOT NULL,
+  url VARCHAR(1024) NOT NULL,
+  thumbnails TEXT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_film_images_film ON film_images (film_id);
+
+-- The same example data as the MySQL seed
+INSERT INTO users (username, password, role)
+VALUES
+  ('adminuser', '$2a$10$7n6jlWeU62A7NRyxMVclzuRek62Ar9AYZf6XV4A8b9T.MPYsW8LfG', 'admin'),
+  ('testuser', '$2a$10$0i05/M4YX7ikbxFs6//voO0I5oQ0HqlTR7Zhl6hXUDwe31QyoZmii', 'user');
+
+INSERT INTO films (user_id, title, release_date, synopsis)
+SELECT u.id, f.column2, f.column3, f.column4
+FROM (VALUES
+  ('adminuser', 'First Admin Film', '2023-01-01 00:00:00+00:00', 'An action-packed admin film.'),
+  ('testuser', 'Testuser Film', '2023-02-01 00:00:00+00:00', 'A dramatic test film.'),
+  ('testuser', 'Another Testuser Film', '2023-03-01 00:00:00+00:00', 'A comedic test film.')
+) AS f
+JOIN users u ON u.username = f.column1;
+
+INSERT INTO genres (name, slug)
+VALUES ('Action', 'action'), ('Drama', 'drama'), ('Comedy', 'comedy');
+
+INSERT INTO film_genres (film_id, genre_id)
+SELECT f.id, g.id
+FROM (VALUES
+  ('First Admin Film', 'action'),
+  ('Testuser Film', 'drama'),
+  ('Another Testuser Film', 'comedy')
+) AS fg
+JOIN films f ON f.title = fg.column1
+JOIN genres g ON g.slug = fg.column2;
+
+INSERT INTO people (name)
+VALUES ('Admin Director'), ('Test Director'), ('Test Director 2'),
+  ('Sample Cast A'), ('Sample Cast B'), ('Sample Cast C');
+
+INSERT INTO film_credits (film_id, person_id, role, billing_order)
+SELECT f.id, p.id, c.column3, c.column4
+FROM (VALUES
+  ('First Admin Film', 'Admin Director', 'director', 0),
+  ('First Admin Film', 'Sample Cast A', 'actor', 1),
+  ('Testuser Film', 'Test Director', 'director', 0),
+  ('Testuser Film', 'Sample Cast B', 'actor', 1),
+  ('Another Testuser Film', 'Test Director 2', 'director', 0),
+  ('Another Testuser Film', 'Sample Cast C', 'actor', 1)
+) AS c
+JOIN films f ON f.title = c.column1
+JOIN people p ON p.name = c.column2;
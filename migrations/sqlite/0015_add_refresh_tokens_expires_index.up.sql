@@ -0,0 +1,2 @@
+-- Expired refresh tokens are deleted every hour
+CREATE INDEX idx_refresh_tokens_expires ON refresh_tokens (expires_at);